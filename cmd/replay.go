@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Play back a session recorded with --record",
+	Long:  `Plays back in the terminal a session recorded with "eye --record", an asciicast v2 file that asciinema can also play.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		speed, _ := cmd.Flags().GetFloat64("speed")
+		idle, _ := cmd.Flags().GetDuration("idle-limit")
+		if err := tui.Replay(cmd.Context(), file, cmd.OutOrStdout(), tui.WithSpeed(speed), tui.WithIdleLimit(idle)); err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout())
+		return nil
+	},
+}
+
+func init() {
+	replayCmd.Flags().Float64("speed", 1, "playback speed multiplier")
+	replayCmd.Flags().Duration("idle-limit", 2*time.Second, "longest pause between frames (0 keeps the recorded pauses)")
+	rootCmd.AddCommand(replayCmd)
+}
@@ -1,34 +1,134 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
 
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
 	"github.com/DippingCode/easyenv/pkg/core/ui/shell"
-	"github.com/spf13/cobra"
+	"github.com/DippingCode/easyenv/pkg/modules/version"
+	configservice "github.com/DippingCode/easyenv/pkg/services/config"
 )
 
+// exitInterrupted is the conventional exit code for a program stopped by SIGINT.
+const exitInterrupted = 130
+
 var rootCmd = &cobra.Command{
 	Use:   "eye",
 	Short: "EasyEnv.io - Gerenciador de ambiente de desenvolvimento",
-	Long:  `A TUI interativa para gerenciar seus ambientes de desenvolvimento.`, 
+	Long:  `A TUI interativa para gerenciar seus ambientes de desenvolvimento.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		appShell := shell.New()
+		if err := loadKeybindings(); err != nil {
+			return err
+		}
 
-		if _, err := tui.Run(appShell, tui.WithAltScreen(), tui.WithMouseCellMotion()); err != nil {
-			fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		if noTUI || !tui.IsInteractive(cmd.OutOrStdout()) {
+			if recordPath != "" {
+				return errors.New("--record needs the full-screen UI")
+			}
+			root := shell.New()
+			defer root.Dispose()
+			_, err := tui.RunPlain(cmd.Context(), root, cmd.InOrStdin(), cmd.OutOrStdout())
 			return err
 		}
-		return nil
+
+		opts := []tui.ProgramOption{
+			tui.WithAltScreen(),
+			tui.WithMouseCellMotion(),
+			tui.WithInput(cmd.InOrStdin()),
+			tui.WithOutput(cmd.OutOrStdout()),
+		}
+		if recordPath != "" {
+			file, err := os.Create(recordPath)
+			if err != nil {
+				return fmt.Errorf("recording session: %w", err)
+			}
+			defer file.Close()
+			rec := tui.NewRecorder(file, "eye")
+			defer func() {
+				if err := rec.Close(); err != nil {
+					fmt.Fprintf(cmd.ErrOrStderr(), "recording session: %v\n", err)
+				}
+			}()
+			opts = append(opts, tui.WithRecorder(rec))
+		}
+
+		root := shell.New()
+		defer root.Dispose()
+		program := tui.NewProgram(root, opts...)
+		if err := program.Start(cmd.Context()); err != nil {
+			return err
+		}
+		_, err := program.Wait()
+		return err
 	},
+}
+
+var (
+	// noTUI forces the plain, line-oriented renderer even on a terminal.
+	noTUI bool
+	// recordPath is the asciicast file the session is recorded to.
+	recordPath string
+)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noTUI, "no-tui", false,
+		"plain line-oriented output instead of the full-screen UI (implied when stdout is not a terminal or TERM=dumb)")
+	rootCmd.Flags().StringVar(&recordPath, "record", "",
+		"record the session to an asciicast v2 `file` (play it with eye replay)")
 
+	rootCmd.AddCommand(version.GetRouter())
 }
 
 // Execute is the main entry point for the cobra CLI.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+
+	if err != nil {
 		// Cobra already prints the error, so we just exit.
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// loadKeybindings applies the user's key overrides from the config file and
+// checks the resulting keymap for keys bound to several actions.
+func loadKeybindings() error {
+	manager, err := configservice.NewFileConfigManager()
+	if err != nil {
+		// No config directory: keep the default bindings.
+		return nil
+	}
+	prefs, err := manager.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	path, _ := manager.GetFilePath()
+	if err := tui.Keys.Load(prefs.Keybindings); err != nil {
+		return fmt.Errorf("%s: keybindings: %w", path, err)
+	}
+
+	if conflicts := tui.Keys.Conflicts(); len(conflicts) > 0 {
+		errs := make([]error, len(conflicts))
+		for i, c := range conflicts {
+			errs[i] = errors.New(c.String())
+		}
+		return fmt.Errorf("%s: conflicting keybindings:\n%w", path, errors.Join(errs...))
+	}
+	return nil
+}
+
+// exitCode maps the error that stopped the CLI to a process exit code.
+func exitCode(err error) int {
+	if errors.Is(err, tui.ErrInterrupted) || errors.Is(err, context.Canceled) {
+		return exitInterrupted
 	}
+	return 1
 }
@@ -28,7 +28,9 @@ func Batch(cmds ...Cmd) Cmd {
 func toTeaCmds(cmds []Cmd) []tea.Cmd {
 	var teaCmds []tea.Cmd
 	for _, cmd := range cmds {
-		teaCmds = append(teaCmds, toTeaCmd(cmd))
+		if cmd != nil {
+			teaCmds = append(teaCmds, toTeaCmd(cmd))
+		}
 	}
 	return teaCmds
 }
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	lg "github.com/charmbracelet/lipgloss"
+	bt "github.com/evertras/bubble-table/table"
+)
+
+// bubble-table takes lipgloss styles for its columns, cells, rows and
+// borders. These helpers hand it Styles instead, so widgets built on it
+// never deal with lipgloss.
+
+// TableColumn creates a bubble-table column of width content cells with
+// its cells aligned to align. A width of 0 makes it a flex column taking
+// flex shares of the width left by the others.
+func TableColumn(key, title string, width, flex int, align Position) bt.Column {
+	var column bt.Column
+	if width > 0 {
+		column = bt.NewColumn(key, title, width)
+	} else {
+		column = bt.NewFlexColumn(key, title, flex)
+	}
+	return column.WithStyle(lg.NewStyle().Align(lg.Position(align)))
+}
+
+// TableCell returns value drawn with style in a bubble-table row. A nil
+// style leaves the value to the row's style.
+func TableCell(value any, style Style) any {
+	if style == nil {
+		return value
+	}
+	return bt.NewStyledCell(value, style.GetLipglossStyle())
+}
+
+// TableRowStyle adapts a function styling the rows of a table to
+// bubble-table.
+func TableRowStyle(style func(in bt.RowStyleFuncInput) Style) func(bt.RowStyleFuncInput) lg.Style {
+	return func(in bt.RowStyleFuncInput) lg.Style {
+		return style(in).GetLipglossStyle()
+	}
+}
+
+// StyleTable returns t drawn with base as its base style, header for its
+// header, and rounded or square borders.
+func StyleTable(t bt.Model, base, header Style, rounded bool) bt.Model {
+	t = t.WithBaseStyle(base.GetLipglossStyle()).HeaderStyle(header.GetLipglossStyle())
+	if rounded {
+		return t.BorderRounded()
+	}
+	return t.BorderDefault()
+}
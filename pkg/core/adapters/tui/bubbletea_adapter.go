@@ -2,8 +2,8 @@
 package tui
 
 import (
-	"fmt"
-	"os"
+	"context"
+	"io"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -11,40 +11,56 @@ import (
 // --- Public API ---
 
 // ProgramOption is a functional option for configuring the TUI program.
-// It's a wrapper around bubbletea's ProgramOption.
-type ProgramOption func() tea.ProgramOption
+// Most options wrap one of bubbletea's ProgramOptions.
+type ProgramOption func(*programConfig)
+
+// programConfig collects the options of a program.
+type programConfig struct {
+	tea      []tea.ProgramOption
+	recorder *Recorder
+}
+
+// teaOption wraps a bubbletea option.
+func teaOption(opt tea.ProgramOption) ProgramOption {
+	return func(c *programConfig) { c.tea = append(c.tea, opt) }
+}
 
 // WithAltScreen is an option to run the program in the alternate screen buffer.
 func WithAltScreen() ProgramOption {
-	return tea.WithAltScreen
+	return teaOption(tea.WithAltScreen())
 }
 
 // WithMouseCellMotion is an option to enable mouse motion tracking.
 func WithMouseCellMotion() ProgramOption {
-	return tea.WithMouseCellMotion
+	return teaOption(tea.WithMouseCellMotion())
 }
 
-// Run starts and runs the TUI program for the given model.
-func Run(model Model, opts ...ProgramOption) (Model, error) {
-	// Convert our generic options to bubbletea options
-	teaOpts := make([]tea.ProgramOption, len(opts))
-	for i, opt := range opts {
-		teaOpts[i] = opt()
-	}
+// WithInput sets the stream the program reads input from.
+// Defaults to os.Stdin.
+func WithInput(r io.Reader) ProgramOption {
+	return teaOption(tea.WithInput(r))
+}
 
-	// Create the bubbletea program with our adapter
-	p := tea.NewProgram(&modelAdapter{inner: model}, teaOpts...)
+// WithOutput sets the stream the program renders to.
+// Defaults to os.Stdout.
+func WithOutput(w io.Writer) ProgramOption {
+	return teaOption(tea.WithOutput(w))
+}
 
-	// Run the program
-	finalModel, err := p.Run()
-	if err != nil {
-		fmt.Printf("Error running program: %v\n", err)
-		os.Exit(1)
-		return nil, err // Should not be reached
-	}
+// WithRecorder records the session, every rendered frame and input event,
+// with rec. The caller closes rec once the program has finished.
+func WithRecorder(rec *Recorder) ProgramOption {
+	return func(c *programConfig) { c.recorder = rec }
+}
 
-	// Return the final inner model
-	return finalModel.(*modelAdapter).inner, nil
+// Run starts the TUI program for the given model and blocks until it exits.
+// It returns the final model and any error that stopped the program.
+func Run(model Model, opts ...ProgramOption) (Model, error) {
+	p := NewProgram(model, opts...)
+	if err := p.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	return p.Wait()
 }
 
 // --- Adapter Internals ---
@@ -52,7 +68,8 @@ func Run(model Model, opts ...ProgramOption) (Model, error) {
 // modelAdapter wraps our generic tui.Model to make it compatible with bubbletea.
 // It implements the tea.Model interface.
 type modelAdapter struct {
-	inner Model
+	inner    Model
+	recorder *Recorder // nil when the session is not recorded
 }
 
 // Init translates the Init call.
@@ -64,6 +81,7 @@ func (a *modelAdapter) Init() tea.Cmd {
 func (a *modelAdapter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Translate incoming message from bubbletea to our generic message
 	translatedMsg := fromTeaMsg(msg)
+	a.recorder.record(translatedMsg)
 
 	// Call the inner model's Update function
 	newInnerModel, newCmd := a.inner.Update(translatedMsg)
@@ -71,13 +89,22 @@ func (a *modelAdapter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// Update the inner model
 	a.inner = newInnerModel
 
+	// Mouse events are also routed to the zone drawn under the pointer,
+	// so Clickable widgets get their command run.
+	if mouseMsg, ok := translatedMsg.(MouseMsg); ok {
+		newCmd = Batch(newCmd, Zones.HandleMouse(mouseMsg))
+	}
+
 	// Return the adapter and the translated command
 	return a, toTeaCmd(newCmd)
 }
 
-// View translates the View call.
+// View translates the View call. Zone markers are recorded and stripped
+// here, so the terminal never sees them.
 func (a *modelAdapter) View() string {
-	return a.inner.View()
+	frame := Zones.Scan(a.inner.View())
+	a.recorder.frame(frame)
+	return frame
 }
 
 // --- Type Translation Helpers ---
@@ -91,13 +118,23 @@ func toTeaCmd(cmd Cmd) tea.Cmd {
 		// When the command is run, it produces a generic Msg.
 		msg := cmd()
 
-		// If the message is a batch message, we unpack it and convert it to a tea.Batch.
-		if b, ok := msg.(BatchMsg); ok {
-			return tea.Batch(toTeaCmds(b)...)
+		switch msg := msg.(type) {
+		case BatchMsg:
+			// Batches run their commands concurrently.
+			return tea.BatchMsg(toTeaCmds(msg))
+		case SequenceMsg:
+			// Sequences run their commands one after another.
+			return tea.Sequence(toTeaCmds(msg)...)()
+		case QuitMsg:
+			return tea.Quit()
+		case ExecMsg:
+			// bubbletea releases the terminal, runs the process and
+			// restores the UI before delivering the exit message.
+			return tea.Exec(msg.Command, func(err error) tea.Msg { return msg.OnExit(err) })()
+		default:
+			// Otherwise, we return the message directly.
+			return msg
 		}
-
-		// Otherwise, we return the message directly.
-		return msg
 	}
 }
 
@@ -107,36 +144,148 @@ func fromTeaMsg(msg tea.Msg) Msg {
 	case tea.WindowSizeMsg:
 		return WindowSizeMsg{Width: msg.Width, Height: msg.Height}
 	case tea.KeyMsg:
-		return KeyMsg{
-			Type:  fromTeaKeyType(msg.Type),
-			Runes: msg.Runes,
-			Alt:   msg.Alt,
+		if msg.Paste {
+			return PasteMsg{Text: string(msg.Runes)}
 		}
+		return fromTeaKey(msg)
+	case tea.MouseMsg:
+		return fromTeaMouse(msg)
 	default:
 		// For any other message type, we pass it through directly.
 		return msg
 	}
 }
 
-// fromTeaKeyType converts a bubbletea key type to our generic key type.
-func fromTeaKeyType(t tea.KeyType) KeyType {
-	switch t {
-	case tea.KeyRunes: return KeyRunes
-	case tea.KeySpace: return KeySpace
-	case tea.KeyBackspace: return KeyBackspace
-	case tea.KeyDelete: return KeyDelete
-	case tea.KeyEnter: return KeyEnter
-	case tea.KeyEscape: return KeyEsc
-	case tea.KeyTab: return KeyTab
-	case tea.KeyUp: return KeyUp
-	case tea.KeyDown: return KeyDown
-	case tea.KeyRight: return KeyRight
-	case tea.KeyLeft: return KeyLeft
-	case tea.KeyCtrlC: return KeyCtrlC
-	case tea.KeyCtrlD: return KeyCtrlD
+// fromTeaKey converts a bubbletea key press to our generic key message,
+// splitting bubbletea's combined key types into a base key plus modifiers.
+func fromTeaKey(msg tea.KeyMsg) KeyMsg {
+	key, ok := teaKeys[msg.Type]
+	if !ok {
+		key = fromTeaCtrlKey(msg.Type)
+	}
+	if key.Type == KeyRunes && key.Runes == nil {
+		key.Runes = msg.Runes
+	}
+	// Neither bubbletea's slice nor the table's may be shared: a receiver
+	// editing its key's runes would change every other key.
+	if key.Runes != nil {
+		key.Runes = append([]rune(nil), key.Runes...)
+	}
+	key.Alt = key.Alt || msg.Alt
+	return key
+}
+
+// fromTeaCtrlKey converts the bubbletea control characters (ctrl+a to ctrl+_)
+// into a rune key with the Ctrl modifier. Anything else becomes a plain rune key.
+func fromTeaCtrlKey(t tea.KeyType) KeyMsg {
+	switch {
+	case t >= tea.KeyCtrlA && t <= tea.KeyCtrlZ:
+		return KeyMsg{Type: KeyRunes, Runes: []rune{rune('a' + t - tea.KeyCtrlA)}, Ctrl: true}
+	case t == tea.KeyCtrlAt:
+		return KeyMsg{Type: KeyRunes, Runes: []rune{'@'}, Ctrl: true}
+	case t >= tea.KeyCtrlBackslash && t <= tea.KeyCtrlUnderscore:
+		return KeyMsg{Type: KeyRunes, Runes: []rune{rune('\\' + t - tea.KeyCtrlBackslash)}, Ctrl: true}
 	default:
-		// For simplicity, we can return a known type for unhandled keys.
-		// A more robust implementation could expand the KeyType enum.
-		return KeyRunes
+		return KeyMsg{Type: KeyRunes}
 	}
 }
+
+// fromTeaMouse converts a bubbletea mouse event to our generic mouse message.
+func fromTeaMouse(msg tea.MouseMsg) MouseMsg {
+	return MouseMsg{
+		X:      msg.X,
+		Y:      msg.Y,
+		Action: teaMouseActions[msg.Action],
+		Button: teaMouseButtons[msg.Button],
+		Alt:    msg.Alt,
+		Ctrl:   msg.Ctrl,
+		Shift:  msg.Shift,
+	}
+}
+
+var teaMouseActions = map[tea.MouseAction]MouseAction{
+	tea.MouseActionPress:   MouseActionPress,
+	tea.MouseActionRelease: MouseActionRelease,
+	tea.MouseActionMotion:  MouseActionMotion,
+}
+
+var teaMouseButtons = map[tea.MouseButton]MouseButton{
+	tea.MouseButtonNone:       MouseButtonNone,
+	tea.MouseButtonLeft:       MouseButtonLeft,
+	tea.MouseButtonMiddle:     MouseButtonMiddle,
+	tea.MouseButtonRight:      MouseButtonRight,
+	tea.MouseButtonWheelUp:    MouseButtonWheelUp,
+	tea.MouseButtonWheelDown:  MouseButtonWheelDown,
+	tea.MouseButtonWheelLeft:  MouseButtonWheelLeft,
+	tea.MouseButtonWheelRight: MouseButtonWheelRight,
+	tea.MouseButtonBackward:   MouseButtonBackward,
+	tea.MouseButtonForward:    MouseButtonForward,
+}
+
+// teaKeys maps bubbletea's named keys to our key types and modifiers.
+// Control characters that double as named keys (tab, enter, esc, backspace)
+// are listed here so they win over the ctrl+letter translation.
+var teaKeys = map[tea.KeyType]KeyMsg{
+	tea.KeyRunes:     {Type: KeyRunes},
+	tea.KeySpace:     {Type: KeySpace, Runes: []rune{' '}},
+	tea.KeyBackspace: {Type: KeyBackspace},
+	tea.KeyDelete:    {Type: KeyDelete},
+	tea.KeyInsert:    {Type: KeyInsert},
+	tea.KeyEnter:     {Type: KeyEnter},
+	tea.KeyEsc:       {Type: KeyEsc},
+	tea.KeyTab:       {Type: KeyTab},
+	tea.KeyShiftTab:  {Type: KeyTab, Shift: true},
+	tea.KeyCtrlC:     {Type: KeyCtrlC, Runes: []rune{'c'}, Ctrl: true},
+	tea.KeyCtrlD:     {Type: KeyCtrlD, Runes: []rune{'d'}, Ctrl: true},
+
+	tea.KeyUp:             {Type: KeyUp},
+	tea.KeyDown:           {Type: KeyDown},
+	tea.KeyRight:          {Type: KeyRight},
+	tea.KeyLeft:           {Type: KeyLeft},
+	tea.KeyCtrlUp:         {Type: KeyUp, Ctrl: true},
+	tea.KeyCtrlDown:       {Type: KeyDown, Ctrl: true},
+	tea.KeyCtrlRight:      {Type: KeyRight, Ctrl: true},
+	tea.KeyCtrlLeft:       {Type: KeyLeft, Ctrl: true},
+	tea.KeyShiftUp:        {Type: KeyUp, Shift: true},
+	tea.KeyShiftDown:      {Type: KeyDown, Shift: true},
+	tea.KeyShiftRight:     {Type: KeyRight, Shift: true},
+	tea.KeyShiftLeft:      {Type: KeyLeft, Shift: true},
+	tea.KeyCtrlShiftUp:    {Type: KeyUp, Ctrl: true, Shift: true},
+	tea.KeyCtrlShiftDown:  {Type: KeyDown, Ctrl: true, Shift: true},
+	tea.KeyCtrlShiftRight: {Type: KeyRight, Ctrl: true, Shift: true},
+	tea.KeyCtrlShiftLeft:  {Type: KeyLeft, Ctrl: true, Shift: true},
+
+	tea.KeyHome:          {Type: KeyHome},
+	tea.KeyEnd:           {Type: KeyEnd},
+	tea.KeyCtrlHome:      {Type: KeyHome, Ctrl: true},
+	tea.KeyCtrlEnd:       {Type: KeyEnd, Ctrl: true},
+	tea.KeyShiftHome:     {Type: KeyHome, Shift: true},
+	tea.KeyShiftEnd:      {Type: KeyEnd, Shift: true},
+	tea.KeyCtrlShiftHome: {Type: KeyHome, Ctrl: true, Shift: true},
+	tea.KeyCtrlShiftEnd:  {Type: KeyEnd, Ctrl: true, Shift: true},
+	tea.KeyPgUp:          {Type: KeyPgUp},
+	tea.KeyPgDown:        {Type: KeyPgDown},
+	tea.KeyCtrlPgUp:      {Type: KeyPgUp, Ctrl: true},
+	tea.KeyCtrlPgDown:    {Type: KeyPgDown, Ctrl: true},
+
+	tea.KeyF1:  {Type: KeyF1},
+	tea.KeyF2:  {Type: KeyF2},
+	tea.KeyF3:  {Type: KeyF3},
+	tea.KeyF4:  {Type: KeyF4},
+	tea.KeyF5:  {Type: KeyF5},
+	tea.KeyF6:  {Type: KeyF6},
+	tea.KeyF7:  {Type: KeyF7},
+	tea.KeyF8:  {Type: KeyF8},
+	tea.KeyF9:  {Type: KeyF9},
+	tea.KeyF10: {Type: KeyF10},
+	tea.KeyF11: {Type: KeyF11},
+	tea.KeyF12: {Type: KeyF12},
+	tea.KeyF13: {Type: KeyF13},
+	tea.KeyF14: {Type: KeyF14},
+	tea.KeyF15: {Type: KeyF15},
+	tea.KeyF16: {Type: KeyF16},
+	tea.KeyF17: {Type: KeyF17},
+	tea.KeyF18: {Type: KeyF18},
+	tea.KeyF19: {Type: KeyF19},
+	tea.KeyF20: {Type: KeyF20},
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFromTeaMsgKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		in   tea.KeyMsg
+		want KeyMsg
+		str  string
+	}{
+		{"runes", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, KeyMsg{Type: KeyRunes, Runes: []rune("q")}, "q"},
+		{"alt rune", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i"), Alt: true}, KeyMsg{Type: KeyRunes, Runes: []rune("i"), Alt: true}, "alt+i"},
+		{"space", tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, KeyMsg{Type: KeySpace, Runes: []rune(" ")}, "space"},
+		{"enter", tea.KeyMsg{Type: tea.KeyEnter}, KeyMsg{Type: KeyEnter}, "enter"},
+		{"alt enter", tea.KeyMsg{Type: tea.KeyEnter, Alt: true}, KeyMsg{Type: KeyEnter, Alt: true}, "alt+enter"},
+		{"esc", tea.KeyMsg{Type: tea.KeyEsc}, KeyMsg{Type: KeyEsc}, "esc"},
+		{"backspace", tea.KeyMsg{Type: tea.KeyBackspace}, KeyMsg{Type: KeyBackspace}, "backspace"},
+		{"delete", tea.KeyMsg{Type: tea.KeyDelete}, KeyMsg{Type: KeyDelete}, "delete"},
+		{"insert", tea.KeyMsg{Type: tea.KeyInsert}, KeyMsg{Type: KeyInsert}, "insert"},
+		{"tab", tea.KeyMsg{Type: tea.KeyTab}, KeyMsg{Type: KeyTab}, "tab"},
+		{"shift tab", tea.KeyMsg{Type: tea.KeyShiftTab}, KeyMsg{Type: KeyTab, Shift: true}, "shift+tab"},
+		{"home", tea.KeyMsg{Type: tea.KeyHome}, KeyMsg{Type: KeyHome}, "home"},
+		{"end", tea.KeyMsg{Type: tea.KeyEnd}, KeyMsg{Type: KeyEnd}, "end"},
+		{"shift home", tea.KeyMsg{Type: tea.KeyShiftHome}, KeyMsg{Type: KeyHome, Shift: true}, "shift+home"},
+		{"ctrl shift end", tea.KeyMsg{Type: tea.KeyCtrlShiftEnd}, KeyMsg{Type: KeyEnd, Ctrl: true, Shift: true}, "ctrl+shift+end"},
+		{"pgup", tea.KeyMsg{Type: tea.KeyPgUp}, KeyMsg{Type: KeyPgUp}, "pgup"},
+		{"ctrl pgdown", tea.KeyMsg{Type: tea.KeyCtrlPgDown}, KeyMsg{Type: KeyPgDown, Ctrl: true}, "ctrl+pgdown"},
+		{"up", tea.KeyMsg{Type: tea.KeyUp}, KeyMsg{Type: KeyUp}, "up"},
+		{"alt up", tea.KeyMsg{Type: tea.KeyUp, Alt: true}, KeyMsg{Type: KeyUp, Alt: true}, "alt+up"},
+		{"ctrl left", tea.KeyMsg{Type: tea.KeyCtrlLeft}, KeyMsg{Type: KeyLeft, Ctrl: true}, "ctrl+left"},
+		{"shift right", tea.KeyMsg{Type: tea.KeyShiftRight}, KeyMsg{Type: KeyRight, Shift: true}, "shift+right"},
+		{"ctrl shift down", tea.KeyMsg{Type: tea.KeyCtrlShiftDown}, KeyMsg{Type: KeyDown, Ctrl: true, Shift: true}, "ctrl+shift+down"},
+		{"f1", tea.KeyMsg{Type: tea.KeyF1}, KeyMsg{Type: KeyF1}, "f1"},
+		{"f12", tea.KeyMsg{Type: tea.KeyF12}, KeyMsg{Type: KeyF12}, "f12"},
+		{"alt f4", tea.KeyMsg{Type: tea.KeyF4, Alt: true}, KeyMsg{Type: KeyF4, Alt: true}, "alt+f4"},
+		{"ctrl c", tea.KeyMsg{Type: tea.KeyCtrlC}, KeyMsg{Type: KeyCtrlC, Runes: []rune("c"), Ctrl: true}, "ctrl+c"},
+		{"ctrl d", tea.KeyMsg{Type: tea.KeyCtrlD}, KeyMsg{Type: KeyCtrlD, Runes: []rune("d"), Ctrl: true}, "ctrl+d"},
+		{"ctrl a", tea.KeyMsg{Type: tea.KeyCtrlA}, KeyMsg{Type: KeyRunes, Runes: []rune("a"), Ctrl: true}, "ctrl+a"},
+		{"ctrl h", tea.KeyMsg{Type: tea.KeyCtrlH}, KeyMsg{Type: KeyRunes, Runes: []rune("h"), Ctrl: true}, "ctrl+h"},
+		{"ctrl p", tea.KeyMsg{Type: tea.KeyCtrlP}, KeyMsg{Type: KeyRunes, Runes: []rune("p"), Ctrl: true}, "ctrl+p"},
+		{"ctrl alt z", tea.KeyMsg{Type: tea.KeyCtrlZ, Alt: true}, KeyMsg{Type: KeyRunes, Runes: []rune("z"), Ctrl: true, Alt: true}, "ctrl+alt+z"},
+		{"ctrl at", tea.KeyMsg{Type: tea.KeyCtrlAt}, KeyMsg{Type: KeyRunes, Runes: []rune("@"), Ctrl: true}, "ctrl+@"},
+		{"ctrl backslash", tea.KeyMsg{Type: tea.KeyCtrlBackslash}, KeyMsg{Type: KeyRunes, Runes: []rune(`\`), Ctrl: true}, `ctrl+\`},
+		{"ctrl underscore", tea.KeyMsg{Type: tea.KeyCtrlUnderscore}, KeyMsg{Type: KeyRunes, Runes: []rune("_"), Ctrl: true}, "ctrl+_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := fromTeaMsg(tt.in).(KeyMsg)
+			if !ok {
+				t.Fatalf("fromTeaMsg(%v) did not return a KeyMsg", tt.in)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fromTeaMsg(%v) = %+v, want %+v", tt.in, got, tt.want)
+			}
+			if s := got.String(); s != tt.str {
+				t.Errorf("String() = %q, want %q", s, tt.str)
+			}
+		})
+	}
+}
+
+func TestFromTeaMsgPaste(t *testing.T) {
+	in := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("brew install go"), Paste: true}
+	got := fromTeaMsg(in)
+	want := PasteMsg{Text: "brew install go"}
+	if got != want {
+		t.Errorf("fromTeaMsg(paste) = %#v, want %#v", got, want)
+	}
+}
+
+func TestFromTeaMsgCopiesRunes(t *testing.T) {
+	in := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}
+	key := fromTeaMsg(in).(KeyMsg)
+	in.Runes[0] = 'x'
+	if got := string(key.Runes); got != "q" {
+		t.Errorf("Runes = %q after bubbletea reused its slice, want %q", got, "q")
+	}
+
+	space := fromTeaMsg(tea.KeyMsg{Type: tea.KeySpace}).(KeyMsg)
+	space.Runes[0] = 'x'
+	if got := string(fromTeaMsg(tea.KeyMsg{Type: tea.KeySpace}).(KeyMsg).Runes); got != " " {
+		t.Errorf("Runes of the next space = %q, want the table left alone", got)
+	}
+}
+
+func TestKeyMsgString(t *testing.T) {
+	tests := []struct {
+		key  KeyMsg
+		want string
+	}{
+		{KeyMsg{Type: KeyRunes, Runes: []rune("p"), Ctrl: true, Shift: true}, "ctrl+shift+p"},
+		{KeyMsg{Type: KeyRunes, Runes: []rune("x"), Ctrl: true, Alt: true, Shift: true}, "ctrl+alt+shift+x"},
+		{KeyMsg{Type: KeyPgDown, Alt: true}, "alt+pgdown"},
+		{KeyMsg{Type: KeyCtrlD}, "ctrl+d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.key.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
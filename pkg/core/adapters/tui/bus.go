@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// The message bus lets widgets talk to each other without their parents
+// knowing about it. A widget publishes an event on a Topic; the event
+// travels through the program loop as an Event message, like any other
+// message, and every widget that subscribed to the topic picks it up in its
+// Update with Subscription.Receive.
+//
+//	var Navigated = tui.NewTopic[Route]("navigation")
+//
+//	// publisher
+//	return m, Navigated.Publish(route)
+//
+//	// subscriber
+//	m.nav = tui.Subscribe(&m.Subscriptions, Navigated)
+//	...
+//	if route, ok := m.nav.Receive(msg); ok { ... }
+//
+// Containers forward non-input messages to all their children, so events
+// reach subscribers wherever they are in the tree.
+
+// Topic is a named stream of events of type T.
+type Topic[T any] struct {
+	name        string
+	mu          sync.Mutex
+	subscribers map[*Subscription[T]]struct{}
+}
+
+// NewTopic creates a topic. Topics are usually package variables shared by
+// the publishers and subscribers.
+func NewTopic[T any](name string) *Topic[T] {
+	return &Topic[T]{
+		name:        name,
+		subscribers: make(map[*Subscription[T]]struct{}),
+	}
+}
+
+// Name returns the topic's name.
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Subscribers returns the number of active subscriptions.
+func (t *Topic[T]) Subscribers() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.subscribers)
+}
+
+// Publish returns a command that delivers event to the topic's subscribers.
+// Events published while nobody is subscribed are dropped.
+func (t *Topic[T]) Publish(event T) Cmd {
+	return func() Msg {
+		if t.Subscribers() == 0 {
+			return nil
+		}
+		return Event[T]{Topic: t, Payload: event}
+	}
+}
+
+// Event is the message carrying a published event.
+type Event[T any] struct {
+	Topic   *Topic[T]
+	Payload T
+}
+
+// Subscription is a widget's interest in a topic.
+type Subscription[T any] struct {
+	topic  *Topic[T]
+	active atomic.Bool
+}
+
+// Subscribe subscribes to topic. When owner is not nil the subscription
+// ends with the owner's Dispose, i.e. with the widget embedding it.
+func Subscribe[T any](owner *Subscriptions, topic *Topic[T]) *Subscription[T] {
+	s := &Subscription[T]{topic: topic}
+	s.active.Store(true)
+
+	topic.mu.Lock()
+	topic.subscribers[s] = struct{}{}
+	topic.mu.Unlock()
+
+	if owner != nil {
+		owner.track(s)
+	}
+	return s
+}
+
+// Receive returns the payload of msg when it is an event of the
+// subscription's topic and the subscription is still active.
+func (s *Subscription[T]) Receive(msg Msg) (T, bool) {
+	var zero T
+	event, ok := msg.(Event[T])
+	if !ok || event.Topic != s.topic || !s.active.Load() {
+		return zero, false
+	}
+	return event.Payload, true
+}
+
+// Unsubscribe ends the subscription. It is safe to call more than once.
+func (s *Subscription[T]) Unsubscribe() {
+	if !s.active.Swap(false) {
+		return
+	}
+	s.topic.mu.Lock()
+	delete(s.topic.subscribers, s)
+	s.topic.mu.Unlock()
+}
+
+// --- Widget Lifetime ---
+
+// Disposable is implemented by models that hold resources beyond their own
+// state (subscriptions, zones). Whoever discards such a model, e.g. the
+// overlay stack when an overlay closes, calls Dispose.
+type Disposable interface {
+	Dispose()
+}
+
+// Subscriptions is an embeddable helper that keeps the subscriptions of a
+// widget, so they all end when the widget is disposed. Like the rest of a
+// widget's state it is only used from Update, so it needs no locking.
+type Subscriptions struct {
+	subs []interface{ Unsubscribe() }
+}
+
+// Dispose ends every subscription made with the widget as owner.
+func (s *Subscriptions) Dispose() {
+	for _, sub := range s.subs {
+		sub.Unsubscribe()
+	}
+	s.subs = nil
+}
+
+func (s *Subscriptions) track(sub interface{ Unsubscribe() }) {
+	s.subs = append(s.subs, sub)
+}
@@ -0,0 +1,56 @@
+package tui
+
+import "testing"
+
+func TestTopicPublishWithoutSubscribers(t *testing.T) {
+	topic := NewTopic[string]("test")
+	if msg := topic.Publish("hello")(); msg != nil {
+		t.Fatalf("Publish without subscribers = %#v, want nil", msg)
+	}
+}
+
+func TestSubscriptionReceive(t *testing.T) {
+	topic := NewTopic[string]("test")
+	other := NewTopic[string]("other")
+
+	var owner Subscriptions
+	sub := Subscribe(&owner, topic)
+
+	msg := topic.Publish("hello")()
+	if got, ok := sub.Receive(msg); !ok || got != "hello" {
+		t.Fatalf("Receive = %q, %v; want hello, true", got, ok)
+	}
+
+	// Events of another topic with the same payload type are ignored.
+	Subscribe(nil, other)
+	if _, ok := sub.Receive(other.Publish("hello")()); ok {
+		t.Fatal("Receive accepted an event of another topic")
+	}
+	if _, ok := sub.Receive(KeyMsg{Type: KeyEnter}); ok {
+		t.Fatal("Receive accepted a non-event message")
+	}
+}
+
+func TestSubscriptionsDispose(t *testing.T) {
+	topic := NewTopic[int]("test")
+
+	var owner Subscriptions
+	first := Subscribe(&owner, topic)
+	Subscribe(&owner, topic)
+	if n := topic.Subscribers(); n != 2 {
+		t.Fatalf("Subscribers = %d, want 2", n)
+	}
+
+	msg := topic.Publish(1)()
+	owner.Dispose()
+	if n := topic.Subscribers(); n != 0 {
+		t.Fatalf("Subscribers after Dispose = %d, want 0", n)
+	}
+	if _, ok := first.Receive(msg); ok {
+		t.Fatal("Receive delivered an event after Dispose")
+	}
+
+	// Unsubscribing again is a no-op.
+	first.Unsubscribe()
+	owner.Dispose()
+}
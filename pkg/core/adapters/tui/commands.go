@@ -1,8 +1,61 @@
 package tui
 
-import tea "github.com/charmbracelet/bubbletea"
+import "time"
+
+// QuitMsg signals the program to exit. The adapter translates it into
+// bubbletea's quit message.
+type QuitMsg struct{}
 
 // Quit is a command that signals the program to exit.
 func Quit() Msg {
-	return tea.Quit()
+	return QuitMsg{}
+}
+
+// IsQuit reports whether msg is the message produced by Quit.
+func IsQuit(msg Msg) bool {
+	_, ok := msg.(QuitMsg)
+	return ok
+}
+
+// SequenceMsg is an internal message used to run commands one after another.
+// We use a custom type to avoid exposing tea.sequenceMsg directly.
+type SequenceMsg []Cmd
+
+// Sequence is a command that runs the given commands in order, delivering
+// each command's message before the next command starts. Contrast with
+// Batch, which runs its commands concurrently.
+func Sequence(cmds ...Cmd) Cmd {
+	var validCmds []Cmd
+	for _, cmd := range cmds {
+		if cmd != nil {
+			validCmds = append(validCmds, cmd)
+		}
+	}
+	if len(validCmds) == 0 {
+		return nil
+	}
+	return func() Msg {
+		return SequenceMsg(validCmds)
+	}
+}
+
+// Tick is a command that waits for d and then produces the message returned
+// by fn, which receives the time the timer fired. To tick repeatedly, return
+// another Tick from Update when the message arrives.
+func Tick(d time.Duration, fn func(time.Time) Msg) Cmd {
+	return func() Msg {
+		timer := time.NewTimer(d)
+		return fn(<-timer.C)
+	}
+}
+
+// Every is like Tick, but the timer is aligned with the system clock: with
+// d = time.Second it fires at the start of the next full second. This keeps
+// several clocks on screen in step with each other.
+func Every(d time.Duration, fn func(time.Time) Msg) Cmd {
+	return func() Msg {
+		now := time.Now()
+		timer := time.NewTimer(now.Truncate(d).Add(d).Sub(now))
+		return fn(<-timer.C)
+	}
 }
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// PlaceOverlay draws fg over bg with fg's top-left corner at (x, y) and
+// returns the combined frame. fg is opaque: every cell of its bounding box
+// replaces the cell below, so ragged lines are padded with spaces. Parts of
+// fg outside bg are clipped; bg keeps its size.
+func PlaceOverlay(x, y int, fg, bg string) string {
+	fgLines := strings.Split(fg, "\n")
+	bgLines := strings.Split(bg, "\n")
+
+	fgWidth := 0
+	for _, line := range fgLines {
+		fgWidth = max(fgWidth, Width(line))
+	}
+
+	for i, line := range fgLines {
+		row := y + i
+		if row < 0 || row >= len(bgLines) {
+			continue
+		}
+		bgLine := bgLines[row]
+		bgWidth := Width(bgLine)
+
+		// Clip fg to the columns that are on screen.
+		line += strings.Repeat(" ", fgWidth-Width(line))
+		start, end := x, x+fgWidth
+		if start < 0 {
+			line = ansi.TruncateLeft(line, -start, "")
+			start = 0
+		}
+		if end > bgWidth {
+			line = ansi.Truncate(line, max(bgWidth-start, 0), "")
+			end = bgWidth
+		}
+		if start >= end {
+			continue
+		}
+
+		var b strings.Builder
+		b.WriteString(ansi.Truncate(bgLine, start, ""))
+		b.WriteString(ansi.ResetStyle)
+		b.WriteString(line)
+		b.WriteString(ansi.ResetStyle)
+		b.WriteString(cutLeft(bgLine, end))
+		bgLines[row] = b.String()
+	}
+	return strings.Join(bgLines, "\n")
+}
+
+// cutLeft drops the first n cells of line. The colors in effect at the cut
+// are re-emitted ahead of the rest, which would otherwise be drawn with the
+// reset style written after the overlay. A wide character split by the cut
+// becomes spaces.
+func cutLeft(line string, n int) string {
+	var sgr, rest strings.Builder
+	var state byte
+	col := 0
+	for len(line) > 0 && col < n {
+		seq, width, size, next := ansi.DecodeSequence(line, state, nil)
+		state, line = next, line[size:]
+		switch {
+		case width > 0:
+			col += width
+			if col > n {
+				rest.WriteString(strings.Repeat(" ", col-n))
+			}
+		case isSGR(seq):
+			if seq == ansi.ResetStyle || seq == "\x1b[0m" {
+				sgr.Reset()
+				continue
+			}
+			sgr.WriteString(seq)
+		default:
+			// Other sequences, such as hyperlinks, keep their order.
+			rest.WriteString(seq)
+		}
+	}
+	return sgr.String() + rest.String() + line
+}
+
+// isSGR reports whether seq is a Select Graphic Rendition sequence.
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
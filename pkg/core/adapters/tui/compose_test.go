@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestPlaceOverlay(t *testing.T) {
+	bg := "..........\n..........\n..........\n.........."
+
+	tests := []struct {
+		name string
+		x, y int
+		fg   string
+		want string
+	}{
+		{
+			name: "inside",
+			x:    2, y: 1,
+			fg:   "ab\nc",
+			want: "..........\n..ab......\n..c ......\n..........",
+		},
+		{
+			name: "clipped right and bottom",
+			x:    8, y: 3,
+			fg:   "wxyz\nmore",
+			want: "..........\n..........\n..........\n........wx",
+		},
+		{
+			name: "clipped left and top",
+			x:    -1, y: -1,
+			fg:   "abc\ndef",
+			want: "ef........\n..........\n..........\n..........",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ansi.Strip(PlaceOverlay(tt.x, tt.y, tt.fg, bg))
+			if got != tt.want {
+				t.Errorf("PlaceOverlay() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceOverlayKeepsBackgroundStyle(t *testing.T) {
+	tests := []struct {
+		name string
+		bg   string
+		want string
+	}{
+		{
+			name: "style set before the overlay",
+			bg:   "\x1b[44m..........\x1b[0m",
+			want: "\x1b[44m...\x1b[0m\x1b[mXX\x1b[m\x1b[44m.....\x1b[0m",
+		},
+		{
+			name: "style changed under the overlay",
+			bg:   "\x1b[44m....\x1b[1m......\x1b[0m",
+			want: "\x1b[44m...\x1b[1m\x1b[0m\x1b[mXX\x1b[m\x1b[44m\x1b[1m.....\x1b[0m",
+		},
+		{
+			name: "style reset under the overlay",
+			bg:   "\x1b[44m....\x1b[0m\x1b[31m......\x1b[0m",
+			want: "\x1b[44m...\x1b[0m\x1b[31m\x1b[0m\x1b[mXX\x1b[m\x1b[31m.....\x1b[0m",
+		},
+		{
+			name: "wide character split by the overlay",
+			bg:   "\x1b[44m....世...\x1b[0m",
+			want: "\x1b[44m...\x1b[0m\x1b[mXX\x1b[m\x1b[44m ...\x1b[0m",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlaceOverlay(3, 0, "XX", tt.bg); got != tt.want {
+				t.Errorf("PlaceOverlay() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripZones(t *testing.T) {
+	id := NewZoneID()
+	view := "\x1b[1m" + MarkZone(id, "ok") + "\x1b[0m"
+	if got, want := StripZones(view), "\x1b[1mok\x1b[0m"; got != want {
+		t.Errorf("StripZones() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"errors"
+	"io"
+	"os/exec"
+)
+
+// ExecCommand is a process that takes over the terminal while it runs.
+// *exec.Cmd is supported through ExecProcess; tests can provide fakes.
+type ExecCommand interface {
+	Run() error
+	SetStdin(io.Reader)
+	SetStdout(io.Writer)
+	SetStderr(io.Writer)
+}
+
+// ExecMsg asks the runtime to suspend the UI, run Command with the
+// terminal's stdio and resume the UI afterwards. The message returned by
+// OnExit is then delivered to Update.
+type ExecMsg struct {
+	Command ExecCommand
+	OnExit  func(err error) Msg
+}
+
+// ExecFinishedMsg is delivered after an external process exits when no
+// onExit callback was given.
+type ExecFinishedMsg struct {
+	Err      error
+	ExitCode int
+}
+
+// Exec is a command that releases the terminal (leaving the alternate screen
+// and raw mode), runs c in the foreground and restores the UI once it exits.
+// onExit turns the process error into the message delivered to Update; if it
+// is nil an ExecFinishedMsg is delivered instead.
+func Exec(c ExecCommand, onExit func(err error) Msg) Cmd {
+	if onExit == nil {
+		onExit = func(err error) Msg {
+			return ExecFinishedMsg{Err: err, ExitCode: ExitCode(err)}
+		}
+	}
+	return func() Msg {
+		return ExecMsg{Command: c, OnExit: onExit}
+	}
+}
+
+// ExecProcess is like Exec for an *exec.Cmd, e.g. an installer, a sudo
+// prompt or the user's $EDITOR. Streams already set on c are kept.
+func ExecProcess(c *exec.Cmd, onExit func(err error) Msg) Cmd {
+	return Exec(osExecCommand{c}, onExit)
+}
+
+// ExitCode returns the exit status carried by a process error: 0 for nil,
+// the process exit code when the error reports one, and -1 otherwise
+// (e.g. the program could not be started).
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// osExecCommand adapts *exec.Cmd to ExecCommand.
+type osExecCommand struct {
+	*exec.Cmd
+}
+
+func (c osExecCommand) SetStdin(r io.Reader) {
+	if c.Stdin == nil {
+		c.Stdin = r
+	}
+}
+
+func (c osExecCommand) SetStdout(w io.Writer) {
+	if c.Stdout == nil {
+		c.Stdout = w
+	}
+}
+
+func (c osExecCommand) SetStderr(w io.Writer) {
+	if c.Stderr == nil {
+		c.Stderr = w
+	}
+}
@@ -0,0 +1,360 @@
+package tui
+
+import "fmt"
+
+// FlexDirection is the way a Flex container arranges its children, matching
+// the Box layout modes of the design system.
+type FlexDirection int
+
+const (
+	// Column stacks children top to bottom.
+	Column FlexDirection = iota
+	// Row places children left to right.
+	Row
+	// Grid places children on a column grid, wrapping into new rows.
+	Grid
+)
+
+var flexDirectionNames = map[FlexDirection]string{
+	Column: "column",
+	Row:    "row",
+	Grid:   "grid",
+}
+
+func (d FlexDirection) String() string {
+	if name, ok := flexDirectionNames[d]; ok {
+		return name
+	}
+	return fmt.Sprintf("FlexDirection(%d)", int(d))
+}
+
+// ParseFlexDirection parses a Box layout mode as written in themes and
+// screen definitions: "row", "column" or "grid".
+func ParseFlexDirection(s string) (FlexDirection, error) {
+	for d, name := range flexDirectionNames {
+		if name == s {
+			return d, nil
+		}
+	}
+	return Column, fmt.Errorf("unknown layout %q (want row, column or grid)", s)
+}
+
+// Justify distributes the space left on the main axis once every child has
+// its size.
+type Justify int
+
+const (
+	JustifyStart Justify = iota
+	JustifyCenter
+	JustifyEnd
+	// JustifySpaceBetween puts the leftover space between the children.
+	JustifySpaceBetween
+)
+
+// AlignItems places children on the cross axis.
+type AlignItems int
+
+const (
+	// AlignStretch gives every child the full cross size, unless it asks
+	// for a Cross size of its own.
+	AlignStretch AlignItems = iota
+	AlignStart
+	AlignCenter
+	AlignEnd
+)
+
+// FlexItem describes how a child is sized along the main axis (the width in
+// a row, the height in a column and in grid rows).
+type FlexItem struct {
+	// Basis is the preferred size before growing or shrinking.
+	Basis int
+	// Grow is the child's share of the leftover space; 0 keeps the basis.
+	Grow int
+	// Min and Max bound the size; 0 means no bound.
+	Min, Max int
+	// Cross is the size on the cross axis; 0 takes the full cross size.
+	Cross int
+	// Span is the number of grid columns the child covers. Defaults to 1.
+	Span int
+}
+
+// Fixed is an item of exactly n cells, shrunk only when nothing else fits.
+func Fixed(n int) FlexItem {
+	return FlexItem{Basis: n}
+}
+
+// Grow is an item that takes grow shares of the leftover space.
+func Grow(grow int) FlexItem {
+	return FlexItem{Grow: grow}
+}
+
+// clamp bounds n by the item's Min and Max.
+func (it FlexItem) clamp(n int) int {
+	if it.Max > 0 && n > it.Max {
+		n = it.Max
+	}
+	if n < it.Min {
+		n = it.Min
+	}
+	return max(n, 0)
+}
+
+// Flex is a layout container: it computes a Rect for each of its items
+// inside a given area. It holds no widgets, so any widget can use it to
+// place its children and then size and join their views.
+//
+//	rects := tui.NewFlex(tui.Column).
+//		Add(tui.Fixed(3), tui.Grow(1), tui.Fixed(3)).
+//		Arrange(tui.Rect{Width: w, Height: h})
+type Flex struct {
+	direction FlexDirection
+	gapX      int
+	gapY      int
+	columns   int
+	justify   Justify
+	align     AlignItems
+	items     []FlexItem
+}
+
+// NewFlex creates a container with the given direction. Grids default to
+// 12 columns.
+func NewFlex(direction FlexDirection) *Flex {
+	return &Flex{direction: direction, columns: 12}
+}
+
+// Gap sets the horizontal and vertical space between children. Rows use x,
+// columns use y and grids use both.
+func (f *Flex) Gap(x, y int) *Flex {
+	f.gapX, f.gapY = max(x, 0), max(y, 0)
+	return f
+}
+
+// Columns sets the number of grid columns.
+func (f *Flex) Columns(n int) *Flex {
+	f.columns = max(n, 1)
+	return f
+}
+
+// Justify sets how leftover main-axis space is distributed.
+func (f *Flex) Justify(j Justify) *Flex {
+	f.justify = j
+	return f
+}
+
+// Align sets how children are placed on the cross axis.
+func (f *Flex) Align(a AlignItems) *Flex {
+	f.align = a
+	return f
+}
+
+// Add appends items, one per child, in order.
+func (f *Flex) Add(items ...FlexItem) *Flex {
+	f.items = append(f.items, items...)
+	return f
+}
+
+// Arrange returns one Rect per item, in the order they were added, placed
+// inside area. Children that do not fit get an empty Rect.
+func (f *Flex) Arrange(area Rect) []Rect {
+	if f.direction == Grid {
+		return f.arrangeGrid(area)
+	}
+
+	main, cross := area.Width, area.Height
+	gap := f.gapX
+	if f.direction == Column {
+		main, cross = area.Height, area.Width
+		gap = f.gapY
+	}
+
+	sizes := distribute(f.items, main, gap)
+
+	used := 0
+	for _, s := range sizes {
+		used += s
+	}
+	if len(sizes) > 1 {
+		used += gap * (len(sizes) - 1)
+	}
+	offset, spacing := justify(f.justify, max(main-used, 0), len(sizes))
+
+	rects := make([]Rect, len(f.items))
+	pos := offset
+	for i, it := range f.items {
+		crossSize, crossPos := f.crossPlacement(it, cross)
+		size := min(sizes[i], max(main-pos, 0))
+		if f.direction == Column {
+			rects[i] = Rect{X: area.X + crossPos, Y: area.Y + pos, Width: crossSize, Height: size}
+		} else {
+			rects[i] = Rect{X: area.X + pos, Y: area.Y + crossPos, Width: size, Height: crossSize}
+		}
+		pos += sizes[i] + gap + spacing
+	}
+	return rects
+}
+
+// crossPlacement returns the size and offset of an item on the cross axis.
+func (f *Flex) crossPlacement(it FlexItem, cross int) (size, pos int) {
+	if it.Cross == 0 {
+		return cross, 0
+	}
+	size = min(it.Cross, cross)
+	switch f.align {
+	case AlignCenter:
+		pos = (cross - size) / 2
+	case AlignEnd:
+		pos = cross - size
+	}
+	return size, pos
+}
+
+// arrangeGrid places the items on the column grid, left to right, starting
+// a new row when an item's span does not fit. A row is as tall as its
+// tallest Basis; rows with no Basis share the remaining height.
+func (f *Flex) arrangeGrid(area Rect) []Rect {
+	columns := f.columns
+	colWidths := distribute(repeat(Grow(1), columns), area.Width, f.gapX)
+
+	// Assign items to rows.
+	type cell struct{ row, col, span int }
+	cells := make([]cell, len(f.items))
+	var rowItems []FlexItem
+	row, col := 0, 0
+	for i, it := range f.items {
+		span := min(max(it.Span, 1), columns)
+		if col+span > columns {
+			row, col = row+1, 0
+		}
+		if row == len(rowItems) {
+			rowItems = append(rowItems, Grow(1))
+		}
+		if it.Basis > 0 {
+			rowItems[row] = Fixed(max(rowItems[row].Basis, it.Basis))
+		}
+		cells[i] = cell{row, col, span}
+		col += span
+	}
+	rowHeights := distribute(rowItems, area.Height, f.gapY)
+
+	colPos := offsets(colWidths, f.gapX)
+	rowPos := offsets(rowHeights, f.gapY)
+
+	rects := make([]Rect, len(f.items))
+	for i, c := range cells {
+		width := colPos[c.col+c.span-1] + colWidths[c.col+c.span-1] - colPos[c.col]
+		rects[i] = Rect{
+			X:      area.X + colPos[c.col],
+			Y:      area.Y + rowPos[c.row],
+			Width:  width,
+			Height: min(rowHeights[c.row], max(area.Height-rowPos[c.row], 0)),
+		}
+	}
+	return rects
+}
+
+// distribute sizes items along a main axis of the given length.
+//
+// Items start at their clamped Basis. Leftover space goes to the items
+// that grow, in proportion to Grow and up to their Max. When the items
+// overflow, growing items shrink first, then fixed items from the last
+// one backwards, never below their Min.
+func distribute(items []FlexItem, length, gap int) []int {
+	sizes := make([]int, len(items))
+	if len(items) == 0 {
+		return sizes
+	}
+	avail := max(length-gap*(len(items)-1), 0)
+
+	used := 0
+	for i, it := range items {
+		sizes[i] = it.clamp(it.Basis)
+		used += sizes[i]
+	}
+
+	// Grow, re-running while some items hit their Max.
+	free := avail - used
+	for free > 0 {
+		growing, shares := []int{}, 0
+		for i, it := range items {
+			if it.Grow > 0 && (it.Max == 0 || sizes[i] < it.Max) {
+				growing = append(growing, i)
+				shares += it.Grow
+			}
+		}
+		if shares == 0 {
+			break
+		}
+		given := 0
+		for _, i := range growing {
+			add := free * items[i].Grow / shares
+			next := items[i].clamp(sizes[i] + add)
+			given += next - sizes[i]
+			sizes[i] = next
+		}
+		// Hand out what integer division left over, one cell at a time.
+		for _, i := range growing {
+			if given >= free {
+				break
+			}
+			if next := items[i].clamp(sizes[i] + 1); next > sizes[i] {
+				sizes[i] = next
+				given++
+			}
+		}
+		if given == 0 {
+			break
+		}
+		free -= given
+	}
+
+	// Shrink growing items first, then the rest from the end.
+	over := -free
+	for pass := 0; pass < 2 && over > 0; pass++ {
+		for i := len(items) - 1; i >= 0 && over > 0; i-- {
+			if (pass == 0) != (items[i].Grow > 0) {
+				continue
+			}
+			take := min(over, sizes[i]-items[i].Min)
+			if take > 0 {
+				sizes[i] -= take
+				over -= take
+			}
+		}
+	}
+	return sizes
+}
+
+// justify returns the offset of the first item and the extra space after
+// each item for the given leftover space.
+func justify(j Justify, free, n int) (offset, spacing int) {
+	switch j {
+	case JustifyCenter:
+		return free / 2, 0
+	case JustifyEnd:
+		return free, 0
+	case JustifySpaceBetween:
+		if n > 1 {
+			return 0, free / (n - 1)
+		}
+	}
+	return 0, 0
+}
+
+// offsets returns the start position of each size separated by gap.
+func offsets(sizes []int, gap int) []int {
+	pos := make([]int, len(sizes))
+	p := 0
+	for i, s := range sizes {
+		pos[i] = p
+		p += s + gap
+	}
+	return pos
+}
+
+func repeat(it FlexItem, n int) []FlexItem {
+	items := make([]FlexItem, n)
+	for i := range items {
+		items[i] = it
+	}
+	return items
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlexRowAndColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		flex *Flex
+		area Rect
+		want []Rect
+	}{
+		{
+			name: "column fixed bars around flexible content",
+			flex: NewFlex(Column).Add(Fixed(3), Grow(1), Fixed(3)),
+			area: Rect{Width: 40, Height: 20},
+			want: []Rect{{0, 0, 40, 3}, {0, 3, 40, 14}, {0, 17, 40, 3}},
+		},
+		{
+			name: "row with gap and offset area",
+			flex: NewFlex(Row).Gap(1, 0).Add(Fixed(10), Grow(1)),
+			area: Rect{X: 2, Y: 1, Width: 30, Height: 5},
+			want: []Rect{{2, 1, 10, 5}, {13, 1, 19, 5}},
+		},
+		{
+			name: "grow shares split the remainder",
+			flex: NewFlex(Row).Add(Grow(1), Grow(2)),
+			area: Rect{Width: 10, Height: 1},
+			want: []Rect{{0, 0, 4, 1}, {4, 0, 6, 1}},
+		},
+		{
+			name: "max caps growth and the rest goes to others",
+			flex: NewFlex(Row).Add(FlexItem{Grow: 1, Max: 3}, Grow(1)),
+			area: Rect{Width: 10, Height: 1},
+			want: []Rect{{0, 0, 3, 1}, {3, 0, 7, 1}},
+		},
+		{
+			name: "overflow shrinks flexible items to their min first",
+			flex: NewFlex(Row).Add(Fixed(8), FlexItem{Grow: 1, Basis: 5, Min: 2}),
+			area: Rect{Width: 10, Height: 1},
+			want: []Rect{{0, 0, 8, 1}, {8, 0, 2, 1}},
+		},
+		{
+			name: "overflow then shrinks fixed items from the end",
+			flex: NewFlex(Column).Add(Fixed(3), Grow(1), Fixed(3)),
+			area: Rect{Width: 5, Height: 4},
+			want: []Rect{{0, 0, 5, 3}, {0, 3, 5, 0}, {0, 3, 5, 1}},
+		},
+		{
+			name: "justify center and cross alignment",
+			flex: NewFlex(Row).Justify(JustifyCenter).Align(AlignEnd).Add(FlexItem{Basis: 4, Cross: 2}),
+			area: Rect{Width: 10, Height: 6},
+			want: []Rect{{3, 4, 4, 2}},
+		},
+		{
+			name: "justify space between",
+			flex: NewFlex(Row).Justify(JustifySpaceBetween).Add(Fixed(2), Fixed(2), Fixed(2)),
+			area: Rect{Width: 10, Height: 1},
+			want: []Rect{{0, 0, 2, 1}, {4, 0, 2, 1}, {8, 0, 2, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flex.Arrange(tt.area); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Arrange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlexGrid(t *testing.T) {
+	got := NewFlex(Grid).Columns(4).Gap(1, 1).
+		Add(FlexItem{Span: 2, Basis: 2}, FlexItem{Span: 2}, FlexItem{Span: 3}).
+		Arrange(Rect{Width: 23, Height: 10})
+
+	// Columns are 5 wide with a gap of 1; the second row takes the
+	// height left after the fixed first row and the row gap.
+	want := []Rect{{0, 0, 11, 2}, {12, 0, 11, 2}, {0, 3, 17, 7}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Arrange() = %v, want %v", got, want)
+	}
+}
+
+func TestParseFlexDirection(t *testing.T) {
+	for _, d := range []FlexDirection{Row, Column, Grid} {
+		got, err := ParseFlexDirection(d.String())
+		if err != nil || got != d {
+			t.Errorf("ParseFlexDirection(%q) = %v, %v", d.String(), got, err)
+		}
+	}
+	if _, err := ParseFlexDirection("stack"); err == nil {
+		t.Error("ParseFlexDirection(stack) did not fail")
+	}
+}
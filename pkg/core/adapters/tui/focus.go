@@ -0,0 +1,204 @@
+package tui
+
+// Focusable is an interface for widgets that can receive keyboard focus.
+// Only the focused widget of a FocusManager receives input messages.
+type Focusable interface {
+	Model
+	// Focus gives the widget keyboard focus. It may return a command,
+	// e.g. to start a cursor blink.
+	Focus() Cmd
+	// Blur removes keyboard focus from the widget.
+	Blur()
+	// Focused reports whether the widget currently has focus.
+	Focused() bool
+}
+
+// FocusState is an embeddable helper that implements the focus methods of
+// Focusable for widgets that only need to track the flag.
+type FocusState struct {
+	focused bool
+}
+
+// Focus marks the widget as focused.
+func (f *FocusState) Focus() Cmd {
+	f.focused = true
+	return nil
+}
+
+// Blur marks the widget as not focused.
+func (f *FocusState) Blur() {
+	f.focused = false
+}
+
+// Focused reports whether the widget is focused.
+func (f *FocusState) Focused() bool {
+	return f.focused
+}
+
+// KeyHandler is implemented by widgets that use some keys before the
+// bindings of the models around them, e.g. Tab accepting a completion or
+// "?" typed in a text input. Containers offer a key to their focused child
+// first and only apply their own bindings when HandlesKey returns false.
+// HandlesKey must not change the widget: Update does the work.
+type KeyHandler interface {
+	HandlesKey(msg KeyMsg) bool
+}
+
+// HandlesKey reports whether m uses msg itself. Models that do not
+// implement KeyHandler leave every key to their container.
+func HandlesKey(m Model, msg KeyMsg) bool {
+	handler, ok := m.(KeyHandler)
+	return ok && handler.HandlesKey(msg)
+}
+
+// --- Focus Messages ---
+
+// FocusChangedMsg is sent after focus moves from one widget to another.
+// Previous is nil when nothing was focused before.
+type FocusChangedMsg struct {
+	Previous Focusable
+	Current  Focusable
+}
+
+// FocusRequestMsg asks the focus manager that owns Target to focus it.
+type FocusRequestMsg struct {
+	Target Focusable
+}
+
+// RequestFocus is a command that asks for target to be focused.
+func RequestFocus(target Focusable) Cmd {
+	return func() Msg {
+		return FocusRequestMsg{Target: target}
+	}
+}
+
+// IsInputMsg reports whether msg is user input that should only reach the
+// focused widget. Everything else (resizes, mouse events, broadcasts) is
+// delivered to the whole tree.
+func IsInputMsg(msg Msg) bool {
+	switch msg.(type) {
+	case KeyMsg, PasteMsg:
+		return true
+	default:
+		return false
+	}
+}
+
+// --- Focus Manager ---
+
+// FocusScope is the keymap scope of the focus traversal actions.
+const FocusScope = "focus"
+
+// Focus traversal actions, bound to Tab and Shift+Tab by default.
+var (
+	ActionFocusNext = Keys.Register(FocusScope, "focus.next", "Next panel", "tab")
+	ActionFocusPrev = Keys.Register(FocusScope, "focus.prev", "Previous panel", "shift+tab")
+)
+
+func init() {
+	// Focus moves from whatever widget has it.
+	Keys.AddOuterScopes(FocusScope)
+}
+
+// FocusManager keeps track of which widget in a set has keyboard focus and
+// moves it in tab order with the focus actions (Tab and Shift+Tab).
+type FocusManager struct {
+	items   []Focusable
+	current int
+}
+
+// NewFocusManager creates a focus manager with the given widgets in tab order.
+// Nothing is focused until Focus, Next or Prev is called.
+func NewFocusManager(items ...Focusable) *FocusManager {
+	f := &FocusManager{current: -1}
+	f.Add(items...)
+	return f
+}
+
+// Add appends widgets to the end of the tab order.
+func (f *FocusManager) Add(items ...Focusable) {
+	for _, item := range items {
+		if item != nil {
+			f.items = append(f.items, item)
+		}
+	}
+}
+
+// Focused returns the focused widget, or nil if nothing is focused.
+func (f *FocusManager) Focused() Focusable {
+	if f.current < 0 || f.current >= len(f.items) {
+		return nil
+	}
+	return f.items[f.current]
+}
+
+// Focus moves focus to target. It returns nil if target is not managed here.
+func (f *FocusManager) Focus(target Focusable) Cmd {
+	for i, item := range f.items {
+		if item == target {
+			return f.focusIndex(i)
+		}
+	}
+	return nil
+}
+
+// Next moves focus to the next widget in tab order, wrapping around.
+func (f *FocusManager) Next() Cmd {
+	if len(f.items) == 0 {
+		return nil
+	}
+	return f.focusIndex((f.current + 1) % len(f.items))
+}
+
+// Prev moves focus to the previous widget in tab order, wrapping around.
+func (f *FocusManager) Prev() Cmd {
+	if len(f.items) == 0 {
+		return nil
+	}
+	if f.current <= 0 {
+		return f.focusIndex(len(f.items) - 1)
+	}
+	return f.focusIndex(f.current - 1)
+}
+
+// Update handles Tab, Shift+Tab and focus requests for managed widgets.
+// It reports whether the message was consumed. The focused widget gets the
+// traversal keys first when it handles them (see KeyHandler).
+func (f *FocusManager) Update(msg Msg) (bool, Cmd) {
+	switch msg := msg.(type) {
+	case KeyMsg:
+		if focused := f.Focused(); focused != nil && HandlesKey(focused, msg) {
+			return false, nil
+		}
+		switch {
+		case Keys.Matches(msg, ActionFocusNext):
+			return true, f.Next()
+		case Keys.Matches(msg, ActionFocusPrev):
+			return true, f.Prev()
+		}
+	case FocusRequestMsg:
+		for i, item := range f.items {
+			if item == msg.Target {
+				return true, f.focusIndex(i)
+			}
+		}
+	}
+	return false, nil
+}
+
+// focusIndex blurs the current widget and focuses the one at index i.
+func (f *FocusManager) focusIndex(i int) Cmd {
+	if i == f.current {
+		return nil
+	}
+	previous := f.Focused()
+	if previous != nil {
+		previous.Blur()
+	}
+	f.current = i
+	current := f.items[i]
+	changed := func() Msg {
+		return FocusChangedMsg{Previous: previous, Current: current}
+	}
+	return Batch(current.Focus(), changed)
+}
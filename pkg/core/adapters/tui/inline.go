@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Inline draws a model for a one-shot command (version, install,
+// doctor...) in the normal scrollback instead of the alternate screen: the
+// model's view is a live region redrawn in place, and what Println writes
+// goes above it. Once the command is done, Close leaves the last frame as
+// static lines.
+//
+// When the output is not a terminal (or TERM=dumb, or WithPlainLines is
+// given), nothing is redrawn: the model runs with the plain renderer, which
+// writes its accessible text as it grows.
+//
+// Commands report their steps with Task. Each change to an InlineTask
+// reaches the model as an InlineTaskMsg, so the model (e.g. a TaskPanel)
+// decides how the tasks look. Tasks may be updated from several goroutines.
+//
+//	ui := tui.NewInline(cmd.Context(), cmd.ErrOrStderr(), taskpanel.New(nil))
+//	defer ui.Close()
+//	task := ui.Task("Downloading go")
+//	task.Progress(n, total)
+//	task.Done("1.23.1")
+//
+// Inline runs its own program, so commands need no Shell or screen.
+type Inline struct {
+	interactive bool
+	transient   bool
+	// program runs the model on a terminal, plain otherwise.
+	program *Program
+	plain   *plainRenderer
+
+	// finished is closed once the plain renderer has stopped with final
+	// and err.
+	finished chan struct{}
+	final    Model
+	err      error
+
+	// mu guards the task IDs and Close.
+	mu     sync.Mutex
+	tasks  int
+	closed bool
+}
+
+// InlineOption is a functional option for configuring Inline.
+type InlineOption func(*Inline)
+
+// WithPlainLines forces plain line output even on a terminal, e.g. for the
+// --no-tui flag.
+func WithPlainLines(plain bool) InlineOption {
+	return func(in *Inline) {
+		if plain {
+			in.interactive = false
+		}
+	}
+}
+
+// WithTransient shows the tasks only while they run: once they have all
+// succeeded, Close leaves nothing behind, and plain output only gets the
+// failed tasks. It suits quick steps, e.g. reading a file, that are only
+// worth a line when they are slow or fail.
+func WithTransient(transient bool) InlineOption {
+	return func(in *Inline) { in.transient = transient }
+}
+
+// NewInline starts drawing model inline on out. Cancelling ctx stops the
+// live region; Close must still be called.
+func NewInline(ctx context.Context, out io.Writer, model Model, opts ...InlineOption) *Inline {
+	return newInline(ctx, out, model, IsInteractive(out), opts...)
+}
+
+func newInline(ctx context.Context, out io.Writer, model Model, interactive bool, opts ...InlineOption) *Inline {
+	in := &Inline{interactive: interactive, finished: make(chan struct{})}
+	for _, opt := range opts {
+		opt(in)
+	}
+
+	if in.interactive {
+		// No input: the command owns stdin, and ctrl+c arrives as a signal.
+		in.program = NewProgram(&inlineModel{inner: model, transient: in.transient}, WithInput(nil), WithOutput(out))
+		// Start only fails when called twice.
+		_ = in.program.Start(ctx)
+		return in
+	}
+
+	in.plain = newPlainRenderer(nil, out)
+	go func() {
+		defer close(in.finished)
+		in.final, in.err = in.plain.run(ctx, model)
+		close(in.plain.done)
+	}()
+	return in
+}
+
+// Send delivers msg to the model, e.g. from a background goroutine. It is
+// a no-op once the model has quit.
+func (in *Inline) Send(msg Msg) {
+	if in.program != nil {
+		in.program.Send(msg)
+		return
+	}
+	in.plain.send(msg)
+}
+
+// Println writes a static line above the live region.
+func (in *Inline) Println(a ...any) {
+	if in.program != nil {
+		in.program.Println(a...)
+		return
+	}
+	in.plain.send(printLineMsg{text: fmt.Sprintln(a...)})
+}
+
+// Wait blocks until the model has quit, e.g. once the work it runs is
+// done, and returns the final model and the error that stopped it.
+func (in *Inline) Wait() (Model, error) {
+	if in.program != nil {
+		final, err := in.program.Wait()
+		if m, ok := final.(*inlineModel); ok {
+			final = m.inner
+		}
+		return final, err
+	}
+	<-in.finished
+	return in.final, in.err
+}
+
+// Close tells the model the command is done with InlineCloseMsg and stops
+// the live region, leaving its last frame on screen. It is safe to call
+// more than once.
+func (in *Inline) Close() error {
+	in.mu.Lock()
+	if in.closed {
+		in.mu.Unlock()
+		return nil
+	}
+	in.closed = true
+	in.mu.Unlock()
+
+	in.Send(InlineCloseMsg{})
+	if in.program != nil {
+		in.program.Quit()
+	} else {
+		in.plain.send(QuitMsg{})
+	}
+	_, err := in.Wait()
+	return err
+}
+
+// --- Tasks ---
+
+// InlineEvent is the change an InlineTaskMsg reports.
+type InlineEvent int
+
+const (
+	// InlineStarted reports a new running task.
+	InlineStarted InlineEvent = iota
+	// InlineStatus reports the task's status text.
+	InlineStatus
+	// InlineProgress reports the task's progress.
+	InlineProgress
+	// InlineDone reports the task finished, with an optional summary.
+	InlineDone
+	// InlineFailed reports the task failed.
+	InlineFailed
+)
+
+// InlineTaskMsg reports a change to a task of an Inline to its model.
+type InlineTaskMsg struct {
+	// ID identifies the task in its Inline.
+	ID    int
+	Title string
+	Event InlineEvent
+	// Text is the status of InlineStatus and the summary of InlineDone.
+	Text string
+	// Done out of Total is the progress of InlineProgress; Total is
+	// positive.
+	Done, Total int
+	// Err is the failure of InlineFailed.
+	Err error
+}
+
+// InlineCloseMsg is sent to the model of an Inline by Close, before it
+// stops: the tasks still running will not report again.
+type InlineCloseMsg struct{}
+
+// Task adds a running task.
+func (in *Inline) Task(title string) *InlineTask {
+	in.mu.Lock()
+	in.tasks++
+	t := &InlineTask{inline: in, id: in.tasks, title: title}
+	in.mu.Unlock()
+
+	t.report(InlineTaskMsg{Event: InlineStarted})
+	return t
+}
+
+// InlineTask is a task of an Inline. Its methods may be called from any
+// goroutine; they are ignored once the task has finished.
+type InlineTask struct {
+	inline *Inline
+	id     int
+	title  string
+
+	// mu guards the fields below.
+	mu       sync.Mutex
+	finished bool
+	// held are the messages a transient task keeps until it fails, when
+	// plain output only gets failures.
+	held []Msg
+}
+
+// Status sets the task's status text.
+func (t *InlineTask) Status(status string) {
+	t.report(InlineTaskMsg{Event: InlineStatus, Text: status})
+}
+
+// Progress reports done out of total; a total of 0 or less is ignored.
+func (t *InlineTask) Progress(done, total int) {
+	if total <= 0 {
+		return
+	}
+	t.report(InlineTaskMsg{Event: InlineProgress, Done: min(max(done, 0), total), Total: total})
+}
+
+// Done marks the task finished, with an optional summary, e.g. the
+// installed version.
+func (t *InlineTask) Done(summary string) {
+	t.report(InlineTaskMsg{Event: InlineDone, Text: summary})
+}
+
+// Fail marks the task failed with err.
+func (t *InlineTask) Fail(err error) {
+	t.report(InlineTaskMsg{Event: InlineFailed, Err: err})
+}
+
+// report sends msg about the task to the model, unless the task has
+// finished.
+func (t *InlineTask) report(msg InlineTaskMsg) {
+	msg.ID, msg.Title = t.id, t.title
+
+	t.mu.Lock()
+	if t.finished {
+		t.mu.Unlock()
+		return
+	}
+	t.finished = msg.Event == InlineDone || msg.Event == InlineFailed
+	msgs := []Msg{msg}
+	if t.inline.transient && t.inline.program == nil {
+		// Plain output gets the whole task once it fails, and nothing of
+		// it otherwise.
+		switch msg.Event {
+		case InlineFailed:
+			msgs = append(t.held, msg)
+			t.held = nil
+		case InlineDone:
+			msgs, t.held = nil, nil
+		default:
+			msgs, t.held = nil, append(t.held, msg)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, msg := range msgs {
+		t.inline.Send(msg)
+	}
+}
+
+// --- Program Model ---
+
+// inlineModel hosts the model of an Inline on a terminal and ends its
+// last frame.
+type inlineModel struct {
+	inner     Model
+	transient bool
+	// Only the program's goroutine touches the fields below.
+	failed bool
+	closed bool
+}
+
+func (m *inlineModel) Init() Cmd {
+	return m.inner.Init()
+}
+
+func (m *inlineModel) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case InlineTaskMsg:
+		m.failed = m.failed || msg.Event == InlineFailed
+	case InlineCloseMsg:
+		m.closed = true
+	}
+	var cmd Cmd
+	m.inner, cmd = m.inner.Update(msg)
+	return m, cmd
+}
+
+func (m *inlineModel) View() string {
+	if !m.closed {
+		return m.inner.View()
+	}
+	// A transient region that went well leaves nothing behind.
+	if m.transient && !m.failed {
+		return ""
+	}
+	view := m.inner.View()
+	if view != "" && !strings.HasSuffix(view, "\n") {
+		// The program clears the line the cursor ends on when it stops.
+		view += "\n"
+	}
+	return view
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// taskLog draws the messages of an Inline's tasks as lines.
+type taskLog struct {
+	lines []string
+}
+
+func (l *taskLog) Init() Cmd { return nil }
+
+func (l *taskLog) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case InlineTaskMsg:
+		line := msg.Title
+		switch msg.Event {
+		case InlineStarted:
+			line += ": started"
+		case InlineStatus:
+			line += ": " + msg.Text
+		case InlineProgress:
+			line += fmt.Sprintf(": %d/%d", msg.Done, msg.Total)
+		case InlineDone:
+			line += ": done " + msg.Text
+		case InlineFailed:
+			line += ": failed " + msg.Err.Error()
+		}
+		l.lines = append(l.lines, strings.TrimSpace(line))
+	case InlineCloseMsg:
+		l.lines = append(l.lines, "closed")
+	}
+	return l, nil
+}
+
+func (l *taskLog) View() string { return strings.Join(l.lines, "\n") }
+
+func TestInlinePlainLines(t *testing.T) {
+	var out bytes.Buffer
+	ui := NewInline(context.Background(), &out, &taskLog{})
+
+	fetch := ui.Task("Fetching index")
+	fetch.Status("mirror 1")
+	fetch.Progress(60, 40)
+	fetch.Progress(1, 0)
+	fetch.Done("312 tools")
+	fetch.Status("ignored once finished")
+
+	build := ui.Task("Building")
+	build.Fail(errors.New("exit status 2"))
+	ui.Println("2 tasks")
+	if err := ui.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := ui.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+
+	want := []string{
+		"Fetching index: started",
+		"Fetching index: mirror 1",
+		"Fetching index: 40/40",
+		"Fetching index: done 312 tools",
+		"Building: started",
+		"Building: failed exit status 2",
+		"2 tasks",
+		"closed",
+		"",
+	}
+	if got := out.String(); got != strings.Join(want, "\n") {
+		t.Errorf("output:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestInlineLiveRegionLeavesLastFrame(t *testing.T) {
+	var out bytes.Buffer
+	ui := newInline(context.Background(), &out, &taskLog{}, true)
+
+	ui.Task("Installing").Done("ok")
+	if err := ui.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// The last frame ends the line, so the program does not clear it.
+	got := ansi.Strip(out.String())
+	if want := "closed\r\n"; !strings.Contains(got, want) {
+		t.Errorf("output %q does not contain %q", got, want)
+	}
+}
+
+func TestInlineTransient(t *testing.T) {
+	var out bytes.Buffer
+	ui := NewInline(context.Background(), &out, &taskLog{}, WithTransient(true))
+
+	read := ui.Task("Reading CHANGELOG.md")
+	read.Status("opening")
+	read.Done("v1.2.0")
+	parse := ui.Task("Parsing")
+	parse.Status("line 3")
+	parse.Fail(errors.New("no version found"))
+	if err := ui.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := "Parsing: started\nParsing: line 3\nParsing: failed no version found\nclosed\n"
+	if got := out.String(); got != want {
+		t.Errorf("output = %q, want only the failed task %q", got, want)
+	}
+}
+
+func TestInlineTransientLiveRegion(t *testing.T) {
+	m := &inlineModel{inner: &taskLog{}, transient: true}
+	m.Update(InlineTaskMsg{Title: "Reading", Event: InlineDone})
+	m.Update(InlineCloseMsg{})
+	if got := m.View(); got != "" {
+		t.Errorf("View() = %q, want nothing once every task succeeded", got)
+	}
+
+	m.Update(InlineTaskMsg{Title: "Parsing", Event: InlineFailed, Err: errors.New("boom")})
+	if got := m.View(); !strings.HasSuffix(got, "Parsing: failed boom\n") {
+		t.Errorf("View() = %q, want the frame kept after a failure", got)
+	}
+}
+
+func TestInlineFromGoroutines(t *testing.T) {
+	var out bytes.Buffer
+	ui := NewInline(context.Background(), &out, &taskLog{})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task := ui.Task(fmt.Sprintf("task %d", i))
+			task.Status("working")
+			ui.Println("note")
+			task.Done("")
+		}()
+	}
+	wg.Wait()
+	if err := ui.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != 8*4+1 {
+		t.Errorf("%d lines written, want %d", lines, 8*4+1)
+	}
+}
+
+func TestInlineWaitsForTheModel(t *testing.T) {
+	var out bytes.Buffer
+	ui := NewInline(context.Background(), &out, &counter{})
+	ui.Send(2)
+	ui.Send("stop")
+
+	final, err := ui.Wait()
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if got := final.(*counter).n; got != 2 {
+		t.Errorf("final count = %d, want 2", got)
+	}
+	if err := ui.Close(); err != nil {
+		t.Errorf("Close() after the model quit = %v", err)
+	}
+}
@@ -0,0 +1,387 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// GlobalScope is the scope of bindings that are active on every screen.
+const GlobalScope = "global"
+
+// Action names a command the user triggers with keys, e.g. "shell.help".
+// Actions are prefixed with the scope that registers them.
+type Action string
+
+// Binding is an action with its help text and the keys bound to it.
+type Binding struct {
+	Action   Action
+	Scope    string
+	Help     string
+	Keys     []string // effective keys, after user overrides
+	Defaults []string // keys registered by the code
+}
+
+// Conflict reports a key bound to several actions that can be active at
+// the same time: actions of the same scope, of a scope and GlobalScope, or
+// of two outer scopes. A conflict between an outer scope and widget scopes
+// is Shadowed: its first action is the outer one, which never runs while
+// one of the widgets after it has focus, as they get the key first.
+type Conflict struct {
+	Key      string
+	Actions  []Action
+	Shadowed bool
+}
+
+func (c Conflict) String() string {
+	names := make([]string, len(c.Actions))
+	for i, a := range c.Actions {
+		names[i] = string(a)
+	}
+	if c.Shadowed {
+		return fmt.Sprintf("%s is bound to %s, which %s takes while focused",
+			c.Key, names[0], strings.Join(names[1:], ", "))
+	}
+	return fmt.Sprintf("%s is bound to %s", c.Key, strings.Join(names, ", "))
+}
+
+// Keymap is the registry of named actions and their key bindings. Screens
+// and widgets register their actions with default keys when their package
+// is initialized; the user's config can then rebind them by action name.
+type Keymap struct {
+	mu        sync.RWMutex
+	bindings  map[Action]*Binding
+	order     []Action
+	overrides map[Action][]string
+	outer     map[string]bool
+}
+
+// Keys is the keymap used by the application.
+var Keys = NewKeymap()
+
+// ActionQuit quits the program from any screen.
+var ActionQuit = Keys.Register(GlobalScope, "app.quit", "Quit", "ctrl+c")
+
+// NewKeymap creates an empty keymap.
+func NewKeymap() *Keymap {
+	return &Keymap{
+		bindings:  make(map[Action]*Binding),
+		overrides: make(map[Action][]string),
+		outer:     make(map[string]bool),
+	}
+}
+
+// AddOuterScopes declares scopes whose bindings stay active whatever widget
+// has focus, such as the Shell's and the focus traversal keys. Their keys
+// are checked against every widget scope by Conflicts.
+func (k *Keymap) AddOuterScopes(scopes ...string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for _, scope := range scopes {
+		k.outer[scope] = true
+	}
+}
+
+// Register adds action to scope with its help text and default keys, and
+// returns the action so it can be kept in a package variable:
+//
+//	var actionRefresh = tui.Keys.Register("tools", "tools.refresh", "Refresh", "r", "f5")
+//
+// Registering an action again replaces its scope, help and defaults. Keys
+// are written as KeyMsg.String prints them; invalid ones are ignored.
+func (k *Keymap) Register(scope string, action Action, help string, keys ...string) Action {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	defaults := normalizeKeys(keys)
+	b, exists := k.bindings[action]
+	if !exists {
+		b = &Binding{Action: action}
+		k.bindings[action] = b
+		k.order = append(k.order, action)
+	}
+	b.Scope, b.Help, b.Defaults = scope, help, defaults
+	b.Keys = defaults
+	if override, ok := k.overrides[action]; ok {
+		b.Keys = override
+	}
+	return action
+}
+
+// Rebind replaces the keys of action. No keys unbinds it. It fails if the
+// action is not registered or a key cannot be parsed.
+func (k *Keymap) Rebind(action Action, keys ...string) error {
+	normalized := make([]string, 0, len(keys))
+	for _, key := range keys {
+		msg, err := ParseKey(key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", action, err)
+		}
+		normalized = append(normalized, msg.String())
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	b, ok := k.bindings[action]
+	if !ok {
+		return fmt.Errorf("%s: unknown action", action)
+	}
+	k.overrides[action] = normalized
+	b.Keys = normalized
+	return nil
+}
+
+// Load applies user overrides, as read from the config file, mapping action
+// names to keys. Every invalid entry is reported; the valid ones are applied.
+func (k *Keymap) Load(overrides map[string][]string) error {
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	var errs []error
+	for _, action := range actions {
+		if err := k.Rebind(Action(action), overrides[action]...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Reset drops every user override and restores the default keys.
+func (k *Keymap) Reset() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	clear(k.overrides)
+	for _, b := range k.bindings {
+		b.Keys = b.Defaults
+	}
+}
+
+// Matches reports whether msg is one of the keys bound to action.
+func (k *Keymap) Matches(msg KeyMsg, action Action) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	b, ok := k.bindings[action]
+	return ok && slices.Contains(b.Keys, msg.String())
+}
+
+// MatchesScope reports whether msg is bound to any action of scope.
+func (k *Keymap) MatchesScope(msg KeyMsg, scope string) bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	key := msg.String()
+	for _, b := range k.bindings {
+		if b.Scope == scope && slices.Contains(b.Keys, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Binding returns the binding of action.
+func (k *Keymap) Binding(action Action) (Binding, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	b, ok := k.bindings[action]
+	if !ok {
+		return Binding{}, false
+	}
+	return *b, true
+}
+
+// Conflicts returns the keys bound to more than one action that can be
+// active at the same time, sorted by key.
+//
+// An outer action shadowed by a widget is only reported when the user
+// rebound one of the two: the default keys overlap on purpose, e.g. Esc
+// clears a list filter before it reaches the Shell.
+func (k *Keymap) Conflicts() []Conflict {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var conflicts []Conflict
+	for _, key := range k.boundKeysLocked() {
+		var users []*Binding
+		for _, action := range k.order {
+			if b := k.bindings[action]; slices.Contains(b.Keys, key) {
+				users = append(users, b)
+			}
+		}
+		var found *Conflict
+		for i, b := range users {
+			actions := []Action{b.Action}
+			for _, other := range users[i+1:] {
+				switch {
+				case k.clashLocked(b.Scope, other.Scope):
+					actions = append(actions, other.Action)
+				case k.outer[b.Scope] != k.outer[other.Scope] && (k.reboundLocked(b) || k.reboundLocked(other)):
+					outer, inner := b, other
+					if k.outer[other.Scope] {
+						outer, inner = other, b
+					}
+					if found == nil {
+						found = &Conflict{Key: key, Actions: []Action{outer.Action}, Shadowed: true}
+					}
+					if found.Actions[0] == outer.Action && !slices.Contains(found.Actions, inner.Action) {
+						found.Actions = append(found.Actions, inner.Action)
+					}
+				}
+			}
+			if len(actions) > 1 {
+				found = &Conflict{Key: key, Actions: actions}
+				break
+			}
+		}
+		if found != nil {
+			conflicts = append(conflicts, *found)
+		}
+	}
+	return conflicts
+}
+
+// clashLocked reports whether the bindings of two scopes are matched
+// against the same key at the same level: the same scope, either scope
+// global, or two outer scopes. The caller must hold k.mu.
+func (k *Keymap) clashLocked(a, b string) bool {
+	return a == b || a == GlobalScope || b == GlobalScope || (k.outer[a] && k.outer[b])
+}
+
+// reboundLocked reports whether the user changed the keys of b. The caller
+// must hold k.mu.
+func (k *Keymap) reboundLocked(b *Binding) bool {
+	_, ok := k.overrides[b.Action]
+	return ok
+}
+
+// Help returns the bindings active for the given scopes, in the order of
+// the scopes and then of registration, followed by the global ones.
+// Actions without keys or help text are left out.
+func (k *Keymap) Help(scopes ...string) []Binding {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	var help []Binding
+	for _, scope := range append(slices.Clone(scopes), GlobalScope) {
+		for _, action := range k.order {
+			b := k.bindings[action]
+			if b.Scope == scope && b.Help != "" && len(b.Keys) > 0 {
+				help = append(help, *b)
+			}
+		}
+	}
+	return help
+}
+
+// HelpView renders the bindings active for the given scopes as a two
+// column list of keys and descriptions.
+func (k *Keymap) HelpView(scopes ...string) string {
+	help := k.Help(scopes...)
+	if len(help) == 0 {
+		return ""
+	}
+
+	keys := make([]string, len(help))
+	width := 0
+	for i, b := range help {
+		keys[i] = strings.Join(b.Keys, ", ")
+		width = max(width, Width(keys[i]))
+	}
+
+	keyStyle := NewStyle().Bold(true).Width(width + 2)
+	lines := make([]string, len(help))
+	for i, b := range help {
+		lines[i] = keyStyle.Render(keys[i]) + b.Help
+	}
+	return strings.Join(lines, "\n")
+}
+
+// boundKeysLocked returns every bound key, sorted. The caller must hold k.mu.
+func (k *Keymap) boundKeysLocked() []string {
+	var keys []string
+	for _, b := range k.bindings {
+		for _, key := range b.Keys {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// KeyScoper is implemented by models with key bindings of their own, so
+// the help view can list the bindings of whatever is on screen. KeyScopes
+// returns the scopes active in the model, e.g. its own and the focused
+// child's.
+type KeyScoper interface {
+	KeyScopes() []string
+}
+
+// --- Key Parsing ---
+
+// ParseKey parses a key written as KeyMsg.String prints it, e.g. "ctrl+c",
+// "shift+tab", "alt+enter", "?" or "f5". Modifiers may come in any order.
+func ParseKey(s string) (KeyMsg, error) {
+	parts := strings.Split(s, "+")
+	name := parts[len(parts)-1]
+	mods := parts[:len(parts)-1]
+	if name == "" && len(parts) > 1 {
+		// "ctrl++" binds the plus key itself.
+		name, mods = "+", parts[:len(parts)-2]
+	}
+
+	var msg KeyMsg
+	for _, mod := range mods {
+		switch mod {
+		case "ctrl":
+			msg.Ctrl = true
+		case "alt":
+			msg.Alt = true
+		case "shift":
+			msg.Shift = true
+		default:
+			return KeyMsg{}, fmt.Errorf("invalid key %q: unknown modifier %q", s, mod)
+		}
+	}
+
+	if t, ok := keyTypesByName[name]; ok {
+		msg.Type = t
+		return msg, nil
+	}
+	if utf8.RuneCountInString(name) == 1 {
+		msg.Type, msg.Runes = KeyRunes, []rune(name)
+		return msg, nil
+	}
+	return KeyMsg{}, fmt.Errorf("invalid key %q", s)
+}
+
+// keyTypesByName maps key names back to their types. Runes and the
+// ctrl+c/ctrl+d types are written as a rune with the ctrl modifier.
+var keyTypesByName = func() map[string]KeyType {
+	m := make(map[string]KeyType, len(keyNames))
+	for t, name := range keyNames {
+		switch t {
+		case KeyRunes, KeyCtrlC, KeyCtrlD:
+			continue
+		}
+		m[name] = t
+	}
+	return m
+}()
+
+// normalizeKeys returns keys in KeyMsg.String form, dropping invalid ones.
+func normalizeKeys(keys []string) []string {
+	normalized := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if msg, err := ParseKey(key); err == nil {
+			normalized = append(normalized, msg.String())
+		}
+	}
+	return normalized
+}
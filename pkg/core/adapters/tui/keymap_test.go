@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"ctrl+c", "ctrl+c"},
+		{"shift+ctrl+up", "ctrl+shift+up"},
+		{"alt+enter", "alt+enter"},
+		{"?", "?"},
+		{"ctrl++", "ctrl++"},
+		{"f5", "f5"},
+		{"space", "space"},
+	}
+	for _, tt := range tests {
+		msg, err := ParseKey(tt.in)
+		if err != nil {
+			t.Errorf("ParseKey(%q) error: %v", tt.in, err)
+			continue
+		}
+		if msg.String() != tt.want {
+			t.Errorf("ParseKey(%q).String() = %q, want %q", tt.in, msg.String(), tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "hyper+x", "enterr"} {
+		if _, err := ParseKey(bad); err == nil {
+			t.Errorf("ParseKey(%q) did not fail", bad)
+		}
+	}
+}
+
+func TestKeymapMatchesAndRebind(t *testing.T) {
+	k := NewKeymap()
+	refresh := k.Register("tools", "tools.refresh", "Refresh", "r", "f5")
+
+	if !k.Matches(KeyMsg{Type: KeyRunes, Runes: []rune("r")}, refresh) {
+		t.Error("r does not match the default binding")
+	}
+	if !k.Matches(KeyMsg{Type: KeyF5}, refresh) {
+		t.Error("f5 does not match the default binding")
+	}
+
+	if err := k.Load(map[string][]string{"tools.refresh": {"ctrl+r"}}); err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if k.Matches(KeyMsg{Type: KeyRunes, Runes: []rune("r")}, refresh) {
+		t.Error("the default key still matches after the override")
+	}
+	if !k.Matches(KeyMsg{Type: KeyRunes, Runes: []rune("r"), Ctrl: true}, refresh) {
+		t.Error("ctrl+r does not match the override")
+	}
+
+	// Registering again (e.g. a second widget instance) keeps the override.
+	k.Register("tools", "tools.refresh", "Refresh", "r")
+	if b, _ := k.Binding(refresh); !reflect.DeepEqual(b.Keys, []string{"ctrl+r"}) {
+		t.Errorf("keys after re-registering = %v, want [ctrl+r]", b.Keys)
+	}
+
+	k.Reset()
+	if b, _ := k.Binding(refresh); !reflect.DeepEqual(b.Keys, []string{"r"}) {
+		t.Errorf("keys after Reset = %v, want [r]", b.Keys)
+	}
+
+	err := k.Load(map[string][]string{"tools.nope": {"x"}, "tools.refresh": {"hyper+x"}})
+	if err == nil || !strings.Contains(err.Error(), "tools.nope") || !strings.Contains(err.Error(), "hyper+x") {
+		t.Errorf("Load() error = %v, want both invalid entries reported", err)
+	}
+}
+
+func TestKeymapConflicts(t *testing.T) {
+	k := NewKeymap()
+	k.Register(GlobalScope, "app.quit", "Quit", "ctrl+c")
+	k.Register("list", "list.open", "Open", "enter")
+	k.Register("list", "list.select", "Select", "space")
+	k.Register("form", "form.submit", "Submit", "enter")
+
+	// Same key in scopes that are never active together is fine.
+	if c := k.Conflicts(); len(c) != 0 {
+		t.Fatalf("Conflicts() = %v, want none", c)
+	}
+
+	if err := k.Rebind("list.select", "enter"); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Rebind("form.submit", "ctrl+c"); err != nil {
+		t.Fatal(err)
+	}
+	want := []Conflict{
+		{Key: "ctrl+c", Actions: []Action{"app.quit", "form.submit"}},
+		{Key: "enter", Actions: []Action{"list.open", "list.select"}},
+	}
+	if got := k.Conflicts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Conflicts() = %v, want %v", got, want)
+	}
+}
+
+func TestKeymapConflictsAcrossOuterScopes(t *testing.T) {
+	k := NewKeymap()
+	k.AddOuterScopes("shell", "focus")
+	k.Register("shell", "shell.help", "Help", "?")
+	k.Register("shell", "shell.back", "Back", "esc")
+	k.Register("focus", "focus.next", "Next", "tab")
+	k.Register("list", "list.clear", "Clear filter", "esc")
+	k.Register("table", "table.filter", "Filter", "/")
+	k.Register("list", "list.filter", "Filter", "/")
+
+	// Widgets shadowing outer keys by default is intended.
+	if c := k.Conflicts(); len(c) != 0 {
+		t.Fatalf("Conflicts() = %v, want none", c)
+	}
+
+	if err := k.Rebind("shell.help", "/"); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Rebind("focus.next", "esc"); err != nil {
+		t.Fatal(err)
+	}
+	want := []Conflict{
+		{Key: "/", Actions: []Action{"shell.help", "table.filter", "list.filter"}, Shadowed: true},
+		{Key: "esc", Actions: []Action{"shell.back", "focus.next"}},
+	}
+	if got := k.Conflicts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Conflicts() = %v, want %v", got, want)
+	}
+}
+
+func TestKeymapHelp(t *testing.T) {
+	k := NewKeymap()
+	k.Register(GlobalScope, "app.quit", "Quit", "ctrl+c")
+	k.Register("list", "list.open", "Open", "enter")
+	k.Register("list", "list.hidden", "", "x")
+	k.Register("form", "form.submit", "Submit", "enter")
+	k.Register("list", "list.filter", "Filter", "/", "ctrl+f")
+
+	var got []Action
+	for _, b := range k.Help("list") {
+		got = append(got, b.Action)
+	}
+	want := []Action{"list.open", "list.filter", "app.quit"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Help(list) = %v, want %v", got, want)
+	}
+
+	wantView := strings.Join([]string{
+		"enter      Open",
+		"/, ctrl+f  Filter",
+		"ctrl+c     Quit",
+	}, "\n")
+	if view := k.HelpView("list"); view != wantView {
+		t.Errorf("HelpView() =\n%s\nwant\n%s", view, wantView)
+	}
+}
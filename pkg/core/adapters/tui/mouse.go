@@ -0,0 +1,111 @@
+package tui
+
+import "strings"
+
+// MouseMsg is sent when the mouse is pressed, released, moved or scrolled.
+// X and Y are zero-based cell coordinates relative to the top-left corner of
+// the program's frame. Use Region.Local to translate them into a widget's
+// own coordinate space.
+type MouseMsg struct {
+	X, Y   int
+	Action MouseAction
+	Button MouseButton
+
+	Alt   bool
+	Ctrl  bool
+	Shift bool
+}
+
+// IsWheel returns true if the event comes from the scroll wheel.
+func (m MouseMsg) IsWheel() bool {
+	switch m.Button {
+	case MouseButtonWheelUp, MouseButtonWheelDown, MouseButtonWheelLeft, MouseButtonWheelRight:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns a textual representation of the event, such as
+// "left press", "ctrl+wheel up" or "motion".
+func (m MouseMsg) String() string {
+	var b strings.Builder
+	if m.Ctrl {
+		b.WriteString("ctrl+")
+	}
+	if m.Alt {
+		b.WriteString("alt+")
+	}
+	if m.Shift {
+		b.WriteString("shift+")
+	}
+
+	switch {
+	case m.IsWheel():
+		b.WriteString(m.Button.String())
+	case m.Button == MouseButtonNone:
+		b.WriteString(m.Action.String())
+	default:
+		b.WriteString(m.Button.String())
+		b.WriteString(" ")
+		b.WriteString(m.Action.String())
+	}
+	return b.String()
+}
+
+// MouseAction defines what happened during a mouse event.
+type MouseAction int
+
+// Mouse actions.
+const (
+	MouseActionPress MouseAction = iota
+	MouseActionRelease
+	MouseActionMotion
+)
+
+// String returns a string representation of the mouse action.
+func (a MouseAction) String() string {
+	return mouseActionNames[a]
+}
+
+var mouseActionNames = map[MouseAction]string{
+	MouseActionPress:   "press",
+	MouseActionRelease: "release",
+	MouseActionMotion:  "motion",
+}
+
+// MouseButton defines which button was involved in a mouse event.
+type MouseButton int
+
+// Mouse buttons. This is a subset of bubbletea's buttons for abstraction
+// purposes.
+const (
+	MouseButtonNone MouseButton = iota
+	MouseButtonLeft
+	MouseButtonMiddle
+	MouseButtonRight
+	MouseButtonWheelUp
+	MouseButtonWheelDown
+	MouseButtonWheelLeft
+	MouseButtonWheelRight
+	MouseButtonBackward
+	MouseButtonForward
+)
+
+// String returns a string representation of the mouse button.
+func (b MouseButton) String() string {
+	return mouseButtonNames[b]
+}
+
+var mouseButtonNames = map[MouseButton]string{
+	MouseButtonNone:       "none",
+	MouseButtonLeft:       "left",
+	MouseButtonMiddle:     "middle",
+	MouseButtonRight:      "right",
+	MouseButtonWheelUp:    "wheel up",
+	MouseButtonWheelDown:  "wheel down",
+	MouseButtonWheelLeft:  "wheel left",
+	MouseButtonWheelRight: "wheel right",
+	MouseButtonBackward:   "backward",
+	MouseButtonForward:    "forward",
+}
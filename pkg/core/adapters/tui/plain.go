@@ -0,0 +1,295 @@
+package tui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/term"
+)
+
+// The plain renderer runs a model without taking over the terminal: no
+// alternate screen, no cursor movement, no colors. It is meant for CI logs,
+// pipes and screen readers, where a full-screen UI is unusable.
+//
+// Output is a linear transcript: each time the model's text changes, the
+// new text is written below the previous one. When the new text only adds
+// lines (e.g. a progress log), just the added lines are written.
+//
+// Input is read a line at a time. A line naming a key ("down", "enter",
+// "esc", "ctrl+c", "q") is delivered as that key, an empty line as Enter,
+// and any other text as a paste followed by Enter. Once the input ends
+// and the commands it started have finished, the renderer stops.
+
+// Default size reported to models run by the plain renderer.
+const (
+	plainWidth  = 80
+	plainHeight = 24
+)
+
+// Accessible is implemented by models that can describe their content as
+// plain text, without box drawing or layout: headings, menus as lists,
+// values as "label: value" lines. The plain renderer prefers this form over
+// the model's View.
+type Accessible interface {
+	AccessibleView() string
+}
+
+// AccessibleText returns model's accessible form, or its view with styles,
+// zone markers and trailing blanks removed when it has none.
+func AccessibleText(model Model) string {
+	if a, ok := model.(Accessible); ok {
+		return a.AccessibleView()
+	}
+	return plainText(model.View())
+}
+
+// IsInteractive reports whether out can host the full-screen UI: it must be
+// a terminal, and TERM must not be "dumb".
+func IsInteractive(out io.Writer) bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := out.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(f.Fd())
+}
+
+// RunPlain runs model with the plain renderer until it quits, the input
+// ends (see above) or ctx is cancelled, and returns the final model. A cancelled ctx
+// returns ErrProgramKilled wrapping the context error.
+func RunPlain(ctx context.Context, model Model, in io.Reader, out io.Writer) (Model, error) {
+	r := newPlainRenderer(in, out)
+	defer close(r.done)
+	return r.run(ctx, model)
+}
+
+func newPlainRenderer(in io.Reader, out io.Writer) *plainRenderer {
+	return &plainRenderer{
+		out:  out,
+		in:   in,
+		msgs: make(chan Msg),
+		done: make(chan struct{}),
+	}
+}
+
+type plainRenderer struct {
+	in   io.Reader
+	out  io.Writer
+	msgs chan Msg
+	done chan struct{}
+	last string
+
+	// pending counts the commands still running; the renderer only quits
+	// on the end of input once they are done. Only the loop touches it.
+	pending int
+	eof     bool
+}
+
+// cmdDone is sent by a finished command with the message it produced,
+// which may be nil.
+type cmdDone struct{ msg Msg }
+
+// inputEOF is sent once the input has ended.
+type inputEOF struct{}
+
+// printLineMsg writes text above the model's output, e.g. for
+// Inline.Println.
+type printLineMsg struct{ text string }
+
+func (r *plainRenderer) run(ctx context.Context, model Model) (Model, error) {
+	r.exec(model.Init())
+	model, cmd := model.Update(WindowSizeMsg{Width: plainWidth, Height: plainHeight})
+	r.exec(cmd)
+	if r.in != nil {
+		go r.readInput()
+	}
+	if err := r.render(model); err != nil {
+		return model, err
+	}
+
+	for {
+		var msg Msg
+		select {
+		case <-ctx.Done():
+			return model, fmt.Errorf("%w: %w", ErrProgramKilled, ctx.Err())
+		case msg = <-r.msgs:
+		}
+
+		switch m := msg.(type) {
+		case cmdDone:
+			r.pending--
+			msg = m.msg
+		case inputEOF:
+			r.eof = true
+			msg = nil
+		}
+
+		switch m := msg.(type) {
+		case nil:
+			if r.eof && r.pending == 0 {
+				return model, nil
+			}
+			continue
+		case QuitMsg:
+			return model, nil
+		case printLineMsg:
+			if _, err := io.WriteString(r.out, m.text); err != nil {
+				return model, err
+			}
+			continue
+		case BatchMsg:
+			for _, cmd := range m {
+				r.exec(cmd)
+			}
+			continue
+		case SequenceMsg:
+			r.pending++
+			go func() {
+				r.sequence(m)
+				r.send(cmdDone{})
+			}()
+			continue
+		}
+
+		model, cmd = model.Update(msg)
+		r.exec(cmd)
+		if err := r.render(model); err != nil {
+			return model, err
+		}
+		if r.eof && r.pending == 0 {
+			return model, nil
+		}
+	}
+}
+
+// render writes the model's text if it changed since the last render.
+func (r *plainRenderer) render(model Model) error {
+	text := AccessibleText(model)
+	if text == r.last {
+		return nil
+	}
+
+	var out string
+	switch {
+	case r.last == "":
+		out = text
+	case strings.HasPrefix(text, r.last+"\n"):
+		// Only lines were added: write just those.
+		out = strings.TrimPrefix(text, r.last+"\n")
+	default:
+		out = "\n" + text
+	}
+	r.last = text
+
+	if out == "" {
+		return nil
+	}
+	_, err := io.WriteString(r.out, out+"\n")
+	return err
+}
+
+// exec runs cmd in the background and feeds its message back into the loop.
+func (r *plainRenderer) exec(cmd Cmd) {
+	if cmd == nil {
+		return
+	}
+	r.pending++
+	go func() {
+		r.send(cmdDone{r.call(cmd)})
+	}()
+}
+
+// sequence runs cmds one after another, delivering each message before the
+// next command starts.
+func (r *plainRenderer) sequence(cmds SequenceMsg) {
+	for _, cmd := range cmds {
+		msg := r.call(cmd)
+		switch msg := msg.(type) {
+		case nil:
+		case BatchMsg:
+			var wg sync.WaitGroup
+			for _, c := range msg {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if m := r.call(c); m != nil {
+						r.send(m)
+					}
+				}()
+			}
+			wg.Wait()
+		case SequenceMsg:
+			r.sequence(msg)
+		default:
+			if !r.send(msg) {
+				return
+			}
+		}
+	}
+}
+
+// call runs cmd, handling the messages that need the renderer's streams.
+// There is no screen to release, so processes run in place.
+func (r *plainRenderer) call(cmd Cmd) Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	if exec, ok := msg.(ExecMsg); ok {
+		exec.Command.SetStdin(r.in)
+		exec.Command.SetStdout(r.out)
+		exec.Command.SetStderr(r.out)
+		return exec.OnExit(exec.Command.Run())
+	}
+	return msg
+}
+
+// send delivers msg to the loop. It reports false once the loop has ended.
+func (r *plainRenderer) send(msg Msg) bool {
+	select {
+	case r.msgs <- msg:
+		return true
+	case <-r.done:
+		return false
+	}
+}
+
+// readInput turns each input line into messages.
+func (r *plainRenderer) readInput() {
+	scanner := bufio.NewScanner(r.in)
+	for scanner.Scan() {
+		for _, msg := range lineMsgs(scanner.Text()) {
+			if !r.send(msg) {
+				return
+			}
+		}
+	}
+	r.send(inputEOF{})
+}
+
+// lineMsgs converts a line of input into the messages it stands for.
+func lineMsgs(line string) []Msg {
+	line = strings.TrimRight(line, "\r")
+	if line == "" {
+		return []Msg{KeyMsg{Type: KeyEnter}}
+	}
+	if key, err := ParseKey(line); err == nil {
+		return []Msg{key}
+	}
+	return []Msg{PasteMsg{Text: line}, KeyMsg{Type: KeyEnter}}
+}
+
+// plainText strips zone markers and ANSI sequences from view, along with
+// trailing blanks on each line and trailing empty lines.
+func plainText(view string) string {
+	lines := strings.Split(ansi.Strip(StripZones(view)), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// menuModel is a small accessible menu: up/down move, enter picks, and
+// pasted text is echoed back.
+type menuModel struct {
+	items  []string
+	cursor int
+	picked []string
+	width  int
+}
+
+func (m *menuModel) Init() Cmd { return nil }
+
+func (m *menuModel) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case WindowSizeMsg:
+		m.width = msg.Width
+	case PasteMsg:
+		m.picked = append(m.picked, "typed "+msg.Text)
+	case KeyMsg:
+		switch msg.String() {
+		case "down":
+			m.cursor = min(m.cursor+1, len(m.items)-1)
+		case "enter":
+			m.picked = append(m.picked, m.items[m.cursor])
+		case "q":
+			return m, Quit
+		}
+	}
+	return m, nil
+}
+
+func (m *menuModel) View() string { return "\x1b[1mnot used\x1b[0m" }
+
+func (m *menuModel) AccessibleView() string {
+	lines := []string{fmt.Sprintf("Menu (%d cols):", m.width)}
+	for i, item := range m.items {
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+		}
+		lines = append(lines, marker+item)
+	}
+	for _, p := range m.picked {
+		lines = append(lines, "picked "+p)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRunPlainTranscript(t *testing.T) {
+	model := &menuModel{items: []string{"Home", "Tools"}}
+	in := strings.NewReader("down\n\nhello\nq\nup\n")
+	var out bytes.Buffer
+
+	if _, err := RunPlain(context.Background(), model, in, &out); err != nil {
+		t.Fatalf("RunPlain: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"Menu (80 cols):",
+		"> Home",
+		"  Tools",
+		"",
+		"Menu (80 cols):",
+		"  Home",
+		"> Tools",
+		// Added lines are written on their own.
+		"picked Tools",
+		"picked typed hello",
+		"picked Tools",
+		"",
+	}, "\n")
+	if got := out.String(); got != want {
+		t.Errorf("transcript:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRunPlainWaitsForCommandsAtEndOfInput(t *testing.T) {
+	model := &tickModel{}
+	var out bytes.Buffer
+
+	if _, err := RunPlain(context.Background(), model, strings.NewReader(""), &out); err != nil {
+		t.Fatalf("RunPlain: %v", err)
+	}
+	if got, want := out.String(), "waiting\n\ndone\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+type tickModel struct{ done bool }
+
+func (m *tickModel) Init() Cmd {
+	return Tick(10*time.Millisecond, func(time.Time) Msg { return "tick" })
+}
+
+func (m *tickModel) Update(msg Msg) (Model, Cmd) {
+	if msg == "tick" {
+		m.done = true
+	}
+	return m, nil
+}
+
+func (m *tickModel) View() string {
+	if m.done {
+		return "\x1b[32mdone\x1b[0m   \n\n"
+	}
+	return "waiting"
+}
+
+func TestRunPlainCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := RunPlain(ctx, &tickModel{}, nil, &bytes.Buffer{})
+	if !errors.Is(err, ErrProgramKilled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want ErrProgramKilled wrapping context.Canceled", err)
+	}
+}
+
+func TestIsInteractive(t *testing.T) {
+	if IsInteractive(&bytes.Buffer{}) {
+		t.Error("a buffer is not interactive")
+	}
+}
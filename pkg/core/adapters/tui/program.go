@@ -0,0 +1,149 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var (
+	// ErrProgramKilled is returned by Wait when the program was stopped
+	// before it could quit on its own, e.g. because its context was
+	// cancelled. It wraps the context error when there is one.
+	ErrProgramKilled = tea.ErrProgramKilled
+
+	// ErrInterrupted is returned by Wait when the user pressed ctrl+c and
+	// the model did not handle it.
+	ErrInterrupted = tea.ErrInterrupted
+
+	// ErrProgramStarted is returned by Start when the program is already
+	// running or has already finished.
+	ErrProgramStarted = errors.New("program already started")
+
+	// ErrProgramNotStarted is returned by Wait when Start was never called.
+	ErrProgramNotStarted = errors.New("program not started")
+)
+
+// Program is a handle to a running TUI program. Unlike Run, it does not block:
+// the program runs in the background after Start, other goroutines can push
+// messages into it with Send, and Wait collects its final model and error.
+type Program struct {
+	tea *tea.Program
+
+	mu      sync.Mutex
+	started bool
+	quit    bool // a Quit before Start, honoured once it runs
+	done    chan struct{}
+	final   Model
+	err     error
+}
+
+// NewProgram creates a program for the given model. It does not touch the
+// terminal until Start is called.
+func NewProgram(model Model, opts ...ProgramOption) *Program {
+	var config programConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	adapter := &modelAdapter{inner: model, recorder: config.recorder}
+	return &Program{
+		tea:   tea.NewProgram(adapter, config.tea...),
+		final: model,
+		done:  make(chan struct{}),
+	}
+}
+
+// Start runs the program in the background. Cancelling ctx stops the
+// program and restores the terminal; Wait then returns ErrProgramKilled.
+func (p *Program) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.started {
+		return ErrProgramStarted
+	}
+	p.started = true
+	if p.quit {
+		// Quit blocks until the program reads it.
+		go p.tea.Quit()
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			p.tea.Kill()
+		case <-p.done:
+		}
+	}()
+
+	go func() {
+		finalModel, err := p.tea.Run()
+
+		p.mu.Lock()
+		if adapter, ok := finalModel.(*modelAdapter); ok {
+			p.final = adapter.inner
+		}
+		if errors.Is(err, tea.ErrProgramKilled) && ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+			err = fmt.Errorf("%w: %w", tea.ErrProgramKilled, ctx.Err())
+		}
+		p.err = err
+		p.mu.Unlock()
+
+		close(p.done)
+	}()
+
+	return nil
+}
+
+// Send delivers a message to the model's Update from outside the program,
+// e.g. from a background goroutine. It blocks until the program is running
+// and is a no-op once the program has finished.
+func (p *Program) Send(msg Msg) {
+	p.tea.Send(msg)
+}
+
+// Quit asks the program to exit, as if the model had returned Quit.
+// It is safe to call before Start or after the program has finished.
+func (p *Program) Quit() {
+	p.mu.Lock()
+	if !p.started {
+		// Nothing reads the program's messages before Start.
+		p.quit = true
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	p.tea.Quit()
+}
+
+// Wait blocks until the program has finished and returns its final model
+// and the error that stopped it, if any.
+func (p *Program) Wait() (Model, error) {
+	p.mu.Lock()
+	started := p.started
+	p.mu.Unlock()
+	if !started {
+		return nil, ErrProgramNotStarted
+	}
+
+	<-p.done
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.final, p.err
+}
+
+// Println prints a line above the program's UI, outside of the managed
+// frame, so it persists across renders. Nothing is printed while the
+// program is in the alternate screen.
+func (p *Program) Println(args ...any) {
+	p.tea.Send(tea.Println(args...)())
+}
+
+// Printf is like Println but takes a format template.
+func (p *Program) Printf(template string, args ...any) {
+	p.tea.Send(tea.Printf(template, args...)())
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// counter counts the ints it receives and quits on "stop".
+type counter struct{ n int }
+
+func (c *counter) Init() Cmd { return nil }
+
+func (c *counter) Update(msg Msg) (Model, Cmd) {
+	switch msg := msg.(type) {
+	case int:
+		c.n += msg
+	case string:
+		if msg == "stop" {
+			return c, Quit
+		}
+	}
+	return c, nil
+}
+
+func (c *counter) View() string { return "" }
+
+// newTestProgram returns a program wired to in-memory streams. The input
+// never delivers data, so the program only stops when told to.
+func newTestProgram(model Model) *Program {
+	r, _ := io.Pipe()
+	return NewProgram(model, WithInput(r), WithOutput(&bytes.Buffer{}))
+}
+
+func TestProgramSendAndQuit(t *testing.T) {
+	p := newTestProgram(&counter{})
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := p.Start(context.Background()); !errors.Is(err, ErrProgramStarted) {
+		t.Errorf("second Start() error = %v, want ErrProgramStarted", err)
+	}
+
+	p.Send(2)
+	p.Send(3)
+	p.Send("stop")
+
+	final, err := p.Wait()
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if got := final.(*counter).n; got != 5 {
+		t.Errorf("final count = %d, want 5", got)
+	}
+}
+
+func TestProgramContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := newTestProgram(&counter{})
+	if err := p.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	p.Send(1)
+	cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.Wait()
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, ErrProgramKilled) || !errors.Is(err, context.Canceled) {
+			t.Errorf("Wait() error = %v, want ErrProgramKilled wrapping context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("program did not stop after its context was cancelled")
+	}
+}
+
+func TestProgramWaitBeforeStart(t *testing.T) {
+	p := newTestProgram(&counter{})
+	if _, err := p.Wait(); !errors.Is(err, ErrProgramNotStarted) {
+		t.Errorf("Wait() error = %v, want ErrProgramNotStarted", err)
+	}
+}
+
+func TestProgramQuitBeforeStart(t *testing.T) {
+	p := newTestProgram(&counter{})
+	quit := make(chan struct{})
+	go func() {
+		p.Quit()
+		close(quit)
+	}()
+	select {
+	case <-quit:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Quit() before Start blocked")
+	}
+
+	if err := p.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.Wait()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Wait() error = %v, want a clean quit", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("program started after Quit() did not stop")
+	}
+}
@@ -0,0 +1,447 @@
+package tui
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sessions are recorded in the asciicast v2 format used by asciinema
+// (https://docs.asciinema.org/manual/asciicast/v2/): a JSON header line with
+// the terminal size, then one JSON array per event holding the time since
+// the start in seconds, the event code and its data:
+//
+//	{"version": 2, "width": 80, "height": 24, "timestamp": 1760000000}
+//	[0.051234, "o", "\u001b[H\u001b[2J..."]
+//	[1.203117, "i", "j"]
+//	[2.500000, "r", "100x30"]
+//
+// Frames are recorded as they leave the model, each one redrawing the whole
+// screen, so a recording replays the same in any player.
+
+// Event codes of an asciicast v2 file.
+const (
+	castOutput = "o"
+	castInput  = "i"
+	castResize = "r"
+)
+
+// Size used in the header when nothing reported the terminal size.
+const (
+	castDefaultWidth  = 80
+	castDefaultHeight = 24
+)
+
+// castHeader is the first line of an asciicast v2 file.
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder writes the frames and input events of a session to an asciicast
+// v2 stream. Use it with WithRecorder:
+//
+//	rec := tui.NewRecorder(file, "eye")
+//	program := tui.NewProgram(model, tui.WithRecorder(rec))
+//	...
+//	err = rec.Close()
+type Recorder struct {
+	mu     sync.Mutex
+	w      *bufio.Writer
+	title  string
+	now    func() time.Time
+	start  time.Time
+	header bool
+	width  int
+	height int
+
+	// pending holds the last frame rendered before the size was known;
+	// it is written right after the header.
+	pending string
+	last    string
+	err     error
+}
+
+// NewRecorder creates a recorder writing to w. The session starts now;
+// title is stored in the header and may be empty.
+func NewRecorder(w io.Writer, title string) *Recorder {
+	r := &Recorder{w: bufio.NewWriter(w), title: title, now: time.Now}
+	r.start = r.now()
+	return r
+}
+
+// Close writes whatever is still buffered and returns the first error met
+// while recording. It does not close the underlying writer.
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.header {
+		r.writeHeader(castDefaultWidth, castDefaultHeight)
+	}
+	if err := r.w.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	return r.err
+}
+
+// record records msg if it is an input or resize event.
+func (r *Recorder) record(msg Msg) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch msg := msg.(type) {
+	case WindowSizeMsg:
+		if !r.header {
+			r.writeHeader(msg.Width, msg.Height)
+			return
+		}
+		if msg.Width != r.width || msg.Height != r.height {
+			r.width, r.height = msg.Width, msg.Height
+			r.writeEvent(castResize, fmt.Sprintf("%dx%d", msg.Width, msg.Height))
+		}
+	case KeyMsg:
+		r.writeEvent(castInput, keySequence(msg))
+	case PasteMsg:
+		r.writeEvent(castInput, "\x1b[200~"+msg.Text+"\x1b[201~")
+	case MouseMsg:
+		r.writeEvent(castInput, mouseSequence(msg))
+	}
+}
+
+// frame records a rendered frame if it differs from the previous one.
+func (r *Recorder) frame(view string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if view == r.last {
+		return
+	}
+	r.last = view
+	if !r.header {
+		r.pending = view
+		return
+	}
+	r.writeEvent(castOutput, redraw(view))
+}
+
+// writeHeader writes the header and the frame rendered before it. The
+// caller holds r.mu.
+func (r *Recorder) writeHeader(width, height int) {
+	r.header = true
+	r.width, r.height = width, height
+
+	line, err := json.Marshal(castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Title:     r.title,
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	})
+	r.writeLine(line, err)
+
+	if r.pending != "" {
+		r.writeEvent(castOutput, redraw(r.pending))
+		r.pending = ""
+	}
+}
+
+// writeEvent writes an event stamped with the time since the start. The
+// caller holds r.mu.
+func (r *Recorder) writeEvent(code, data string) {
+	if data == "" {
+		return
+	}
+	elapsed := r.now().Sub(r.start).Seconds()
+	var quoted bytes.Buffer
+	enc := json.NewEncoder(&quoted)
+	// Keep "<" and ">" of mouse reports readable.
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(data)
+	line := fmt.Appendf(nil, "[%s, %q, %s]", strconv.FormatFloat(elapsed, 'f', 6, 64), code, bytes.TrimSuffix(quoted.Bytes(), []byte("\n")))
+	r.writeLine(line, err)
+}
+
+func (r *Recorder) writeLine(line []byte, err error) {
+	if r.err != nil {
+		return
+	}
+	if err != nil {
+		r.err = err
+		return
+	}
+	if _, err := r.w.Write(append(line, '\n')); err != nil {
+		r.err = err
+	}
+}
+
+// redraw turns a frame into the output that draws it over the whole screen.
+func redraw(view string) string {
+	return "\x1b[H\x1b[2J" + strings.ReplaceAll(view, "\n", "\r\n")
+}
+
+// keySequences holds the bytes terminals send for named keys.
+var keySequences = map[KeyType]string{
+	KeySpace:     " ",
+	KeyBackspace: "\x7f",
+	KeyDelete:    "\x1b[3~",
+	KeyEnter:     "\r",
+	KeyEsc:       "\x1b",
+	KeyTab:       "\t",
+	KeyUp:        "\x1b[A",
+	KeyDown:      "\x1b[B",
+	KeyRight:     "\x1b[C",
+	KeyLeft:      "\x1b[D",
+	KeyCtrlC:     "\x03",
+	KeyCtrlD:     "\x04",
+	KeyHome:      "\x1b[H",
+	KeyEnd:       "\x1b[F",
+	KeyPgUp:      "\x1b[5~",
+	KeyPgDown:    "\x1b[6~",
+	KeyInsert:    "\x1b[2~",
+	KeyF1:        "\x1bOP",
+	KeyF2:        "\x1bOQ",
+	KeyF3:        "\x1bOR",
+	KeyF4:        "\x1bOS",
+	KeyF5:        "\x1b[15~",
+	KeyF6:        "\x1b[17~",
+	KeyF7:        "\x1b[18~",
+	KeyF8:        "\x1b[19~",
+	KeyF9:        "\x1b[20~",
+	KeyF10:       "\x1b[21~",
+	KeyF11:       "\x1b[23~",
+	KeyF12:       "\x1b[24~",
+}
+
+// keySequence returns the bytes a terminal sends for key, or "" when no
+// terminal sends it (e.g. f13 or ctrl+enter); such keys are not recorded.
+func keySequence(key KeyMsg) string {
+	var seq string
+	switch {
+	case key.Type == KeyTab && key.Shift && !key.Ctrl && !key.Alt:
+		return "\x1b[Z"
+	case key.Type == KeyCtrlC || key.Type == KeyCtrlD:
+		seq = keySequences[key.Type]
+	case key.Type == KeyRunes && key.Ctrl && len(key.Runes) == 1 && key.Runes[0] >= '@' && key.Runes[0] <= 0x7f:
+		// Control characters: ctrl+@ is 0x00, ctrl+a (or ctrl+A) is 0x01...
+		seq = string(key.Runes[0] & 0x1f)
+	case key.Type == KeyRunes && !key.Ctrl:
+		seq = string(key.Runes)
+	case key.Type == KeyRunes:
+		return ""
+	case key.Ctrl || key.Shift:
+		return modifiedSequence(key)
+	default:
+		var ok bool
+		if seq, ok = keySequences[key.Type]; !ok {
+			return ""
+		}
+	}
+	if key.Alt {
+		seq = "\x1b" + seq
+	}
+	return seq
+}
+
+// modifiedSequence returns the xterm encoding of a named key with
+// modifiers: its CSI sequence with the modifiers as a parameter, e.g.
+// "\x1b[1;5A" for ctrl+up or "\x1b[3;2~" for shift+delete. Keys sent as
+// plain characters, like enter or backspace, have none.
+func modifiedSequence(key KeyMsg) string {
+	mod := 1
+	if key.Shift {
+		mod++
+	}
+	if key.Alt {
+		mod += 2
+	}
+	if key.Ctrl {
+		mod += 4
+	}
+
+	seq := keySequences[key.Type]
+	switch {
+	case strings.HasPrefix(seq, "\x1bO"):
+		// F1 to F4 move from SS3 to CSI to carry the parameter.
+		return fmt.Sprintf("\x1b[1;%d%s", mod, seq[2:])
+	case strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "~"):
+		return fmt.Sprintf("\x1b[%s;%d~", seq[2:len(seq)-1], mod)
+	case strings.HasPrefix(seq, "\x1b["):
+		return fmt.Sprintf("\x1b[1;%d%s", mod, seq[2:])
+	}
+	return ""
+}
+
+// mouseSequence encodes a mouse event as an SGR (1006) mouse report.
+func mouseSequence(m MouseMsg) string {
+	var button int
+	switch m.Button {
+	case MouseButtonLeft:
+		button = 0
+	case MouseButtonMiddle:
+		button = 1
+	case MouseButtonRight:
+		button = 2
+	case MouseButtonNone:
+		button = 3
+	case MouseButtonWheelUp:
+		button = 64
+	case MouseButtonWheelDown:
+		button = 65
+	case MouseButtonWheelLeft:
+		button = 66
+	case MouseButtonWheelRight:
+		button = 67
+	case MouseButtonBackward:
+		button = 128
+	case MouseButtonForward:
+		button = 129
+	}
+	if m.Shift {
+		button += 4
+	}
+	if m.Alt {
+		button += 8
+	}
+	if m.Ctrl {
+		button += 16
+	}
+	if m.Action == MouseActionMotion {
+		button += 32
+	}
+	final := 'M'
+	if m.Action == MouseActionRelease {
+		final = 'm'
+	}
+	return fmt.Sprintf("\x1b[<%d;%d;%d%c", button, m.X+1, m.Y+1, final)
+}
+
+// --- Replay ---
+
+// ErrNotCast is returned by Replay when the input is not an asciicast v2
+// recording.
+var ErrNotCast = errors.New("not an asciicast v2 recording")
+
+// ReplayOption is a functional option for Replay.
+type ReplayOption func(*replayConfig)
+
+type replayConfig struct {
+	speed     float64
+	idleLimit time.Duration
+}
+
+// WithSpeed plays the recording faster (>1) or slower (<1).
+func WithSpeed(speed float64) ReplayOption {
+	return func(c *replayConfig) {
+		if speed > 0 {
+			c.speed = speed
+		}
+	}
+}
+
+// WithIdleLimit caps the pauses between events, so long idle periods
+// don't stall the playback. Zero keeps the recorded pauses.
+func WithIdleLimit(d time.Duration) ReplayOption {
+	return func(c *replayConfig) { c.idleLimit = max(d, 0) }
+}
+
+// Replay plays an asciicast v2 recording from r to w, writing each output
+// event at its recorded time. Input and resize events are skipped. It
+// returns the context error if ctx is cancelled first.
+func Replay(ctx context.Context, r io.Reader, w io.Writer, opts ...ReplayOption) error {
+	config := replayConfig{speed: 1}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	scanner := bufio.NewScanner(r)
+	// Frames of large screens easily exceed the default line limit.
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		return ErrNotCast
+	}
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil || header.Version != 2 {
+		return ErrNotCast
+	}
+
+	// wait is the time left before the next output event.
+	var previous, wait time.Duration
+	for line := 2; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var event [3]any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		seconds, ok1 := event[0].(float64)
+		code, ok2 := event[1].(string)
+		data, ok3 := event[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return fmt.Errorf("line %d: malformed event", line)
+		}
+
+		at := time.Duration(seconds * float64(time.Second))
+		pause := at - previous
+		previous = at
+		if config.idleLimit > 0 {
+			pause = min(pause, config.idleLimit)
+		}
+		wait += time.Duration(float64(pause) / config.speed)
+
+		if code != castOutput {
+			continue
+		}
+		if err := sleepFor(ctx, wait); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+		wait = 0
+	}
+	return scanner.Err()
+}
+
+// sleepFor waits for d or until ctx is done.
+func sleepFor(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// echoModel shows the last key it received.
+type echoModel struct{ last string }
+
+func (m *echoModel) Init() Cmd { return nil }
+
+func (m *echoModel) Update(msg Msg) (Model, Cmd) {
+	if key, ok := msg.(KeyMsg); ok {
+		m.last = key.String()
+	}
+	return m, nil
+}
+
+func (m *echoModel) View() string { return "key:\n" + m.last }
+
+// newTestRecorder returns a recorder whose clock advances by 500ms on
+// every reading.
+func newTestRecorder(w *bytes.Buffer) *Recorder {
+	rec := NewRecorder(w, "test")
+	clock := time.Unix(1700000000, 0)
+	rec.start = clock
+	rec.now = func() time.Time {
+		clock = clock.Add(500 * time.Millisecond)
+		return clock
+	}
+	return rec
+}
+
+func TestRecorderAtAdapterBoundary(t *testing.T) {
+	t.Setenv("TERM", "xterm")
+	t.Setenv("SHELL", "/bin/sh")
+
+	var out bytes.Buffer
+	rec := newTestRecorder(&out)
+	a := &modelAdapter{inner: &echoModel{}, recorder: rec}
+
+	a.View() // rendered before the size is known
+	a.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	a.View() // unchanged: not recorded again
+	a.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	a.View()
+	a.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	a.Update(tea.MouseMsg{X: 4, Y: 2, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+	a.Update(tea.WindowSizeMsg{Width: 50, Height: 12})
+	if err := rec.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		`{"version":2,"width":40,"height":10,"timestamp":1700000000,"title":"test","env":{"SHELL":"/bin/sh","TERM":"xterm"}}`,
+		`[0.500000, "o", "\u001b[H\u001b[2Jkey:\r\n"]`,
+		`[1.000000, "i", "j"]`,
+		`[1.500000, "o", "\u001b[H\u001b[2Jkey:\r\nj"]`,
+		`[2.000000, "i", "\u001b[Z"]`,
+		`[2.500000, "i", "\u001b[<0;5;3M"]`,
+		`[3.000000, "r", "50x12"]`,
+		``,
+	}, "\n")
+	if got := out.String(); got != want {
+		t.Errorf("recording:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestKeySequence(t *testing.T) {
+	tests := []struct {
+		key  KeyMsg
+		want string
+	}{
+		{KeyMsg{Type: KeyEnter}, "\r"},
+		{KeyMsg{Type: KeyUp}, "\x1b[A"},
+		{KeyMsg{Type: KeyRunes, Runes: []rune("é")}, "é"},
+		{KeyMsg{Type: KeyRunes, Runes: []rune{'x'}, Alt: true}, "\x1bx"},
+		{KeyMsg{Type: KeyRunes, Runes: []rune{'a'}, Ctrl: true}, "\x01"},
+		{KeyMsg{Type: KeyCtrlC, Runes: []rune{'c'}, Ctrl: true}, "\x03"},
+		{KeyMsg{Type: KeyUp, Ctrl: true}, "\x1b[1;5A"},
+		{KeyMsg{Type: KeyUp, Alt: true}, "\x1b\x1b[A"},
+		{KeyMsg{Type: KeyRight, Ctrl: true, Shift: true}, "\x1b[1;6C"},
+		{KeyMsg{Type: KeyDelete, Shift: true}, "\x1b[3;2~"},
+		{KeyMsg{Type: KeyF1, Ctrl: true}, "\x1b[1;5P"},
+		{KeyMsg{Type: KeyTab, Ctrl: true, Shift: true}, ""},
+		{KeyMsg{Type: KeyEnter, Ctrl: true}, ""},
+		{KeyMsg{Type: KeyRunes, Runes: []rune("é"), Ctrl: true}, ""},
+	}
+	for _, tt := range tests {
+		if got := keySequence(tt.key); got != tt.want {
+			t.Errorf("keySequence(%s) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	cast := strings.Join([]string{
+		`{"version": 2, "width": 80, "height": 24}`,
+		`[0.01, "o", "hello "]`,
+		`[0.02, "i", "j"]`,
+		``,
+		`[5.0, "o", "world"]`,
+	}, "\n")
+
+	var out bytes.Buffer
+	start := time.Now()
+	err := Replay(context.Background(), strings.NewReader(cast), &out, WithSpeed(2), WithIdleLimit(50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	if got := out.String(); got != "hello world" {
+		t.Errorf("output = %q, want %q", got, "hello world")
+	}
+	// The 5s pause is capped at 50ms, then halved.
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Replay took %v, want the idle limit to apply", elapsed)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	if err := Replay(context.Background(), strings.NewReader(`{"version": 1}`), &bytes.Buffer{}); !errors.Is(err, ErrNotCast) {
+		t.Errorf("version 1: err = %v, want ErrNotCast", err)
+	}
+	if err := Replay(context.Background(), strings.NewReader(""), &bytes.Buffer{}); !errors.Is(err, ErrNotCast) {
+		t.Errorf("empty: err = %v, want ErrNotCast", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cast := "{\"version\": 2, \"width\": 80, \"height\": 24}\n[1.0, \"o\", \"x\"]\n"
+	if err := Replay(ctx, strings.NewReader(cast), &bytes.Buffer{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled: err = %v, want context.Canceled", err)
+	}
+}
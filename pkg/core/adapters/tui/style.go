@@ -3,6 +3,7 @@ package tui
 
 import (
 	lg "github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 // Position corresponds to lipgloss.Position.
@@ -39,10 +40,17 @@ var (
 
 // Style is an interface that abstracts the lipgloss.Style.
 // It allows for setting styling rules and rendering strings.
+//
+// Styles are values: every setter returns a new Style and leaves the
+// receiver untouched, so a widget can derive per-frame styles (sizes, focus
+// colors) from its stored style without changing it. Callers keep the
+// result, e.g. m.style = m.style.Bold(true).
 type Style interface {
 	// Manipulation
 	Width(int) Style
 	Height(int) Style
+	MaxWidth(int) Style
+	MaxHeight(int) Style
 	Padding(...int) Style
 	Margin(...int) Style
 	Align(Position) Style
@@ -55,22 +63,56 @@ type Style interface {
 	Bold(bool) Style
 	Italic(bool) Style
 	Underline(bool) Style
+	Reverse(bool) Style
+	Faint(bool) Style
+	Strikethrough(bool) Style
+	Blink(bool) Style
 
 	// Border
 	Border(Border, ...bool) Style
 	BorderForeground(string) Style
 
+	// Composition
+	Copy() Style
+	Inherit(Style) Style
+
+	// Unsetting
+	UnsetWidth() Style
+	UnsetHeight() Style
+	UnsetMaxWidth() Style
+	UnsetMaxHeight() Style
+	UnsetPadding() Style
+	UnsetMargin() Style
+	UnsetAlign() Style
+	UnsetForeground() Style
+	UnsetBackground() Style
+	UnsetBold() Style
+	UnsetItalic() Style
+	UnsetUnderline() Style
+	UnsetReverse() Style
+	UnsetFaint() Style
+	UnsetStrikethrough() Style
+	UnsetBlink() Style
+	UnsetBorder() Style
+	UnsetBorderForeground() Style
+
 	// Rendering & Introspection
 	Render(string) string
 	GetFrameSize() (horizontal int, vertical int)
+	GetWidth() int
+	GetHeight() int
+	GetPadding() (top, right, bottom, left int)
+	GetMargin() (top, right, bottom, left int)
+	GetBorder() (border Border, top, right, bottom, left bool)
 
 	// Get the underlying lipgloss style, for advanced use cases or compatibility.
 	GetLipglossStyle() lg.Style
 }
 
 // lipglossAdapter implements the Style interface using a lipgloss.Style.
+// lipgloss styles are values already, so the adapter is one too.
 // Ensure it implements the interface.
-var _ Style = (*lipglossAdapter)(nil)
+var _ Style = lipglossAdapter{}
 
 type lipglossAdapter struct {
 	style lg.Style
@@ -78,83 +120,215 @@ type lipglossAdapter struct {
 
 // NewStyle creates a new style adapter.
 func NewStyle() Style {
-	return &lipglossAdapter{
+	return lipglossAdapter{
 		style: lg.NewStyle(),
 	}
 }
 
 // --- Implementation ---
 
-func (s *lipglossAdapter) Width(i int) Style {
-	s.style = s.style.Width(i)
-	return s
+func (s lipglossAdapter) Width(i int) Style {
+	return lipglossAdapter{s.style.Width(i)}
 }
 
-func (s *lipglossAdapter) Height(i int) Style {
-	s.style = s.style.Height(i)
-	return s
+func (s lipglossAdapter) Height(i int) Style {
+	return lipglossAdapter{s.style.Height(i)}
 }
 
-func (s *lipglossAdapter) Padding(p ...int) Style {
-	s.style = s.style.Padding(p...)
-	return s
+func (s lipglossAdapter) MaxWidth(i int) Style {
+	return lipglossAdapter{s.style.MaxWidth(i)}
 }
 
-func (s *lipglossAdapter) Margin(m ...int) Style {
-	s.style = s.style.Margin(m...)
-	return s
+func (s lipglossAdapter) MaxHeight(i int) Style {
+	return lipglossAdapter{s.style.MaxHeight(i)}
 }
 
-func (s *lipglossAdapter) Align(p Position) Style {
-	s.style = s.style.Align(lg.Position(p))
-	return s
+func (s lipglossAdapter) Padding(p ...int) Style {
+	return lipglossAdapter{s.style.Padding(p...)}
 }
 
-func (s *lipglossAdapter) Foreground(str string) Style {
-	s.style = s.style.Foreground(lg.Color(str))
-	return s
+func (s lipglossAdapter) Margin(m ...int) Style {
+	return lipglossAdapter{s.style.Margin(m...)}
 }
 
-func (s *lipglossAdapter) Background(str string) Style {
-	s.style = s.style.Background(lg.Color(str))
-	return s
+func (s lipglossAdapter) Align(p Position) Style {
+	return lipglossAdapter{s.style.Align(lg.Position(p))}
 }
 
-func (s *lipglossAdapter) Bold(b bool) Style {
-	s.style = s.style.Bold(b)
-	return s
+func (s lipglossAdapter) Foreground(str string) Style {
+	return lipglossAdapter{s.style.Foreground(lg.Color(str))}
 }
 
-func (s *lipglossAdapter) Italic(b bool) Style {
-	s.style = s.style.Italic(b)
-	return s
+func (s lipglossAdapter) Background(str string) Style {
+	return lipglossAdapter{s.style.Background(lg.Color(str))}
 }
 
-func (s *lipglossAdapter) Underline(b bool) Style {
-	s.style = s.style.Underline(b)
-	return s
+func (s lipglossAdapter) Bold(b bool) Style {
+	return lipglossAdapter{s.style.Bold(b)}
+}
+
+func (s lipglossAdapter) Italic(b bool) Style {
+	return lipglossAdapter{s.style.Italic(b)}
+}
+
+func (s lipglossAdapter) Underline(b bool) Style {
+	return lipglossAdapter{s.style.Underline(b)}
+}
+
+func (s lipglossAdapter) Reverse(b bool) Style {
+	return lipglossAdapter{s.style.Reverse(b)}
+}
+
+func (s lipglossAdapter) Faint(b bool) Style {
+	return lipglossAdapter{s.style.Faint(b)}
+}
+
+func (s lipglossAdapter) Strikethrough(b bool) Style {
+	return lipglossAdapter{s.style.Strikethrough(b)}
 }
 
-func (s *lipglossAdapter) Border(b Border, v ...bool) Style {
+func (s lipglossAdapter) Blink(b bool) Style {
+	return lipglossAdapter{s.style.Blink(b)}
+}
+
+func (s lipglossAdapter) Border(b Border, v ...bool) Style {
 	lgBorder := toLipglossBorder(b)
-	s.style = s.style.Border(lgBorder, v...)
-	return s
+	return lipglossAdapter{s.style.Border(lgBorder, v...)}
+}
+
+func (s lipglossAdapter) BorderForeground(str string) Style {
+	return lipglossAdapter{s.style.BorderForeground(lg.Color(str))}
 }
 
-func (s *lipglossAdapter) BorderForeground(str string) Style {
-	s.style = s.style.BorderForeground(lg.Color(str))
+// Copy returns the style itself. Styles are immutable values, so a copy is
+// never needed; it is kept for readability where a style is derived.
+func (s lipglossAdapter) Copy() Style {
 	return s
 }
 
-func (s *lipglossAdapter) Render(str string) string {
+// Inherit returns the style with the rules of parent that it does not set
+// itself. Margins, padding and the background of the content are not
+// inherited, as in lipgloss.
+func (s lipglossAdapter) Inherit(parent Style) Style {
+	if parent == nil {
+		return s
+	}
+	return lipglossAdapter{s.style.Inherit(parent.GetLipglossStyle())}
+}
+
+// --- Unsetting ---
+
+func (s lipglossAdapter) UnsetWidth() Style {
+	return lipglossAdapter{s.style.UnsetWidth()}
+}
+
+func (s lipglossAdapter) UnsetHeight() Style {
+	return lipglossAdapter{s.style.UnsetHeight()}
+}
+
+func (s lipglossAdapter) UnsetMaxWidth() Style {
+	return lipglossAdapter{s.style.UnsetMaxWidth()}
+}
+
+func (s lipglossAdapter) UnsetMaxHeight() Style {
+	return lipglossAdapter{s.style.UnsetMaxHeight()}
+}
+
+func (s lipglossAdapter) UnsetPadding() Style {
+	return lipglossAdapter{s.style.UnsetPadding()}
+}
+
+func (s lipglossAdapter) UnsetMargin() Style {
+	return lipglossAdapter{s.style.UnsetMargins()}
+}
+
+func (s lipglossAdapter) UnsetAlign() Style {
+	return lipglossAdapter{s.style.UnsetAlign()}
+}
+
+func (s lipglossAdapter) UnsetForeground() Style {
+	return lipglossAdapter{s.style.UnsetForeground()}
+}
+
+func (s lipglossAdapter) UnsetBackground() Style {
+	return lipglossAdapter{s.style.UnsetBackground()}
+}
+
+func (s lipglossAdapter) UnsetBold() Style {
+	return lipglossAdapter{s.style.UnsetBold()}
+}
+
+func (s lipglossAdapter) UnsetItalic() Style {
+	return lipglossAdapter{s.style.UnsetItalic()}
+}
+
+func (s lipglossAdapter) UnsetUnderline() Style {
+	return lipglossAdapter{s.style.UnsetUnderline()}
+}
+
+func (s lipglossAdapter) UnsetReverse() Style {
+	return lipglossAdapter{s.style.UnsetReverse()}
+}
+
+func (s lipglossAdapter) UnsetFaint() Style {
+	return lipglossAdapter{s.style.UnsetFaint()}
+}
+
+func (s lipglossAdapter) UnsetStrikethrough() Style {
+	return lipglossAdapter{s.style.UnsetStrikethrough()}
+}
+
+func (s lipglossAdapter) UnsetBlink() Style {
+	return lipglossAdapter{s.style.UnsetBlink()}
+}
+
+// UnsetBorder removes the border style and all border sides.
+func (s lipglossAdapter) UnsetBorder() Style {
+	return lipglossAdapter{s.style.
+		UnsetBorderStyle().
+		UnsetBorderTop().
+		UnsetBorderRight().
+		UnsetBorderBottom().
+		UnsetBorderLeft()}
+}
+
+func (s lipglossAdapter) UnsetBorderForeground() Style {
+	return lipglossAdapter{s.style.UnsetBorderForeground()}
+}
+
+// --- Rendering & Introspection ---
+
+func (s lipglossAdapter) Render(str string) string {
 	return s.style.Render(str)
 }
 
-func (s *lipglossAdapter) GetFrameSize() (int, int) {
+func (s lipglossAdapter) GetFrameSize() (int, int) {
 	return s.style.GetFrameSize()
 }
 
-func (s *lipglossAdapter) GetLipglossStyle() lg.Style {
+func (s lipglossAdapter) GetWidth() int {
+	return s.style.GetWidth()
+}
+
+func (s lipglossAdapter) GetHeight() int {
+	return s.style.GetHeight()
+}
+
+func (s lipglossAdapter) GetPadding() (int, int, int, int) {
+	return s.style.GetPadding()
+}
+
+func (s lipglossAdapter) GetMargin() (int, int, int, int) {
+	return s.style.GetMargin()
+}
+
+// GetBorder returns the border and which of its sides are drawn.
+func (s lipglossAdapter) GetBorder() (Border, bool, bool, bool, bool) {
+	b, top, right, bottom, left := s.style.GetBorder()
+	return fromLipglossBorder(b), top, right, bottom, left
+}
+
+func (s lipglossAdapter) GetLipglossStyle() lg.Style {
 	return s.style
 }
 
@@ -205,3 +379,16 @@ func Width(s string) int {
 	return lg.Width(s)
 }
 
+
+// Wrap wraps s to lines of at most width cells, breaking at spaces and
+// hyphens, and inside words longer than a line. Wide characters count as
+// two cells and styles are kept across breaks.
+func Wrap(s string, width int) string {
+	return ansi.Wrap(s, width, "-")
+}
+
+// Truncate cuts s to at most width cells, ending it with tail (e.g. "…")
+// when something was cut. The tail counts toward width.
+func Truncate(s string, width int, tail string) string {
+	return ansi.Truncate(s, width, tail)
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"testing"
+)
+
+func TestStyleSettersReturnNewStyle(t *testing.T) {
+	base := NewStyle().Padding(1)
+	before := base.Render("x")
+
+	derived := base.Width(10).Height(3).Border(NormalBorder).Margin(1).Bold(true)
+
+	if got := base.Render("x"); got != before {
+		t.Errorf("deriving a style changed the base:\n%q\nwant\n%q", got, before)
+	}
+	if base.GetWidth() != 0 || base.GetHeight() != 0 {
+		t.Errorf("base size = %dx%d, want unset", base.GetWidth(), base.GetHeight())
+	}
+	if _, _, _, left := base.GetMargin(); left != 0 {
+		t.Errorf("base left margin = %d, want 0", left)
+	}
+	if derived.GetWidth() != 10 || derived.GetHeight() != 3 {
+		t.Errorf("derived size = %dx%d, want 10x3", derived.GetWidth(), derived.GetHeight())
+	}
+}
+
+func TestStyleRenderHasNoSideEffects(t *testing.T) {
+	style := NewStyle().Border(RoundedBorder).Padding(0, 1).Width(12)
+
+	first := style.Render("hello")
+	for range 3 {
+		// Per-frame derivations must not leak into the stored style.
+		_ = style.Width(4).Height(1).Render("hello")
+		if got := style.Render("hello"); got != first {
+			t.Fatalf("render changed after deriving a style:\n%s\nwant\n%s", got, first)
+		}
+	}
+	if h, v := style.GetFrameSize(); h != 4 || v != 2 {
+		t.Errorf("frame size = %d,%d, want 4,2", h, v)
+	}
+}
+
+func TestStyleCopyAndInherit(t *testing.T) {
+	parent := NewStyle().Foreground("12").Bold(true).Padding(2)
+	child := NewStyle().Italic(true).Inherit(parent)
+
+	lg := child.GetLipglossStyle()
+	if !lg.GetBold() || !lg.GetItalic() {
+		t.Errorf("inherited style: bold=%v italic=%v, want both", lg.GetBold(), lg.GetItalic())
+	}
+	if top, _, _, _ := child.GetPadding(); top != 0 {
+		t.Errorf("padding was inherited: top=%d", top)
+	}
+	if NewStyle().Inherit(nil) == nil {
+		t.Error("Inherit(nil) returned nil")
+	}
+
+	copied := parent.Copy().Bold(false)
+	if !parent.GetLipglossStyle().GetBold() {
+		t.Error("changing a copy changed the original")
+	}
+	if copied.GetLipglossStyle().GetBold() {
+		t.Error("copy did not take the change")
+	}
+}
+
+func TestStyleUnset(t *testing.T) {
+	style := NewStyle().
+		Width(5).MaxWidth(8).Margin(1).Padding(1).
+		Faint(true).Strikethrough(true).Blink(true).Reverse(true).
+		Border(DoubleBorder).BorderForeground("9")
+
+	unset := style.
+		UnsetWidth().UnsetMaxWidth().UnsetMargin().UnsetPadding().
+		UnsetFaint().UnsetStrikethrough().UnsetBlink().UnsetReverse().
+		UnsetBorder().UnsetBorderForeground()
+
+	if got, want := unset.Render("x"), NewStyle().Render("x"); got != want {
+		t.Errorf("fully unset style renders %q, want %q", got, want)
+	}
+	if h, v := unset.GetFrameSize(); h != 0 || v != 0 {
+		t.Errorf("frame size after unset = %d,%d, want 0,0", h, v)
+	}
+
+	border, top, right, bottom, left := style.GetBorder()
+	if border != DoubleBorder || !top || !right || !bottom || !left {
+		t.Errorf("GetBorder() = %+v %v %v %v %v, want double border on all sides", border, top, right, bottom, left)
+	}
+}
+
+func TestStyleMaxSize(t *testing.T) {
+	got := NewStyle().MaxWidth(3).MaxHeight(1).Render("abcdef\nsecond")
+	if got != "abc" {
+		t.Errorf("MaxWidth/MaxHeight render = %q, want %q", got, "abc")
+	}
+}
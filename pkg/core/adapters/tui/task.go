@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// TaskID identifies a Task. Screens keep the ID of the task they are waiting
+// for and ignore messages carrying any other ID, so results from a task that
+// was restarted or cancelled are dropped.
+type TaskID int64
+
+var lastTaskID atomic.Int64
+
+// TaskFunc is the work run by a Task. It should return promptly once ctx is
+// cancelled. progress delivers intermediate messages to the UI, wrapped in
+// a TaskProgressMsg. The returned message and error are delivered in a
+// TaskDoneMsg.
+type TaskFunc func(ctx context.Context, progress func(Msg)) (Msg, error)
+
+// TaskProgressMsg carries an intermediate message reported by a task.
+type TaskProgressMsg struct {
+	ID       TaskID
+	Progress Msg
+}
+
+// TaskDoneMsg is sent once when a task finishes. Err is the context error
+// when the task was cancelled.
+type TaskDoneMsg struct {
+	ID     TaskID
+	Result Msg
+	Err    error
+}
+
+// Task runs a TaskFunc in the background and streams its progress and final
+// result into the program as messages.
+type Task struct {
+	id     TaskID
+	fn     TaskFunc
+	once   sync.Once
+	start  func()
+	cancel context.CancelFunc
+	events chan Msg
+	// done holds the TaskDoneMsg. It has room for it, so the task finishes
+	// even when nobody waits for its result any more.
+	done chan Msg
+}
+
+// NewTask creates a task with a new, unique ID. Nothing runs until the
+// command returned by Start is executed.
+func NewTask(fn TaskFunc) *Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Task{
+		id:     TaskID(lastTaskID.Add(1)),
+		fn:     fn,
+		cancel: cancel,
+		events: make(chan Msg),
+		done:   make(chan Msg, 1),
+	}
+	t.start = func() { go t.run(ctx) }
+	return t
+}
+
+// ID returns the task's identifier.
+func (t *Task) ID() TaskID {
+	return t.id
+}
+
+// Start returns the command that runs the task and delivers its messages.
+// The command should be executed once; a task cannot be restarted, create
+// a new one instead.
+func (t *Task) Start() Cmd {
+	return func() Msg {
+		t.once.Do(t.start)
+		return t.next()
+	}
+}
+
+// Cancel stops the task. Its TaskDoneMsg is still delivered, with the
+// context error, unless the task had already finished.
+func (t *Task) Cancel() {
+	t.cancel()
+}
+
+// run executes the task function and reports its events.
+func (t *Task) run(ctx context.Context) {
+	defer t.cancel()
+
+	progress := func(msg Msg) {
+		select {
+		case t.events <- TaskProgressMsg{ID: t.id, Progress: msg}:
+		case <-ctx.Done():
+		}
+	}
+
+	result, err := t.fn(ctx, progress)
+	if err == nil && ctx.Err() != nil {
+		err = ctx.Err()
+	}
+	t.done <- TaskDoneMsg{ID: t.id, Result: result, Err: err}
+}
+
+// next waits for the task's next event. Progress events re-arm the wait in
+// a sequence, so the stream keeps flowing without the screen having to ask.
+func (t *Task) next() Msg {
+	var msg Msg
+	select {
+	case msg = <-t.events:
+	case msg = <-t.done:
+		return msg
+	}
+	return SequenceMsg{
+		func() Msg { return msg },
+		t.next,
+	}
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// drain runs cmd like the program would, expanding batches and sequences,
+// and returns the produced messages in order.
+func drain(cmd Cmd) []Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case nil:
+		return nil
+	case BatchMsg:
+		var msgs []Msg
+		for _, c := range msg {
+			msgs = append(msgs, drain(c)...)
+		}
+		return msgs
+	case SequenceMsg:
+		var msgs []Msg
+		for _, c := range msg {
+			msgs = append(msgs, drain(c)...)
+		}
+		return msgs
+	default:
+		return []Msg{msg}
+	}
+}
+
+func TestTaskStreamsProgressAndResult(t *testing.T) {
+	task := NewTask(func(ctx context.Context, progress func(Msg)) (Msg, error) {
+		for i := 1; i <= 3; i++ {
+			progress(i)
+		}
+		return "installed", nil
+	})
+
+	msgs := drain(task.Start())
+	if len(msgs) != 4 {
+		t.Fatalf("got %d messages, want 4: %v", len(msgs), msgs)
+	}
+	for i, msg := range msgs[:3] {
+		want := TaskProgressMsg{ID: task.ID(), Progress: i + 1}
+		if msg != want {
+			t.Errorf("message %d = %#v, want %#v", i, msg, want)
+		}
+	}
+	done, ok := msgs[3].(TaskDoneMsg)
+	if !ok || done.ID != task.ID() || done.Result != "installed" || done.Err != nil {
+		t.Errorf("last message = %#v, want TaskDoneMsg with the result", msgs[3])
+	}
+}
+
+func TestTaskCancel(t *testing.T) {
+	started := make(chan struct{})
+	task := NewTask(func(ctx context.Context, progress func(Msg)) (Msg, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, nil
+	})
+
+	result := make(chan []Msg, 1)
+	go func() { result <- drain(task.Start()) }()
+	<-started
+	task.Cancel()
+
+	select {
+	case msgs := <-result:
+		done, ok := msgs[len(msgs)-1].(TaskDoneMsg)
+		if !ok || !errors.Is(done.Err, context.Canceled) {
+			t.Errorf("last message = %#v, want TaskDoneMsg with context.Canceled", msgs[len(msgs)-1])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled task never finished")
+	}
+}
+
+func TestTaskFinishesWhenResultIsDropped(t *testing.T) {
+	task := NewTask(func(ctx context.Context, progress func(Msg)) (Msg, error) {
+		progress("working")
+		return "result", nil
+	})
+
+	// The screen takes the first progress event and is closed before it
+	// asks for more; the task must still finish rather than wait forever.
+	if _, ok := task.Start()().(SequenceMsg); !ok {
+		t.Fatal("first event is not a progress sequence")
+	}
+	task.Cancel()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(task.done) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("task blocked delivering its result")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTaskIDsAreUnique(t *testing.T) {
+	a := NewTask(func(context.Context, func(Msg)) (Msg, error) { return nil, nil })
+	b := NewTask(func(context.Context, func(Msg)) (Msg, error) { return nil, nil })
+	if a.ID() == b.ID() {
+		t.Errorf("two tasks share ID %d", a.ID())
+	}
+}
+
+func TestSequenceAndTick(t *testing.T) {
+	type tick struct{}
+	cmd := Sequence(
+		func() Msg { return "first" },
+		nil,
+		Tick(time.Millisecond, func(time.Time) Msg { return tick{} }),
+	)
+	msgs := drain(cmd)
+	if len(msgs) != 2 || msgs[0] != "first" || msgs[1] != (tick{}) {
+		t.Errorf("Sequence produced %v, want [first {}]", msgs)
+	}
+	if Sequence(nil, nil) != nil {
+		t.Errorf("Sequence of nil commands should be nil")
+	}
+}
@@ -1,6 +1,8 @@
 //Package tui
 package tui
 
+import "strings"
+
 // Msg represents a message that can be sent to a model's Update function.
 // It's an alias for any, allowing for any type of message.
 type Msg any
@@ -40,6 +42,11 @@ func (k KeyType) String() string {
 }
 
 // KeyMsg is sent when a key is pressed.
+//
+// Modifiers are reported separately from the key itself, so ctrl+up arrives
+// as Type KeyUp with Ctrl set, and ctrl+p as Type KeyRunes with Runes "p" and
+// Ctrl set. The only exceptions are KeyCtrlC and KeyCtrlD, which keep their
+// own types (with Ctrl also set) because they are handled globally.
 type KeyMsg struct {
 	Type KeyType
 
@@ -48,11 +55,58 @@ type KeyMsg struct {
 
 	// Alt is true if the alt key was pressed.
 	Alt bool
+
+	// Ctrl is true if the ctrl key was pressed.
+	Ctrl bool
+
+	// Shift is true if the shift key was pressed. Terminals report shifted
+	// letters as upper-case runes, so this is only set for named keys.
+	Shift bool
+}
+
+// String returns a textual representation of the key press with its
+// modifiers, in the form "ctrl+alt+shift+key" (e.g. "ctrl+p", "shift+tab",
+// "alt+enter"). Rune keys render as their runes.
+func (k KeyMsg) String() string {
+	var b strings.Builder
+	if k.Ctrl || k.Type == KeyCtrlC || k.Type == KeyCtrlD {
+		b.WriteString("ctrl+")
+	}
+	if k.Alt {
+		b.WriteString("alt+")
+	}
+	if k.Shift {
+		b.WriteString("shift+")
+	}
+	b.WriteString(k.keyName())
+	return b.String()
+}
+
+// keyName returns the name of the key without modifiers.
+func (k KeyMsg) keyName() string {
+	switch k.Type {
+	case KeyRunes:
+		return string(k.Runes)
+	case KeyCtrlC:
+		return "c"
+	case KeyCtrlD:
+		return "d"
+	default:
+		return k.Type.String()
+	}
+}
+
+// PasteMsg is sent when text is pasted into the terminal while bracketed
+// paste is active. The whole pasted text arrives in a single message instead
+// of one KeyMsg per rune.
+type PasteMsg struct {
+	Text string
 }
 
 // --- Key Types ---
 
-// This is a subset of bubbletea's KeyType for abstraction purposes.
+// This mirrors bubbletea's named keys, with modifier combinations folded into
+// the KeyMsg modifier fields.
 const (
 	KeyRunes KeyType = iota
 	KeySpace
@@ -67,6 +121,31 @@ const (
 	KeyLeft
 	KeyCtrlC
 	KeyCtrlD
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDown
+	KeyInsert
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+	KeyF13
+	KeyF14
+	KeyF15
+	KeyF16
+	KeyF17
+	KeyF18
+	KeyF19
+	KeyF20
 )
 
 var keyNames = map[KeyType]string{
@@ -83,4 +162,29 @@ var keyNames = map[KeyType]string{
 	KeyLeft:      "left",
 	KeyCtrlC:     "ctrl+c",
 	KeyCtrlD:     "ctrl+d",
+	KeyHome:      "home",
+	KeyEnd:       "end",
+	KeyPgUp:      "pgup",
+	KeyPgDown:    "pgdown",
+	KeyInsert:    "insert",
+	KeyF1:        "f1",
+	KeyF2:        "f2",
+	KeyF3:        "f3",
+	KeyF4:        "f4",
+	KeyF5:        "f5",
+	KeyF6:        "f6",
+	KeyF7:        "f7",
+	KeyF8:        "f8",
+	KeyF9:        "f9",
+	KeyF10:       "f10",
+	KeyF11:       "f11",
+	KeyF12:       "f12",
+	KeyF13:       "f13",
+	KeyF14:       "f14",
+	KeyF15:       "f15",
+	KeyF16:       "f16",
+	KeyF17:       "f17",
+	KeyF18:       "f18",
+	KeyF19:       "f19",
+	KeyF20:       "f20",
 }
@@ -0,0 +1,57 @@
+package tuitest
+
+import (
+	"io"
+	"strconv"
+)
+
+// FakeProcess is a tui.ExecCommand that runs nothing. It writes Output to
+// its stdout and returns Err, so screens that shell out to installers or
+// editors can be tested without spawning processes.
+type FakeProcess struct {
+	Output string
+	Err    error
+
+	ran    bool
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// Run records that the process ran and writes Output.
+func (p *FakeProcess) Run() error {
+	p.ran = true
+	if p.stdout != nil && p.Output != "" {
+		if _, err := io.WriteString(p.stdout, p.Output); err != nil {
+			return err
+		}
+	}
+	return p.Err
+}
+
+// Ran reports whether the process was run.
+func (p *FakeProcess) Ran() bool {
+	return p.ran
+}
+
+// SetStdin sets the process input.
+func (p *FakeProcess) SetStdin(r io.Reader) { p.stdin = r }
+
+// SetStdout sets the process output.
+func (p *FakeProcess) SetStdout(w io.Writer) { p.stdout = w }
+
+// SetStderr sets the process error output.
+func (p *FakeProcess) SetStderr(w io.Writer) { p.stderr = w }
+
+// ExitError is an error carrying a process exit status, for use as
+// FakeProcess.Err. tui.ExitCode reports its value.
+type ExitError int
+
+func (e ExitError) Error() string {
+	return "exit status " + strconv.Itoa(int(e))
+}
+
+// ExitCode returns the exit status.
+func (e ExitError) ExitCode() int {
+	return int(e)
+}
@@ -0,0 +1,76 @@
+package tuitest
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+// execModel runs its process when Enter is pressed and shows the exit code.
+type execModel struct {
+	proc   tui.ExecCommand
+	result *tui.ExecFinishedMsg
+}
+
+func (m *execModel) Init() tui.Cmd { return nil }
+
+func (m *execModel) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.KeyMsg:
+		if msg.Type == tui.KeyEnter {
+			return m, tui.Exec(m.proc, nil)
+		}
+	case tui.ExecFinishedMsg:
+		m.result = &msg
+	}
+	return m, nil
+}
+
+func (m *execModel) View() string {
+	if m.result == nil {
+		return "idle"
+	}
+	return "exited"
+}
+
+func TestExecRunsFakeProcess(t *testing.T) {
+	proc := &FakeProcess{Output: "installing...\n", Err: ExitError(3)}
+	model := &execModel{proc: proc}
+
+	d := New(t, model).Press(tui.KeyEnter)
+
+	if !proc.Ran() {
+		t.Fatal("process did not run")
+	}
+	if len(d.Execs()) != 1 || d.Execs()[0] != proc {
+		t.Errorf("Execs() = %v, want the fake process", d.Execs())
+	}
+	if got := d.ExecOutput(); got != "installing...\n" {
+		t.Errorf("ExecOutput() = %q", got)
+	}
+	if model.result == nil || model.result.ExitCode != 3 {
+		t.Fatalf("result = %+v, want exit code 3", model.result)
+	}
+	if d.Frame() != "exited" {
+		t.Errorf("frame = %q, want exited", d.Frame())
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	for _, tc := range []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{ExitError(2), 2},
+		{errString("not found"), -1},
+	} {
+		if got := tui.ExitCode(tc.err); got != tc.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tc.err, got, tc.want)
+		}
+	}
+}
+
+type errString string
+
+func (e errString) Error() string { return string(e) }
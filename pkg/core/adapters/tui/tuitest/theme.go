@@ -0,0 +1,23 @@
+package tuitest
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// UseTheme makes theme the current theme until the test ends.
+func UseTheme(t testing.TB, theme *designsystem.Theme) {
+	t.Helper()
+	designsystem.SetCurrent(theme)
+	t.Cleanup(func() { designsystem.SetCurrent(nil) })
+}
+
+// UseCharset draws the test's glyphs with the default theme in charset,
+// whatever the terminal running the tests supports.
+func UseCharset(t testing.TB, charset designsystem.Charset) {
+	t.Helper()
+	theme := designsystem.Default()
+	theme.Charset = charset
+	UseTheme(t, theme)
+}
@@ -0,0 +1,326 @@
+// Package tuitest drives tui.Models headlessly for tests.
+//
+// A Driver builds a model at a fixed size, feeds it scripted messages, runs
+// every command it returns (batches included) to completion and records each
+// rendered frame. Frames can be compared against golden files stored in the
+// package's testdata directory; run the tests with -update to rewrite them
+// (e.g. go test ./pkg/core/ui/shell -update).
+package tuitest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+var update = flag.Bool("update", false, "update tuitest golden files")
+
+// maxMessages bounds how many messages a single Send may process, so a
+// model that keeps re-scheduling commands fails the test instead of hanging.
+const maxMessages = 10000
+
+// Option is a functional option for configuring the Driver.
+type Option func(*Driver)
+
+// WithSize sets the terminal size the model is built at. Defaults to 80x24.
+func WithSize(width, height int) Option {
+	return func(d *Driver) { d.width, d.height = width, height }
+}
+
+// WithANSI keeps ANSI escape sequences in recorded frames. By default they
+// are stripped, so golden files only contain the visible text.
+func WithANSI() Option {
+	return func(d *Driver) { d.keepANSI = true }
+}
+
+// WithFocus focuses the model before it is built, as the screen would
+// focus its only widget. The model must be a tui.Focusable.
+func WithFocus() Option {
+	return func(d *Driver) { d.focus = true }
+}
+
+// Driver runs a tui.Model without a terminal.
+type Driver struct {
+	t        testing.TB
+	model    tui.Model
+	width    int
+	height   int
+	keepANSI bool
+	focus    bool
+
+	frames     []string
+	messages   []tui.Msg
+	execs      []tui.ExecCommand
+	execOutput strings.Builder
+	quit       bool
+}
+
+// New builds model at the configured size: it runs Init, then sends the
+// initial WindowSizeMsg, like the real program does.
+func New(t testing.TB, model tui.Model, opts ...Option) *Driver {
+	t.Helper()
+	d := &Driver{
+		t:      t,
+		model:  model,
+		width:  80,
+		height: 24,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	// Widgets holding click zones release them with Close.
+	if c, ok := model.(interface{ Close() }); ok {
+		t.Cleanup(c.Close)
+	}
+
+	var focus tui.Cmd
+	if d.focus {
+		f, ok := model.(tui.Focusable)
+		if !ok {
+			t.Fatalf("tuitest: WithFocus on %T, which is not focusable", model)
+		}
+		focus = f.Focus()
+	}
+	d.run(model.Init())
+	d.run(focus)
+	d.Send(tui.WindowSizeMsg{Width: d.width, Height: d.height})
+	return d
+}
+
+// Key parses a key binding, e.g. "ctrl+a" or "pgdown", failing the test
+// when it is not one.
+func Key(t testing.TB, s string) tui.KeyMsg {
+	t.Helper()
+	msg, err := tui.ParseKey(s)
+	if err != nil {
+		t.Fatalf("tuitest: %v", err)
+	}
+	return msg
+}
+
+// Send delivers each message to the model in order and runs the resulting
+// commands to completion. A frame is recorded after every Update.
+func (d *Driver) Send(msgs ...tui.Msg) *Driver {
+	d.t.Helper()
+	for _, msg := range msgs {
+		d.dispatch(msg)
+	}
+	return d
+}
+
+// Type sends one KeyMsg per rune of s.
+func (d *Driver) Type(s string) *Driver {
+	d.t.Helper()
+	for _, r := range s {
+		key := tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune{r}}
+		if r == ' ' {
+			key.Type = tui.KeySpace
+		}
+		d.Send(key)
+	}
+	return d
+}
+
+// Press sends one KeyMsg per key type.
+func (d *Driver) Press(keys ...tui.KeyType) *Driver {
+	d.t.Helper()
+	for _, k := range keys {
+		d.Send(tui.KeyMsg{Type: k})
+	}
+	return d
+}
+
+// PressKeys sends one KeyMsg per key binding, e.g. "ctrl+a" or "pgdown".
+func (d *Driver) PressKeys(keys ...string) *Driver {
+	d.t.Helper()
+	for _, k := range keys {
+		d.Send(Key(d.t, k))
+	}
+	return d
+}
+
+// Resize changes the terminal size and sends the matching WindowSizeMsg.
+func (d *Driver) Resize(width, height int) *Driver {
+	d.t.Helper()
+	d.width, d.height = width, height
+	return d.Send(tui.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Model returns the current model.
+func (d *Driver) Model() tui.Model {
+	return d.model
+}
+
+// Frame returns the last recorded frame.
+func (d *Driver) Frame() string {
+	if len(d.frames) == 0 {
+		return d.render()
+	}
+	return d.frames[len(d.frames)-1]
+}
+
+// Frames returns every recorded frame, oldest first.
+func (d *Driver) Frames() []string {
+	return d.frames
+}
+
+// Messages returns every message delivered to the model, including the ones
+// produced by commands, oldest first.
+func (d *Driver) Messages() []tui.Msg {
+	return d.messages
+}
+
+// Execs returns the external processes the model ran with tui.Exec or
+// tui.ExecProcess, oldest first.
+func (d *Driver) Execs() []tui.ExecCommand {
+	return d.execs
+}
+
+// ExecOutput returns everything the external processes wrote to their
+// stdout and stderr.
+func (d *Driver) ExecOutput() string {
+	return d.execOutput.String()
+}
+
+// Quit reports whether the model asked the program to quit.
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+// Golden compares the last frame against testdata/<test name>/<name>.golden.
+func (d *Driver) Golden(name string) {
+	d.t.Helper()
+	AssertGolden(d.t, name, d.Frame())
+}
+
+// --- Internals ---
+
+// dispatch delivers msg and every message its commands produce.
+func (d *Driver) dispatch(msg tui.Msg) {
+	d.t.Helper()
+	queue := []tui.Msg{msg}
+	for processed := 0; len(queue) > 0; processed++ {
+		if processed >= maxMessages {
+			d.t.Fatalf("tuitest: more than %d messages processed by a single Send", maxMessages)
+		}
+		if d.quit {
+			return
+		}
+
+		next := queue[0]
+		queue = queue[1:]
+		d.messages = append(d.messages, next)
+
+		model, cmd := d.model.Update(next)
+		d.model = model
+		if mouse, ok := next.(tui.MouseMsg); ok {
+			cmd = tui.Batch(cmd, tui.Zones.HandleMouse(mouse))
+		}
+		d.frames = append(d.frames, d.render())
+
+		queue = append(queue, d.collect(cmd)...)
+	}
+}
+
+// run executes cmd outside of an Update (e.g. the Init command).
+func (d *Driver) run(cmd tui.Cmd) {
+	d.t.Helper()
+	for _, msg := range d.collect(cmd) {
+		d.dispatch(msg)
+	}
+}
+
+// collect runs cmd, expanding batches and sequences, and returns the
+// produced messages in order.
+func (d *Driver) collect(cmd tui.Cmd) []tui.Msg {
+	if cmd == nil {
+		return nil
+	}
+	msg := cmd()
+	switch msg := msg.(type) {
+	case nil:
+		return nil
+	case tui.BatchMsg:
+		return d.collectAll(msg)
+	case tui.SequenceMsg:
+		return d.collectAll(msg)
+	case tui.ExecMsg:
+		// There is no terminal to hand over: the process runs in place with
+		// empty input and its output captured by the driver.
+		d.execs = append(d.execs, msg.Command)
+		msg.Command.SetStdin(strings.NewReader(""))
+		msg.Command.SetStdout(&d.execOutput)
+		msg.Command.SetStderr(&d.execOutput)
+		return []tui.Msg{msg.OnExit(msg.Command.Run())}
+	default:
+		if tui.IsQuit(msg) {
+			d.quit = true
+			return nil
+		}
+		return []tui.Msg{msg}
+	}
+}
+
+// collectAll runs cmds one after another and returns their messages.
+func (d *Driver) collectAll(cmds []tui.Cmd) []tui.Msg {
+	var msgs []tui.Msg
+	for _, c := range cmds {
+		msgs = append(msgs, d.collect(c)...)
+	}
+	return msgs
+}
+
+// render returns the model's view with zone markers (and, by default, ANSI
+// sequences) removed.
+func (d *Driver) render() string {
+	frame := tui.Zones.Scan(d.model.View())
+	if !d.keepANSI {
+		frame = ansi.Strip(frame)
+	}
+	return frame
+}
+
+// --- Golden Files ---
+
+// AssertGolden compares got against testdata/<test name>/<name>.golden,
+// rewriting the file instead when the tests run with -update.
+func AssertGolden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", sanitize(t.Name()), sanitize(name)+".golden")
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("tuitest: creating golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+			t.Fatalf("tuitest: writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path) // #nosec G304 -- path is built from the test name under testdata.
+	if err != nil {
+		t.Fatalf("tuitest: reading golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("tuitest: frame does not match %s\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+// sanitize turns a test or frame name into a safe file name.
+func sanitize(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		default:
+			return r
+		}
+	}, name)
+}
@@ -0,0 +1,40 @@
+package tuitest
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+// keyLog records the keys it gets while focused, and whether it was closed.
+type keyLog struct {
+	tui.FocusState
+	keys   []string
+	closed bool
+}
+
+func (m *keyLog) Init() tui.Cmd { return nil }
+
+func (m *keyLog) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if key, ok := msg.(tui.KeyMsg); ok && m.Focused() {
+		m.keys = append(m.keys, key.String())
+	}
+	return m, nil
+}
+
+func (m *keyLog) View() string { return "" }
+
+func (m *keyLog) Close() { m.closed = true }
+
+func TestPressKeysWithFocus(t *testing.T) {
+	m := &keyLog{}
+	t.Run("driver", func(t *testing.T) {
+		New(t, m, WithFocus()).PressKeys("ctrl+a", "pgdown", "alt+b")
+	})
+	if got := len(m.keys); got != 3 || m.keys[0] != "ctrl+a" || m.keys[2] != "alt+b" {
+		t.Errorf("keys = %q, want ctrl+a, pgdown and alt+b", m.keys)
+	}
+	if !m.closed {
+		t.Error("the driver did not close the model when the test ended")
+	}
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// Rendering a widget tree styles and joins every widget on every frame,
+// even though most messages only change one of them. ViewCache lets a
+// widget keep its last view and reuse it until something it depends on
+// changes:
+//
+//	type Model struct {
+//		tui.ViewCache
+//		...
+//	}
+//
+//	func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+//		...
+//		m.cursor++
+//		m.MarkDirty()
+//	}
+//
+//	func (m *Model) View() string {
+//		return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+//	}
+//
+// Containers pass the views of their children as Parts of the key, so they
+// are rebuilt when a child's view changes and reused otherwise. Cached
+// children return the same string, which compares in constant time.
+
+// viewEpoch changes whenever every cached view must be dropped, e.g. when
+// the theme changes.
+var viewEpoch atomic.Uint64
+
+// viewCaching turns ViewCache on; see SetViewCaching.
+var viewCaching atomic.Bool
+
+func init() {
+	viewCaching.Store(true)
+}
+
+// InvalidateViews drops every cached view. Call it when something all views
+// depend on changes, such as the theme.
+func InvalidateViews() {
+	viewEpoch.Add(1)
+}
+
+// SetViewCaching turns view caching on or off for the whole program. It is
+// on by default; turning it off helps to tell a stale view from a rendering
+// bug, and to measure what the cache saves.
+func SetViewCaching(on bool) {
+	viewCaching.Store(on)
+	InvalidateViews()
+}
+
+// ViewKey is what a cached view depends on besides the widget's own state,
+// which MarkDirty tracks.
+type ViewKey struct {
+	Width, Height int
+	Focused       bool
+	// Parts are the views of the children the view is built from.
+	Parts []string
+}
+
+// ViewCache is an embeddable helper that memoizes a widget's view. Its zero
+// value is an empty cache.
+type ViewCache struct {
+	generation uint64
+	valid      bool
+	view       string
+
+	key           ViewKey
+	keyEpoch      uint64
+	keyGeneration uint64
+}
+
+// MarkDirty records that the widget's state changed, so its next View is
+// rendered again.
+func (c *ViewCache) MarkDirty() {
+	c.generation++
+}
+
+// CachedView returns the view cached for key, calling render when the
+// widget was marked dirty, key differs from the last one or the cache was
+// invalidated.
+func (c *ViewCache) CachedView(key ViewKey, render func() string) string {
+	if !viewCaching.Load() {
+		return render()
+	}
+
+	epoch := viewEpoch.Load()
+	if c.valid && c.keyEpoch == epoch && c.keyGeneration == c.generation && c.key.equal(key) {
+		return c.view
+	}
+
+	c.view = render()
+	c.valid = true
+	c.keyEpoch, c.keyGeneration = epoch, c.generation
+	c.key.Width, c.key.Height, c.key.Focused = key.Width, key.Height, key.Focused
+	// Reuse the stored slice: keys are rebuilt on every View.
+	c.key.Parts = append(c.key.Parts[:0], key.Parts...)
+	return c.view
+}
+
+func (k ViewKey) equal(other ViewKey) bool {
+	return k.Width == other.Width &&
+		k.Height == other.Height &&
+		k.Focused == other.Focused &&
+		slices.Equal(k.Parts, other.Parts)
+}
@@ -0,0 +1,60 @@
+package tui
+
+import "testing"
+
+// cachedWidget counts its renders.
+type cachedWidget struct {
+	ViewCache
+	text    string
+	renders int
+}
+
+func (w *cachedWidget) view(key ViewKey) string {
+	return w.CachedView(key, func() string {
+		w.renders++
+		return w.text
+	})
+}
+
+func TestViewCache(t *testing.T) {
+	w := &cachedWidget{text: "a"}
+	key := ViewKey{Width: 10, Height: 2, Parts: []string{"child"}}
+
+	steps := []struct {
+		name   string
+		change func()
+		key    ViewKey
+		render bool
+	}{
+		{"first view", func() {}, key, true},
+		{"unchanged", func() {}, key, false},
+		{"marked dirty", func() { w.text = "b"; w.MarkDirty() }, key, true},
+		{"resized", func() {}, ViewKey{Width: 11, Height: 2, Parts: []string{"child"}}, true},
+		{"focused", func() {}, ViewKey{Width: 11, Height: 2, Focused: true, Parts: []string{"child"}}, true},
+		{"child changed", func() {}, ViewKey{Width: 11, Height: 2, Focused: true, Parts: []string{"other"}}, true},
+		{"same key again", func() {}, ViewKey{Width: 11, Height: 2, Focused: true, Parts: []string{"other"}}, false},
+		{"theme changed", InvalidateViews, ViewKey{Width: 11, Height: 2, Focused: true, Parts: []string{"other"}}, true},
+	}
+	for _, step := range steps {
+		before := w.renders
+		step.change()
+		if got := w.view(step.key); got != w.text {
+			t.Fatalf("%s: view = %q, want %q", step.name, got, w.text)
+		}
+		if rendered := w.renders > before; rendered != step.render {
+			t.Errorf("%s: rendered = %v, want %v", step.name, rendered, step.render)
+		}
+	}
+}
+
+func TestSetViewCachingOff(t *testing.T) {
+	SetViewCaching(false)
+	t.Cleanup(func() { SetViewCaching(true) })
+
+	w := &cachedWidget{text: "a"}
+	w.view(ViewKey{})
+	w.view(ViewKey{})
+	if w.renders != 2 {
+		t.Errorf("renders = %d, want 2 with caching off", w.renders)
+	}
+}
@@ -0,0 +1,421 @@
+package tui
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ZoneID identifies a hit-testable region of the frame.
+// The zero value means "no zone".
+type ZoneID int64
+
+var lastZoneID atomic.Int64
+
+// NewZoneID returns a new, process-unique zone identifier.
+func NewZoneID() ZoneID {
+	return ZoneID(lastZoneID.Add(1))
+}
+
+// Rect is a rectangle of terminal cells.
+type Rect struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Contains reports whether the cell at (x, y) lies inside the rectangle.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// Local translates frame coordinates into coordinates relative to the
+// rectangle's top-left corner.
+func (r Rect) Local(x, y int) (int, int) {
+	return x - r.X, y - r.Y
+}
+
+// Empty reports whether the rectangle covers no cells.
+func (r Rect) Empty() bool {
+	return r.Width <= 0 || r.Height <= 0
+}
+
+// --- Zone Markers ---
+
+// Zone markers are private CSI sequences ("ESC [ = id z" and
+// "ESC [ = id ; 0 z"). They have no width, so they survive lipgloss joins,
+// padding and borders untouched, and are stripped by ZoneMap.Scan before the
+// frame reaches the terminal.
+const zoneMarkerPrefix = "\x1b[="
+
+// MarkZone wraps every line of view in invisible zone markers, so that the
+// area where view ends up on screen can be recovered with ZoneMap.Scan.
+// Marking each line (instead of the whole block) keeps the markers attached
+// to their content when blocks are joined side by side.
+func MarkZone(id ZoneID, view string) string {
+	if id == 0 {
+		return view
+	}
+	start := zoneMarkerPrefix + strconv.FormatInt(int64(id), 10) + "z"
+	end := zoneMarkerPrefix + strconv.FormatInt(int64(id), 10) + ";0z"
+
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		lines[i] = start + line + end
+	}
+	return strings.Join(lines, "\n")
+}
+
+// --- Zone Map ---
+
+// ZoneMap records where marked zones were drawn in the last frame and routes
+// mouse events to the handlers registered for them.
+type ZoneMap struct {
+	mu       sync.Mutex
+	regions  map[ZoneID]Rect
+	onClick  map[ZoneID]func() Cmd
+	onScroll map[ZoneID]func(delta int) Cmd
+	pressed  ZoneID
+	// focused is the zone of the focused widget; it gets the wheel while
+	// it is on screen and scrollable.
+	focused ZoneID
+}
+
+// Zones is the zone map used by the program adapter. Widgets register their
+// click and scroll handlers here.
+var Zones = NewZoneMap()
+
+// NewZoneMap creates an empty zone map.
+func NewZoneMap() *ZoneMap {
+	return &ZoneMap{
+		regions:  make(map[ZoneID]Rect),
+		onClick:  make(map[ZoneID]func() Cmd),
+		onScroll: make(map[ZoneID]func(delta int) Cmd),
+	}
+}
+
+// OnClick registers the handler run when the zone is clicked (pressed and
+// released with the left button). A nil handler removes it.
+func (z *ZoneMap) OnClick(id ZoneID, onClick func() Cmd) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if onClick == nil {
+		delete(z.onClick, id)
+		return
+	}
+	z.onClick[id] = onClick
+}
+
+// OnScroll registers the handler run when the wheel is turned over the zone.
+// delta is negative when scrolling up and positive when scrolling down.
+// A nil handler removes it.
+func (z *ZoneMap) OnScroll(id ZoneID, onScroll func(delta int) Cmd) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if onScroll == nil {
+		delete(z.onScroll, id)
+		return
+	}
+	z.onScroll[id] = onScroll
+}
+
+// Remove forgets a zone and its handlers. Widgets should call it when they
+// are discarded.
+func (z *ZoneMap) Remove(id ZoneID) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	delete(z.regions, id)
+	delete(z.onClick, id)
+	delete(z.onScroll, id)
+	if z.pressed == id {
+		z.pressed = 0
+	}
+	if z.focused == id {
+		z.focused = 0
+	}
+}
+
+// Focus records id as the zone of the focused widget: wheel events go to
+// it wherever the pointer is, as long as it is drawn and scrollable.
+// Widgets call it from their Focus method.
+func (z *ZoneMap) Focus(id ZoneID) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.focused = id
+}
+
+// Blur forgets id as the focused zone. It does nothing if another zone has
+// taken focus since.
+func (z *ZoneMap) Blur(id ZoneID) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if z.focused == id {
+		z.focused = 0
+	}
+}
+
+// Registered reports whether the zone has a click or scroll handler.
+func (z *ZoneMap) Registered(id ZoneID) bool {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	_, click := z.onClick[id]
+	_, scroll := z.onScroll[id]
+	return click || scroll
+}
+
+// Get returns the rectangle where the zone was drawn in the last frame.
+func (z *ZoneMap) Get(id ZoneID) (Rect, bool) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	r, ok := z.regions[id]
+	return r, ok
+}
+
+// At returns the innermost zone drawn at (x, y) in the last frame.
+func (z *ZoneMap) At(x, y int) (ZoneID, bool) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	id := z.hitLocked(x, y, func(ZoneID) bool { return true })
+	return id, id != 0
+}
+
+// HandleMouse routes a mouse event and returns the command produced by its
+// handler, if any. Clicks go to the zone under the pointer; the wheel goes
+// to the focused zone, or to the one under the pointer when the focused
+// widget cannot scroll.
+func (z *ZoneMap) HandleMouse(msg MouseMsg) Cmd {
+	z.mu.Lock()
+
+	switch {
+	case msg.IsWheel():
+		delta := 0
+		switch msg.Button {
+		case MouseButtonWheelUp:
+			delta = -1
+		case MouseButtonWheelDown:
+			delta = 1
+		}
+		id := z.focused
+		if _, drawn := z.regions[id]; !drawn || z.onScroll[id] == nil {
+			id = z.hitLocked(msg.X, msg.Y, func(id ZoneID) bool { return z.onScroll[id] != nil })
+		}
+		onScroll := z.onScroll[id]
+		z.mu.Unlock()
+		if onScroll == nil || delta == 0 {
+			return nil
+		}
+		return onScroll(delta)
+
+	case msg.Action == MouseActionPress && msg.Button == MouseButtonLeft:
+		z.pressed = z.hitLocked(msg.X, msg.Y, func(id ZoneID) bool { return z.onClick[id] != nil })
+		z.mu.Unlock()
+		return nil
+
+	case msg.Action == MouseActionRelease:
+		pressed := z.pressed
+		z.pressed = 0
+		id := z.hitLocked(msg.X, msg.Y, func(id ZoneID) bool { return z.onClick[id] != nil })
+		onClick := z.onClick[id]
+		z.mu.Unlock()
+		if pressed == 0 || id != pressed || onClick == nil {
+			return nil
+		}
+		return onClick()
+
+	default:
+		z.mu.Unlock()
+		return nil
+	}
+}
+
+// hitLocked returns the smallest zone containing (x, y) that satisfies
+// accept. Nested zones are always smaller than their parents, so this picks
+// the innermost one. The caller must hold z.mu.
+func (z *ZoneMap) hitLocked(x, y int, accept func(ZoneID) bool) ZoneID {
+	var best ZoneID
+	bestArea := -1
+	for id, r := range z.regions {
+		if !r.Contains(x, y) || !accept(id) {
+			continue
+		}
+		area := r.Width * r.Height
+		if bestArea < 0 || area < bestArea || (area == bestArea && id > best) {
+			best, bestArea = id, area
+		}
+	}
+	return best
+}
+
+// Scan records the position of every zone marked in frame and returns the
+// frame with the markers removed. Zones that are not present in frame are
+// no longer hit-testable until they are drawn again.
+func (z *ZoneMap) Scan(frame string) string {
+	if !strings.Contains(frame, zoneMarkerPrefix) {
+		z.mu.Lock()
+		clear(z.regions)
+		z.mu.Unlock()
+		return frame
+	}
+
+	type bounds struct{ minX, minY, maxX, maxY int }
+	found := make(map[ZoneID]*bounds)
+	open := make(map[ZoneID]int)
+
+	var out strings.Builder
+	out.Grow(len(frame))
+	x, y := 0, 0
+
+	for i := 0; i < len(frame); {
+		switch frame[i] {
+		case '\n':
+			out.WriteByte('\n')
+			x, y = 0, y+1
+			i++
+
+		case '\x1b':
+			n := escapeLen(frame[i:])
+			seq := frame[i : i+n]
+			i += n
+
+			id, isEnd, ok := parseZoneMarker(seq)
+			if !ok {
+				out.WriteString(seq)
+				continue
+			}
+			if !isEnd {
+				open[id] = x
+				continue
+			}
+			startX, wasOpen := open[id]
+			if !wasOpen {
+				continue
+			}
+			delete(open, id)
+			if b, seen := found[id]; seen {
+				b.minX, b.maxX, b.maxY = min(b.minX, startX), max(b.maxX, x), y
+			} else {
+				found[id] = &bounds{minX: startX, minY: y, maxX: x, maxY: y}
+			}
+
+		default:
+			j := strings.IndexAny(frame[i:], "\x1b\n")
+			if j < 0 {
+				j = len(frame) - i
+			}
+			segment := frame[i : i+j]
+			out.WriteString(segment)
+			x += Width(segment)
+			i += j
+		}
+	}
+
+	z.mu.Lock()
+	clear(z.regions)
+	for id, b := range found {
+		z.regions[id] = Rect{X: b.minX, Y: b.minY, Width: b.maxX - b.minX, Height: b.maxY - b.minY + 1}
+	}
+	z.mu.Unlock()
+
+	return out.String()
+}
+
+// StripZones returns view without its zone markers, so none of its zones
+// can be hit. Overlays use it for the frame they cover.
+func StripZones(view string) string {
+	if !strings.Contains(view, zoneMarkerPrefix) {
+		return view
+	}
+	var out strings.Builder
+	out.Grow(len(view))
+	for i := 0; i < len(view); {
+		if view[i] != '\x1b' {
+			j := strings.IndexByte(view[i:], '\x1b')
+			if j < 0 {
+				j = len(view) - i
+			}
+			out.WriteString(view[i : i+j])
+			i += j
+			continue
+		}
+		n := escapeLen(view[i:])
+		if _, _, ok := parseZoneMarker(view[i : i+n]); !ok {
+			out.WriteString(view[i : i+n])
+		}
+		i += n
+	}
+	return out.String()
+}
+
+// parseZoneMarker reports whether seq is a zone marker, returning its zone
+// and whether it closes the zone.
+func parseZoneMarker(seq string) (id ZoneID, isEnd bool, ok bool) {
+	if !strings.HasPrefix(seq, zoneMarkerPrefix) || !strings.HasSuffix(seq, "z") {
+		return 0, false, false
+	}
+	params := seq[len(zoneMarkerPrefix) : len(seq)-1]
+	if p, found := strings.CutSuffix(params, ";0"); found {
+		params, isEnd = p, true
+	}
+	n, err := strconv.ParseInt(params, 10, 64)
+	if err != nil {
+		return 0, false, false
+	}
+	return ZoneID(n), isEnd, true
+}
+
+// escapeLen returns the length of the escape sequence at the start of s.
+// It understands CSI ("ESC [ ... final") and OSC ("ESC ] ... BEL|ST")
+// sequences; any other escape is treated as ESC plus one byte.
+func escapeLen(s string) int {
+	if len(s) < 2 {
+		return len(s)
+	}
+	switch s[1] {
+	case '[':
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(s)
+	case ']':
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	default:
+		return 2
+	}
+}
+
+// --- Clickable Support ---
+
+// ClickZone is an embeddable helper for widgets that implement Clickable.
+// It owns the widget's zone, implements SetOnClick and marks the widget's
+// rendered view so clicks on it can be routed back.
+type ClickZone struct {
+	id ZoneID
+}
+
+// ZoneID returns the zone of the widget, allocating it on first use.
+func (c *ClickZone) ZoneID() ZoneID {
+	if c.id == 0 {
+		c.id = NewZoneID()
+	}
+	return c.id
+}
+
+// SetOnClick registers the command factory run when the widget is clicked.
+func (c *ClickZone) SetOnClick(onClick func() Cmd) {
+	Zones.OnClick(c.ZoneID(), onClick)
+}
+
+// MarkZone marks view as the clickable area of the widget.
+func (c *ClickZone) MarkZone(view string) string {
+	return MarkZone(c.ZoneID(), view)
+}
@@ -0,0 +1,122 @@
+package tui
+
+import "testing"
+
+func TestZoneMapScan(t *testing.T) {
+	zones := NewZoneMap()
+	left, right, inner := NewZoneID(), NewZoneID(), NewZoneID()
+
+	box := NewStyle().Border(NormalBorder).Width(6).Height(2)
+	leftView := MarkZone(left, box.Render("left"))
+	rightView := MarkZone(right, box.Render(MarkZone(inner, "ok")))
+	frame := JoinVertical(Left, "header", JoinHorizontal(Top, leftView, "  ", rightView))
+
+	plain := zones.Scan(frame)
+	if want := JoinVertical(Left, "header", JoinHorizontal(Top, box.Render("left"), "  ", box.Render("ok"))); plain != want {
+		t.Fatalf("Scan did not strip markers:\n%q\nwant\n%q", plain, want)
+	}
+
+	tests := []struct {
+		id   ZoneID
+		want Rect
+	}{
+		{left, Rect{X: 0, Y: 1, Width: 8, Height: 4}},
+		{right, Rect{X: 10, Y: 1, Width: 8, Height: 4}},
+		{inner, Rect{X: 11, Y: 2, Width: 2, Height: 1}},
+	}
+	for _, tt := range tests {
+		got, ok := zones.Get(tt.id)
+		if !ok {
+			t.Fatalf("zone %d not recorded", tt.id)
+		}
+		if got != tt.want {
+			t.Errorf("zone %d = %+v, want %+v", tt.id, got, tt.want)
+		}
+	}
+
+	if id, _ := zones.At(11, 2); id != inner {
+		t.Errorf("At(11, 2) = %d, want innermost zone %d", id, inner)
+	}
+	if _, ok := zones.At(9, 2); ok {
+		t.Errorf("At(9, 2) hit a zone in the gap between boxes")
+	}
+}
+
+func TestZoneMapHandleMouse(t *testing.T) {
+	type clicked struct{}
+	zones := NewZoneMap()
+	button, list := NewZoneID(), NewZoneID()
+	zones.OnClick(button, func() Cmd { return func() Msg { return clicked{} } })
+	var scrolled int
+	zones.OnScroll(list, func(delta int) Cmd { scrolled += delta; return nil })
+	zones.Scan(JoinHorizontal(Top, MarkZone(button, "[ OK ]"), MarkZone(list, "a\nb\nc")))
+
+	press := MouseMsg{X: 2, Y: 0, Button: MouseButtonLeft, Action: MouseActionPress}
+	release := MouseMsg{X: 3, Y: 0, Button: MouseButtonLeft, Action: MouseActionRelease}
+	if cmd := zones.HandleMouse(press); cmd != nil {
+		t.Fatalf("press returned a command")
+	}
+	cmd := zones.HandleMouse(release)
+	if cmd == nil {
+		t.Fatalf("click on button returned no command")
+	}
+	if _, ok := cmd().(clicked); !ok {
+		t.Errorf("click command produced the wrong message")
+	}
+
+	// A press outside followed by a release on the button is not a click.
+	zones.HandleMouse(MouseMsg{X: 6, Y: 2, Button: MouseButtonLeft, Action: MouseActionPress})
+	if cmd := zones.HandleMouse(release); cmd != nil {
+		t.Errorf("drag onto button counted as a click")
+	}
+
+	zones.HandleMouse(MouseMsg{X: 6, Y: 1, Button: MouseButtonWheelDown})
+	zones.HandleMouse(MouseMsg{X: 6, Y: 1, Button: MouseButtonWheelDown})
+	zones.HandleMouse(MouseMsg{X: 6, Y: 1, Button: MouseButtonWheelUp})
+	if scrolled != 1 {
+		t.Errorf("scrolled = %d, want 1", scrolled)
+	}
+}
+
+func TestZoneMapWheelGoesToFocus(t *testing.T) {
+	zones := NewZoneMap()
+	left, right, button := NewZoneID(), NewZoneID(), NewZoneID()
+	scrolled := map[ZoneID]int{}
+	for _, id := range []ZoneID{left, right} {
+		zones.OnScroll(id, func(delta int) Cmd { scrolled[id] += delta; return nil })
+	}
+	zones.OnClick(button, func() Cmd { return nil })
+	zones.Scan(JoinHorizontal(Top, MarkZone(left, "a\nb"), MarkZone(right, "c\nd"), MarkZone(button, "[OK]")))
+	wheel := func(x int) { zones.HandleMouse(MouseMsg{X: x, Y: 0, Button: MouseButtonWheelDown}) }
+
+	// Nothing focused: the zone under the pointer scrolls.
+	wheel(1)
+	if scrolled[right] != 1 || scrolled[left] != 0 {
+		t.Fatalf("scrolled = %v, want the right zone under the pointer", scrolled)
+	}
+
+	// The focused zone scrolls wherever the pointer is.
+	zones.Focus(left)
+	wheel(1)
+	wheel(3)
+	if scrolled[left] != 2 {
+		t.Errorf("scrolled = %v, want the focused left zone twice", scrolled)
+	}
+
+	// A focused zone that cannot scroll leaves the wheel to the pointer.
+	zones.Focus(button)
+	wheel(1)
+	if scrolled[right] != 2 {
+		t.Errorf("scrolled = %v, want the right zone under the pointer", scrolled)
+	}
+
+	// Blurring another zone keeps the focus; blurring the focused one drops it.
+	zones.Focus(left)
+	zones.Blur(right)
+	wheel(1)
+	zones.Blur(left)
+	wheel(1)
+	if scrolled[left] != 3 || scrolled[right] != 3 {
+		t.Errorf("scrolled = %v, want one more each", scrolled)
+	}
+}
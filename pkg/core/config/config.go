@@ -4,6 +4,9 @@ package config
 // UserPreferences representa as configurações do usuário.
 type UserPreferences struct {
 	Theme string `yaml:"theme"`
+	// Keybindings substitui as teclas de ações do keymap, pelo nome da ação.
+	// Ex.: shell.help: ["f1", "?"]. Uma lista vazia desativa a ação.
+	Keybindings map[string][]string `yaml:"keybindings,omitempty"`
 	// Adicione outros campos, como:
 	// DefaultStack string `yaml:"default_stack"`
 	// TelemetryEnabled bool `yaml:"telemetry_enabled"`
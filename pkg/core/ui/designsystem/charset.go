@@ -0,0 +1,56 @@
+package designsystem
+
+import (
+	"os"
+	"strings"
+)
+
+// Charset is the set of characters the terminal can draw (charset_priority
+// in the theme files).
+type Charset int
+
+const (
+	CharsetUnicode Charset = iota
+	CharsetASCII
+)
+
+// DetectCharset returns CharsetASCII when the terminal cannot be trusted
+// with Unicode: TERM is "dumb" or "linux" (the kernel console), or the
+// locale is set to something other than UTF-8, e.g. LANG=C.
+func DetectCharset() Charset {
+	switch os.Getenv("TERM") {
+	case "dumb", "linux":
+		return CharsetASCII
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := strings.ToLower(os.Getenv(name))
+		if locale == "" {
+			continue
+		}
+		if strings.Contains(locale, "utf-8") || strings.Contains(locale, "utf8") {
+			return CharsetUnicode
+		}
+		return CharsetASCII
+	}
+	return CharsetUnicode
+}
+
+// Glyph is a symbol drawn by a widget, with its ASCII fallback.
+type Glyph struct {
+	Unicode string
+	ASCII   string
+}
+
+// String returns the glyph for the charset of the current theme.
+func (g Glyph) String() string {
+	return g.For(Current().Charset)
+}
+
+// For returns the glyph for charset. A glyph without a Unicode form is
+// ASCII everywhere.
+func (g Glyph) For(charset Charset) string {
+	if charset == CharsetASCII || g.Unicode == "" {
+		return g.ASCII
+	}
+	return g.Unicode
+}
@@ -0,0 +1,841 @@
+// Package designsystem holds the theme tokens that widgets use to style
+// themselves. The default theme mirrors assets/themes/template.yml.
+package designsystem
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+// Effect is a terminal text effect applied by a state (focus, active, ...).
+type Effect string
+
+// Effects supported by the themes.
+const (
+	EffectNone      Effect = "none"
+	EffectReverse   Effect = "reverse"
+	EffectUnderline Effect = "underline"
+	EffectDim       Effect = "dim"
+)
+
+// Palette holds the semantic colors of a theme. Values are anything
+// tui.Style accepts as a color: "#RRGGBB" or an ANSI index such as "12".
+type Palette struct {
+	Bg           string
+	Surface      string
+	SurfaceAlt   string
+	Text         string
+	TextMuted    string
+	Primary      string
+	PrimaryOn    string
+	Secondary    string
+	Success      string
+	Warning      string
+	Danger       string
+	Info         string
+	OverlayScrim string
+}
+
+// StateStyle holds the styling applied to a widget in a given state.
+// Empty colors leave the widget's own color untouched.
+type StateStyle struct {
+	Border string
+	Text   string
+	Bg     string
+	Effect Effect
+}
+
+// Apply returns style with the state's colors and effect applied.
+// Styles are values, so the caller's stored style is left untouched.
+func (s StateStyle) Apply(style tui.Style) tui.Style {
+	style = s.ApplyBorder(style)
+	if s.Text != "" {
+		style = style.Foreground(s.Text)
+	}
+	if s.Bg != "" {
+		style = style.Background(s.Bg)
+	}
+	switch s.Effect {
+	case EffectReverse:
+		style = style.Reverse(true)
+	case EffectUnderline:
+		style = style.Underline(true)
+	case EffectDim:
+		style = style.Faint(true)
+	}
+	return style
+}
+
+// ApplyBorder returns style with only the state's border color applied.
+// Containers use it to show focus without recoloring their whole content.
+func (s StateStyle) ApplyBorder(style tui.Style) tui.Style {
+	if s.Border != "" {
+		style = style.BorderForeground(s.Border)
+	}
+	return style
+}
+
+// FocusBorder returns style with the current theme's focus border color
+// when focused, and style unchanged otherwise. The Scaffold's slots show
+// which one has focus this way.
+func FocusBorder(style tui.Style, focused bool) tui.Style {
+	if !focused {
+		return style
+	}
+	return Current().State.Focus.ApplyBorder(style)
+}
+
+// States holds the styling of the interactive states.
+type States struct {
+	Focus    StateStyle
+	Hover    StateStyle
+	Active   StateStyle
+	Disabled StateStyle
+}
+
+// ScaffoldTokens holds the sizes of the Scaffold slots created without a
+// size option: the heights of the bars and the width of the sidemenu,
+// borders included.
+type ScaffoldTokens struct {
+	AppBarHeight    int
+	NavBarHeight    int
+	BottomBarHeight int
+	SidemenuWidth   int
+}
+
+// TextVariant names a typographic type (typography.scale in the theme
+// files).
+type TextVariant string
+
+// Text variants supported by the themes.
+const (
+	TextH1    TextVariant = "h1"
+	TextH2    TextVariant = "h2"
+	TextH3    TextVariant = "h3"
+	TextH4    TextVariant = "h4"
+	TextH5    TextVariant = "h5"
+	TextH6    TextVariant = "h6"
+	TextTitle TextVariant = "title"
+	TextBody  TextVariant = "body"
+	TextLabel TextVariant = "label"
+	TextCode  TextVariant = "code"
+)
+
+// TypeStyle holds the styling of a text variant.
+type TypeStyle struct {
+	Color     string
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Dim       bool
+	// Lines is the vertical space the type takes in a TextBox: headings
+	// leave blank lines below them.
+	Lines int
+}
+
+// Apply returns style with the type's color and emphasis applied.
+func (t TypeStyle) Apply(style tui.Style) tui.Style {
+	if t.Color != "" {
+		style = style.Foreground(t.Color)
+	}
+	return style.Bold(t.Bold).Italic(t.Italic).Underline(t.Underline).Faint(t.Dim)
+}
+
+// TextTokens holds the variants of the Text widget.
+type TextTokens struct {
+	Variants map[TextVariant]TypeStyle
+}
+
+// Variant returns the styling of variant, or of TextBody when the theme
+// does not define it.
+func (t TextTokens) Variant(variant TextVariant) TypeStyle {
+	if style, ok := t.Variants[variant]; ok {
+		return style
+	}
+	return t.Variants[TextBody]
+}
+
+// TextBoxTokens holds the defaults of the TextBox widget.
+type TextBoxTokens struct {
+	Padding  int
+	Variant  TextVariant
+	Wrap     bool
+	Ellipsis string
+}
+
+// ButtonVariant names a Button variant.
+type ButtonVariant string
+
+// Button variants supported by the themes.
+const (
+	ButtonPrimary   ButtonVariant = "primary"
+	ButtonSecondary ButtonVariant = "secondary"
+	ButtonGhost     ButtonVariant = "ghost"
+	ButtonDanger    ButtonVariant = "danger"
+)
+
+// ButtonStates holds the styling of a Button in each of its states.
+type ButtonStates struct {
+	Default  StateStyle
+	Hover    StateStyle
+	Focus    StateStyle
+	Active   StateStyle
+	Disabled StateStyle
+}
+
+// ButtonVariantTokens holds what a variant changes from the base Button. A
+// state left zero keeps the base styling; a state set replaces it whole,
+// so a variant can drop the base background.
+type ButtonVariantTokens struct {
+	NoBorder bool
+	States   ButtonStates
+}
+
+// ButtonTokens holds the defaults of the Button widget.
+type ButtonTokens struct {
+	Padding     int
+	MinWidth    int
+	Border      tui.Border
+	BorderColor string
+	States      ButtonStates
+	Variants    map[ButtonVariant]ButtonVariantTokens
+}
+
+// Bordered reports whether buttons of variant are drawn with a border.
+func (b ButtonTokens) Bordered(variant ButtonVariant) bool {
+	return !b.Variants[variant].NoBorder
+}
+
+// VariantStates returns the state styles of variant, falling back to the base
+// Button for the states the variant does not define.
+func (b ButtonTokens) VariantStates(variant ButtonVariant) ButtonStates {
+	states := b.States
+	override := b.Variants[variant].States
+	for _, s := range []struct{ base, over *StateStyle }{
+		{&states.Default, &override.Default},
+		{&states.Hover, &override.Hover},
+		{&states.Focus, &override.Focus},
+		{&states.Active, &override.Active},
+		{&states.Disabled, &override.Disabled},
+	} {
+		if *s.over != (StateStyle{}) {
+			*s.base = *s.over
+		}
+	}
+	return states
+}
+
+// InputStates holds the styling of an Input in each of its states.
+type InputStates struct {
+	Default  StateStyle
+	Focus    StateStyle
+	Disabled StateStyle
+	Error    StateStyle
+}
+
+// InputTokens holds the defaults of the Input widget.
+type InputTokens struct {
+	Padding     int
+	Border      tui.Border
+	BorderColor string
+	Placeholder StateStyle
+	Caret       string
+	// Suggestion styles the completion shown after the value.
+	Suggestion StateStyle
+	HelpText   string
+	States     InputStates
+}
+
+// ListItemStates holds the styling of the rows of a List.
+type ListItemStates struct {
+	Normal   StateStyle
+	Muted    StateStyle
+	Selected StateStyle
+	// Match styles the characters matched by the filter.
+	Match StateStyle
+}
+
+// ScrollbarTokens holds the glyphs and colors of a scrollbar.
+type ScrollbarTokens struct {
+	Enabled    bool
+	Track      string
+	TrackColor string
+	Thumb      string
+	ThumbColor string
+}
+
+// Render draws a vertical scrollbar of height cells for a view showing
+// visible of total rows, starting at offset. It returns "" when everything
+// fits or the scrollbar is disabled.
+func (s ScrollbarTokens) Render(height, visible, total, offset int) string {
+	if !s.Enabled || total <= visible || height <= 0 {
+		return ""
+	}
+	thumb := min(max(height*visible/total, 1), height)
+	start := 0
+	if scrollable := total - visible; scrollable > 0 {
+		start = (height - thumb) * min(max(offset, 0), scrollable) / scrollable
+	}
+	track := tui.NewStyle().Foreground(s.TrackColor).Render(s.Track)
+	bar := tui.NewStyle().Foreground(s.ThumbColor).Render(s.Thumb)
+	cells := make([]string, height)
+	for i := range cells {
+		cells[i] = track
+		if i >= start && i < start+thumb {
+			cells[i] = bar
+		}
+	}
+	return strings.Join(cells, "\n")
+}
+
+// ListTokens holds the defaults of the List widget.
+type ListTokens struct {
+	Padding   int
+	Item      ListItemStates
+	Focus     StateStyle
+	Scrollbar ScrollbarTokens
+}
+
+// TableRowStates holds the styling of the rows of a Table.
+type TableRowStates struct {
+	Normal StateStyle
+	// Alternate styles every other row; leave it empty for no stripes.
+	Alternate StateStyle
+	Highlight StateStyle
+	Muted     StateStyle
+}
+
+// TableTokens holds the defaults of the Table widget.
+type TableTokens struct {
+	Rounded     bool
+	BorderColor string
+	Header      StateStyle
+	Row         TableRowStates
+	Footer      StateStyle
+	// SortAsc and SortDesc mark the title of the sorted column.
+	SortAsc  string
+	SortDesc string
+}
+
+// CheckboxTokens holds the glyphs and colors of checkboxes and checkbox
+// trees.
+type CheckboxTokens struct {
+	Unchecked      Glyph
+	Checked        Glyph
+	Mixed          Glyph
+	UncheckedColor string
+	CheckedColor   string
+	MixedColor     string
+	// Indent is the number of cells each level of a tree is indented by.
+	Indent int
+}
+
+// RadioTokens holds the glyphs and colors of radio buttons.
+type RadioTokens struct {
+	Off      Glyph
+	On       Glyph
+	OffColor string
+	OnColor  string
+}
+
+// ProgressTokens holds the glyphs and colors of progress bars.
+type ProgressTokens struct {
+	Filled      Glyph
+	Empty       Glyph
+	FilledColor string
+	EmptyColor  string
+	// Pulse is the width, in cells, of the block sweeping an indeterminate
+	// bar.
+	Pulse       int
+	ShowPercent bool
+}
+
+// SpinnerTokens holds the frames of spinners.
+type SpinnerTokens struct {
+	Frames      []string
+	ASCIIFrames []string
+	Interval    time.Duration
+	Color       string
+}
+
+// FramesFor returns the frames to draw with charset.
+func (s SpinnerTokens) FramesFor(charset Charset) []string {
+	if charset == CharsetASCII || len(s.Frames) == 0 {
+		return s.ASCIIFrames
+	}
+	return s.Frames
+}
+
+// TaskStateTokens holds how a task state is marked in a TaskPanel.
+type TaskStateTokens struct {
+	Glyph Glyph
+	Color string
+}
+
+// TaskPanelTokens holds the defaults of the TaskPanel widget. Running
+// tasks are marked with the spinner.
+type TaskPanelTokens struct {
+	// TitleWidth is the most cells given to the task titles.
+	TitleWidth int
+	Pending    TaskStateTokens
+	Done       TaskStateTokens
+	Failed     TaskStateTokens
+	Skipped    TaskStateTokens
+	Output     StateStyle
+}
+
+// NavBarTokens holds the defaults of the NavBar (tabs) widget.
+type NavBarTokens struct {
+	// Padding is the number of cells on each side of a tab's label.
+	Padding int
+	Tab     StateStyle
+	Active  StateStyle
+	// Focus styles the active tab while the bar has focus.
+	Focus StateStyle
+	Badge StateStyle
+	// ScrollLeft and ScrollRight mark tabs hidden past the edges.
+	ScrollLeft  Glyph
+	ScrollRight Glyph
+}
+
+// BorderSet holds the glyphs of a frame (borders.sets in the theme files),
+// with the junctions drawn where dividers meet it.
+type BorderSet struct {
+	Horizontal  string
+	Vertical    string
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	// TeeTop joins a vertical line to a top edge (┬), TeeLeft a horizontal
+	// line to a left edge (├), and so on; Cross joins two lines (┼).
+	TeeTop    string
+	TeeBottom string
+	TeeLeft   string
+	TeeRight  string
+	Cross     string
+}
+
+// The border sets of the theme files.
+var (
+	ASCIISingle = BorderSet{
+		Horizontal: "-", Vertical: "|",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+		TeeTop: "+", TeeBottom: "+", TeeLeft: "+", TeeRight: "+", Cross: "+",
+	}
+	UnicodeLight = BorderSet{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘",
+		TeeTop: "┬", TeeBottom: "┴", TeeLeft: "├", TeeRight: "┤", Cross: "┼",
+	}
+	UnicodeDouble = BorderSet{
+		Horizontal: "═", Vertical: "║",
+		TopLeft: "╔", TopRight: "╗", BottomLeft: "╚", BottomRight: "╝",
+		TeeTop: "╦", TeeBottom: "╩", TeeLeft: "╠", TeeRight: "╣", Cross: "╬",
+	}
+	UnicodeRounded = BorderSet{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "╭", TopRight: "╮", BottomLeft: "╰", BottomRight: "╯",
+		TeeTop: "┬", TeeBottom: "┴", TeeLeft: "├", TeeRight: "┤", Cross: "┼",
+	}
+)
+
+// BorderSetOf returns the set drawing border. Borders that are not one of
+// the theme sets get their edges as junctions.
+func BorderSetOf(border tui.Border) BorderSet {
+	for _, set := range []BorderSet{UnicodeLight, UnicodeRounded, UnicodeDouble, ASCIISingle} {
+		if set.Border() == border {
+			return set
+		}
+	}
+	return BorderSet{
+		Horizontal: border.Top, Vertical: border.Left,
+		TopLeft: border.TopLeft, TopRight: border.TopRight,
+		BottomLeft: border.BottomLeft, BottomRight: border.BottomRight,
+		TeeTop: border.Top, TeeBottom: border.Bottom,
+		TeeLeft: border.Left, TeeRight: border.Right, Cross: border.Top,
+	}
+}
+
+// Border returns the set as a tui.Border, for styles that draw frames.
+func (s BorderSet) Border() tui.Border {
+	return tui.Border{
+		Top: s.Horizontal, Bottom: s.Horizontal, Left: s.Vertical, Right: s.Vertical,
+		TopLeft: s.TopLeft, TopRight: s.TopRight, BottomLeft: s.BottomLeft, BottomRight: s.BottomRight,
+	}
+}
+
+// For returns the set to draw with charset: ASCIISingle for ASCII.
+func (s BorderSet) For(charset Charset) BorderSet {
+	if charset == CharsetASCII {
+		return ASCIISingle
+	}
+	return s
+}
+
+// Junction returns the glyph of a cell whose lines leave it upward,
+// rightward, downward and leftward as given: a line, a corner, a tee or a
+// cross.
+func (s BorderSet) Junction(up, right, down, left bool) string {
+	switch {
+	case up && right && down && left:
+		return s.Cross
+	case right && down && left:
+		return s.TeeTop
+	case up && right && left:
+		return s.TeeBottom
+	case up && right && down:
+		return s.TeeLeft
+	case up && down && left:
+		return s.TeeRight
+	case right && down:
+		return s.TopLeft
+	case down && left:
+		return s.TopRight
+	case up && right:
+		return s.BottomLeft
+	case up && left:
+		return s.BottomRight
+	case up || down:
+		return s.Vertical
+	default:
+		return s.Horizontal
+	}
+}
+
+// BorderToken is a named border: a set and its color.
+type BorderToken struct {
+	Set   BorderSet
+	Color string
+}
+
+// BorderTokens holds the named borders (borders.tokens in the theme files).
+type BorderTokens struct {
+	Hair   BorderToken
+	Thin   BorderToken
+	Strong BorderToken
+	Round  BorderToken
+	Focus  BorderToken
+	Danger BorderToken
+}
+
+// Elevation names how high a surface floats over the screen.
+type Elevation string
+
+// Elevations supported by the themes.
+const (
+	ElevationNone   Elevation = "none"
+	ElevationLow    Elevation = "low"
+	ElevationMedium Elevation = "medium"
+	ElevationHigh   Elevation = "high"
+)
+
+// ElevationTokens holds the simulated shadow of an elevation: a background
+// shifted right by OffsetX columns and down by OffsetY rows. A zero offset
+// draws no shadow.
+type ElevationTokens struct {
+	Shadow  string
+	OffsetX int
+	OffsetY int
+	// Shade fills the shadow cells; a space shows only the background.
+	Shade string
+}
+
+// CardBoxTokens holds the defaults of the CardBox widget.
+type CardBoxTokens struct {
+	Bg        string
+	Border    BorderToken
+	Padding   int
+	Elevation Elevation
+	Title     TextVariant
+}
+
+// DividerTokens holds the defaults of the Divider widget.
+type DividerTokens struct {
+	Border BorderToken
+}
+
+// SpacerTokens holds the defaults of the Spacer widget.
+type SpacerTokens struct {
+	// Size is the blank space, in cells, a Spacer leaves.
+	Size int
+}
+
+// Components holds the per-component tokens (components.* in the theme
+// files).
+type Components struct {
+	Scaffold  ScaffoldTokens
+	Text      TextTokens
+	TextBox   TextBoxTokens
+	Button    ButtonTokens
+	Input     InputTokens
+	List      ListTokens
+	Table     TableTokens
+	Checkbox  CheckboxTokens
+	Radio     RadioTokens
+	CardBox   CardBoxTokens
+	Divider   DividerTokens
+	Spacer    SpacerTokens
+	Progress  ProgressTokens
+	Spinner   SpinnerTokens
+	TaskPanel TaskPanelTokens
+	NavBar    NavBarTokens
+}
+
+// Theme is the set of tokens shared by every widget.
+type Theme struct {
+	Name string
+	// Charset picks the glyphs widgets draw; see DetectCharset.
+	Charset Charset
+	Palette Palette
+	State   States
+	Borders BorderTokens
+	// Elevation maps each elevation to its shadow; elevations missing from
+	// it draw none.
+	Elevation  map[Elevation]ElevationTokens
+	Components Components
+}
+
+// Default returns the built-in theme, matching assets/themes/template.yml.
+func Default() *Theme {
+	palette := Palette{
+		Bg:           "#0B0B0C",
+		Surface:      "#121316",
+		SurfaceAlt:   "#16181C",
+		Text:         "#EAECEF",
+		TextMuted:    "#9AA0A6",
+		Primary:      "#4F46E5",
+		PrimaryOn:    "#F8FAFC",
+		Secondary:    "#22D3EE",
+		Success:      "#22C55E",
+		Warning:      "#F59E0B",
+		Danger:       "#EF4444",
+		Info:         "#38BDF8",
+		OverlayScrim: "#000000",
+	}
+	borders := BorderTokens{
+		Hair:   BorderToken{Set: UnicodeLight, Color: palette.Surface},
+		Thin:   BorderToken{Set: UnicodeLight, Color: "8"},
+		Strong: BorderToken{Set: UnicodeDouble, Color: "12"},
+		Round:  BorderToken{Set: UnicodeRounded, Color: "8"},
+		Focus:  BorderToken{Set: UnicodeLight, Color: "12"},
+		Danger: BorderToken{Set: UnicodeLight, Color: palette.Danger},
+	}
+
+	return &Theme{
+		Name:    "default",
+		Charset: DetectCharset(),
+		Palette: palette,
+		State: States{
+			Focus: StateStyle{
+				Border: "#60A5FA",
+				Text:   "7",
+				Bg:     palette.SurfaceAlt,
+				Effect: EffectReverse,
+			},
+			Hover: StateStyle{
+				Border: "8",
+				Bg:     palette.SurfaceAlt,
+			},
+			Active: StateStyle{
+				Effect: EffectReverse,
+			},
+			Disabled: StateStyle{
+				Text:   "8",
+				Border: "8",
+			},
+		},
+		Borders: borders,
+		// Shadows are black at 25%, 35% and 50% over the background.
+		Elevation: map[Elevation]ElevationTokens{
+			ElevationNone:   {},
+			ElevationLow:    {Shadow: "#080809", OffsetX: 1, OffsetY: 1, Shade: " "},
+			ElevationMedium: {Shadow: "#070708", OffsetX: 1, OffsetY: 1, Shade: " "},
+			ElevationHigh:   {Shadow: "#050506", OffsetX: 1, OffsetY: 2, Shade: " "},
+		},
+		Components: Components{
+			Scaffold: ScaffoldTokens{
+				AppBarHeight:    3,
+				NavBarHeight:    1,
+				BottomBarHeight: 3,
+				SidemenuWidth:   20,
+			},
+			Text: TextTokens{
+				Variants: map[TextVariant]TypeStyle{
+					TextH1:    {Color: palette.Text, Bold: true, Lines: 2},
+					TextH2:    {Color: palette.Text, Bold: true, Lines: 2},
+					TextH3:    {Color: palette.Text, Bold: true, Lines: 1},
+					TextH4:    {Color: palette.Text, Bold: true, Lines: 1},
+					TextH5:    {Color: palette.Text, Lines: 1},
+					TextH6:    {Color: palette.TextMuted, Dim: true, Lines: 1},
+					TextTitle: {Color: palette.Text, Bold: true, Lines: 1},
+					TextBody:  {Color: palette.Text, Lines: 1},
+					TextLabel: {Color: palette.TextMuted, Italic: true, Lines: 1},
+					TextCode:  {Color: "6", Lines: 1},
+				},
+			},
+			TextBox: TextBoxTokens{
+				Padding:  0,
+				Variant:  TextBody,
+				Wrap:     true,
+				Ellipsis: "…",
+			},
+			Button: ButtonTokens{
+				Padding:     1,
+				MinWidth:    6,
+				Border:      tui.NormalBorder,
+				BorderColor: "8",
+				States: ButtonStates{
+					Default:  StateStyle{Text: palette.Text, Bg: palette.SurfaceAlt},
+					Hover:    StateStyle{Text: palette.Text, Bg: palette.Surface},
+					Focus:    StateStyle{Border: "#60A5FA", Effect: EffectReverse},
+					Active:   StateStyle{Effect: EffectReverse},
+					Disabled: StateStyle{Text: palette.TextMuted, Bg: palette.Surface, Effect: EffectDim},
+				},
+				Variants: map[ButtonVariant]ButtonVariantTokens{
+					ButtonPrimary: {States: ButtonStates{
+						Default: StateStyle{Text: palette.PrimaryOn, Bg: palette.Primary},
+						Hover:   StateStyle{Text: palette.PrimaryOn, Bg: "4"},
+					}},
+					ButtonSecondary: {States: ButtonStates{
+						Default: StateStyle{Text: palette.Text, Bg: palette.Surface},
+					}},
+					ButtonGhost: {NoBorder: true, States: ButtonStates{
+						Default: StateStyle{Text: palette.Text},
+						Hover:   StateStyle{Text: palette.Text, Bg: palette.Surface},
+					}},
+					ButtonDanger: {States: ButtonStates{
+						Default: StateStyle{Text: palette.PrimaryOn, Bg: palette.Danger},
+					}},
+				},
+			},
+			Input: InputTokens{
+				Padding:     1,
+				Border:      tui.NormalBorder,
+				BorderColor: "8",
+				Placeholder: StateStyle{Text: palette.TextMuted, Effect: EffectDim},
+				Caret:       palette.Primary,
+				Suggestion:  StateStyle{Text: palette.TextMuted},
+				HelpText:    palette.TextMuted,
+				States: InputStates{
+					Default:  StateStyle{Text: palette.Text, Bg: palette.Bg},
+					Focus:    StateStyle{Border: "#60A5FA", Effect: EffectNone},
+					Disabled: StateStyle{Text: palette.TextMuted, Bg: palette.Surface},
+					Error:    StateStyle{Border: palette.Danger, Text: palette.Danger},
+				},
+			},
+			List: ListTokens{
+				Padding: 0,
+				Item: ListItemStates{
+					Normal:   StateStyle{Text: palette.Text},
+					Muted:    StateStyle{Text: palette.TextMuted},
+					Selected: StateStyle{Text: palette.Text, Bg: palette.SurfaceAlt, Effect: EffectReverse},
+					Match:    StateStyle{Text: palette.Secondary, Effect: EffectUnderline},
+				},
+				Focus: StateStyle{Border: "#60A5FA"},
+				Scrollbar: ScrollbarTokens{
+					Enabled:    true,
+					Track:      "░",
+					TrackColor: "8",
+					Thumb:      "▒",
+					ThumbColor: "12",
+				},
+			},
+			Table: TableTokens{
+				Rounded:     true,
+				BorderColor: "8",
+				Header:      StateStyle{Text: palette.Secondary},
+				Row: TableRowStates{
+					Normal:    StateStyle{Text: palette.Text},
+					Highlight: StateStyle{Text: palette.Text, Bg: palette.SurfaceAlt, Effect: EffectReverse},
+					Muted:     StateStyle{Text: palette.TextMuted},
+				},
+				Footer:   StateStyle{Text: palette.TextMuted},
+				SortAsc:  "▲",
+				SortDesc: "▼",
+			},
+			Checkbox: CheckboxTokens{
+				Unchecked:      Glyph{Unicode: "☐", ASCII: "[ ]"},
+				Checked:        Glyph{Unicode: "☑", ASCII: "[x]"},
+				Mixed:          Glyph{Unicode: "◩", ASCII: "[-]"},
+				UncheckedColor: palette.Text,
+				CheckedColor:   palette.Primary,
+				MixedColor:     palette.Warning,
+				Indent:         4,
+			},
+			Radio: RadioTokens{
+				Off:      Glyph{Unicode: "( )", ASCII: "( )"},
+				On:       Glyph{Unicode: "(•)", ASCII: "(*)"},
+				OffColor: palette.Text,
+				OnColor:  palette.Primary,
+			},
+			CardBox: CardBoxTokens{
+				Bg:        palette.SurfaceAlt,
+				Border:    borders.Thin,
+				Padding:   2,
+				Elevation: ElevationLow,
+				Title:     TextH5,
+			},
+			Divider: DividerTokens{Border: borders.Thin},
+			Spacer:  SpacerTokens{Size: 1},
+			Progress: ProgressTokens{
+				Filled:      Glyph{Unicode: "█", ASCII: "#"},
+				Empty:       Glyph{Unicode: "░", ASCII: "-"},
+				FilledColor: palette.Primary,
+				EmptyColor:  "8",
+				Pulse:       4,
+				ShowPercent: true,
+			},
+			Spinner: SpinnerTokens{
+				Frames:      []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+				ASCIIFrames: []string{"|", "/", "-", "\\"},
+				Interval:    100 * time.Millisecond,
+				Color:       palette.Primary,
+			},
+			TaskPanel: TaskPanelTokens{
+				TitleWidth: 24,
+				Pending:    TaskStateTokens{Glyph: Glyph{Unicode: "○", ASCII: "."}, Color: palette.TextMuted},
+				Done:       TaskStateTokens{Glyph: Glyph{Unicode: "✓", ASCII: "v"}, Color: palette.Success},
+				Failed:     TaskStateTokens{Glyph: Glyph{Unicode: "✗", ASCII: "x"}, Color: palette.Danger},
+				Skipped:    TaskStateTokens{Glyph: Glyph{Unicode: "–", ASCII: "-"}, Color: palette.TextMuted},
+				Output:     StateStyle{Text: palette.TextMuted},
+			},
+			NavBar: NavBarTokens{
+				Padding:     1,
+				Tab:         StateStyle{Text: palette.TextMuted},
+				Active:      StateStyle{Text: palette.PrimaryOn, Bg: palette.Primary},
+				Focus:       StateStyle{Text: palette.PrimaryOn, Bg: palette.Primary, Effect: EffectUnderline},
+				Badge:       StateStyle{Text: palette.Warning},
+				ScrollLeft:  Glyph{Unicode: "‹", ASCII: "<"},
+				ScrollRight: Glyph{Unicode: "›", ASCII: ">"},
+			},
+		},
+	}
+}
+
+var (
+	currentMu sync.RWMutex
+	current   = Default()
+)
+
+// Current returns the theme loaded for the application.
+func Current() *Theme {
+	currentMu.RLock()
+	defer currentMu.RUnlock()
+	return current
+}
+
+// SetCurrent replaces the application theme. A nil theme restores Default.
+// Cached views are dropped, so the next frame uses the new theme.
+func SetCurrent(theme *Theme) {
+	if theme == nil {
+		theme = Default()
+	}
+	currentMu.Lock()
+	current = theme
+	currentMu.Unlock()
+	tui.InvalidateViews()
+}
@@ -0,0 +1,18 @@
+// Package navigation holds the events widgets use to announce where the
+// user is going, so bars and menus can follow along without knowing about
+// each other.
+package navigation
+
+import "github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+
+// Route is a destination of the application, e.g. the tools screen.
+type Route struct {
+	// ID is the stable identifier screens switch on, e.g. "tools".
+	ID string
+	// Title is the human readable name shown in bars and menus.
+	Title string
+}
+
+// Navigated is published when the user picks a route, e.g. from the side
+// menu.
+var Navigated = tui.NewTopic[Route]("navigation")
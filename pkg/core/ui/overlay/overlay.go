@@ -0,0 +1,244 @@
+// Package overlay holds the layer of modals, dialogs and popovers drawn on
+// top of the Shell's active screen.
+//
+// Screens never own overlays directly: they return Open with the overlay's
+// model, and the Shell pushes it on its Stack. While an overlay is open it
+// captures the input, and it is removed when it returns Close.
+package overlay
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Placement is where an overlay is drawn on screen.
+type Placement int
+
+const (
+	// Center centers the overlay on screen.
+	Center Placement = iota
+	// Anchored puts the overlay's top-left corner at the anchor set with
+	// WithAnchor, moved back on screen if it would not fit.
+	Anchored
+	TopLeft
+	TopRight
+	BottomLeft
+	BottomRight
+)
+
+// Option is a functional option for configuring an Overlay.
+type Option func(*Overlay)
+
+// Overlay is a model drawn on top of the current frame.
+type Overlay struct {
+	model     tui.Model
+	placement Placement
+	anchorX   int
+	anchorY   int
+	scrim     bool
+}
+
+// --- Functional Options ---
+
+// WithPlacement sets where the overlay is drawn. Defaults to Center.
+func WithPlacement(p Placement) Option {
+	return func(o *Overlay) { o.placement = p }
+}
+
+// WithAnchor places the overlay's top-left corner at (x, y), e.g. just below
+// the widget that opened it (see tui.Zones.Get).
+func WithAnchor(x, y int) Option {
+	return func(o *Overlay) {
+		o.placement = Anchored
+		o.anchorX, o.anchorY = x, y
+	}
+}
+
+// WithScrim dims everything below the overlay with the theme's overlay
+// scrim color. Modals use it; popovers usually don't.
+func WithScrim(scrim bool) Option {
+	return func(o *Overlay) { o.scrim = scrim }
+}
+
+// --- Messages ---
+
+// OpenMsg asks the Shell to push an overlay.
+type OpenMsg struct {
+	Overlay *Overlay
+}
+
+// CloseMsg asks the Shell to remove the overlay showing Model. Models that
+// implement tui.Disposable are disposed.
+type CloseMsg struct {
+	Model tui.Model
+}
+
+// Open returns a command that opens model as an overlay. The overlay's Init
+// command runs once it is pushed.
+func Open(model tui.Model, opts ...Option) tui.Cmd {
+	o := &Overlay{model: model}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return func() tui.Msg { return OpenMsg{Overlay: o} }
+}
+
+// Close returns a command that removes the overlay showing model. Overlays
+// return it themselves once they are done.
+func Close(model tui.Model) tui.Cmd {
+	return func() tui.Msg { return CloseMsg{Model: model} }
+}
+
+// --- Stack ---
+
+// Stack is the ordered set of open overlays; the last one is on top.
+type Stack struct {
+	overlays      []*Overlay
+	width, height int
+}
+
+// NewStack creates an empty stack.
+func NewStack() *Stack {
+	return &Stack{}
+}
+
+// Len returns the number of open overlays.
+func (s *Stack) Len() int {
+	return len(s.overlays)
+}
+
+// Top returns the model of the topmost overlay, or nil.
+func (s *Stack) Top() tui.Model {
+	if len(s.overlays) == 0 {
+		return nil
+	}
+	return s.overlays[len(s.overlays)-1].model
+}
+
+// Update handles the overlay messages and routes msg to the overlays.
+// Input (keys, paste and mouse) only reaches the topmost overlay and is
+// reported as handled, so the screen below never sees it. Other messages
+// are delivered to every overlay and reported as not handled, so the
+// caller still passes them on to the screen.
+func (s *Stack) Update(msg tui.Msg) (bool, tui.Cmd) {
+	switch msg := msg.(type) {
+	case OpenMsg:
+		s.overlays = append(s.overlays, msg.Overlay)
+		cmd := msg.Overlay.model.Init()
+		if s.width > 0 || s.height > 0 {
+			cmd = tui.Batch(cmd, s.updateAt(len(s.overlays)-1, tui.WindowSizeMsg{Width: s.width, Height: s.height}))
+		}
+		return true, cmd
+
+	case CloseMsg:
+		for i, o := range s.overlays {
+			if o.model == msg.Model {
+				s.overlays = append(s.overlays[:i], s.overlays[i+1:]...)
+				if d, ok := o.model.(tui.Disposable); ok {
+					d.Dispose()
+				}
+				break
+			}
+		}
+		return true, nil
+
+	case tui.WindowSizeMsg:
+		s.width, s.height = msg.Width, msg.Height
+	}
+
+	if len(s.overlays) == 0 {
+		return false, nil
+	}
+
+	if isInput(msg) {
+		return true, s.updateAt(len(s.overlays)-1, msg)
+	}
+
+	cmds := make([]tui.Cmd, len(s.overlays))
+	for i := range s.overlays {
+		cmds[i] = s.updateAt(i, msg)
+	}
+	return false, tui.Batch(cmds...)
+}
+
+// View draws the overlays, bottom first, over background. background is
+// expected to fill the stack's size.
+func (s *Stack) View(background string) string {
+	if len(s.overlays) == 0 {
+		return background
+	}
+
+	// The frame below the overlays cannot be clicked.
+	frame := tui.StripZones(background)
+	for _, o := range s.overlays {
+		if o.scrim {
+			frame = s.dim(frame)
+		}
+		view := o.model.View()
+		x, y := s.position(o, view)
+		frame = tui.PlaceOverlay(x, y, view, frame)
+	}
+	return frame
+}
+
+// AccessibleView returns background followed by the text of each open
+// overlay, bottom first, separated by blank lines.
+func (s *Stack) AccessibleView(background string) string {
+	sections := []string{background}
+	for _, o := range s.overlays {
+		if text := tui.AccessibleText(o.model); text != "" {
+			sections = append(sections, text)
+		}
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+// --- Internals ---
+
+func (s *Stack) updateAt(i int, msg tui.Msg) tui.Cmd {
+	model, cmd := s.overlays[i].model.Update(msg)
+	s.overlays[i].model = model
+	return cmd
+}
+
+// position returns the top-left corner of view for the overlay's placement.
+func (s *Stack) position(o *Overlay, view string) (int, int) {
+	w, h := tui.Width(view), strings.Count(view, "\n")+1
+	maxX, maxY := max(s.width-w, 0), max(s.height-h, 0)
+
+	switch o.placement {
+	case Anchored:
+		return min(max(o.anchorX, 0), maxX), min(max(o.anchorY, 0), maxY)
+	case TopLeft:
+		return 0, 0
+	case TopRight:
+		return maxX, 0
+	case BottomLeft:
+		return 0, maxY
+	case BottomRight:
+		return maxX, maxY
+	default:
+		return maxX / 2, maxY / 2
+	}
+}
+
+// dim repaints frame as faint text on the theme's scrim color.
+func (s *Stack) dim(frame string) string {
+	palette := designsystem.Current().Palette
+	scrim := tui.NewStyle().
+		Faint(true).
+		Foreground(palette.TextMuted).
+		Background(palette.OverlayScrim).
+		Width(s.width)
+	return scrim.Render(ansi.Strip(frame))
+}
+
+// isInput reports whether msg comes from the user.
+func isInput(msg tui.Msg) bool {
+	_, mouse := msg.(tui.MouseMsg)
+	return mouse || tui.IsInputMsg(msg)
+}
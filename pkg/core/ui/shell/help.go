@@ -0,0 +1,51 @@
+package shell
+
+import (
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/overlay"
+)
+
+// Ensure helpOverlay implements the tui.Model interface.
+var _ tui.Model = (*helpOverlay)(nil)
+
+// helpScope is the keymap scope of the help overlay.
+const helpScope = "help"
+
+var actionCloseHelp = tui.Keys.Register(helpScope, "help.close", "Close help", "esc", "?", "q")
+
+// helpOverlay lists the key bindings active for the scopes it was opened
+// with.
+type helpOverlay struct {
+	scopes []string
+}
+
+// openHelp returns the command that shows the bindings of scopes.
+func openHelp(scopes []string) tui.Cmd {
+	return overlay.Open(&helpOverlay{scopes: scopes}, overlay.WithScrim(true))
+}
+
+func (h *helpOverlay) Init() tui.Cmd {
+	return nil
+}
+
+func (h *helpOverlay) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if key, ok := msg.(tui.KeyMsg); ok && tui.Keys.Matches(key, actionCloseHelp) {
+		return h, overlay.Close(h)
+	}
+	return h, nil
+}
+
+func (h *helpOverlay) View() string {
+	palette := designsystem.Current().Palette
+
+	title := tui.NewStyle().Bold(true).Foreground(palette.Primary).Render("Key bindings")
+	body := tui.NewStyle().Foreground(palette.Text).Render(tui.Keys.HelpView(h.scopes...))
+
+	return tui.NewStyle().
+		Border(tui.RoundedBorder).
+		BorderForeground(palette.Primary).
+		Background(palette.Surface).
+		Padding(0, 1).
+		Render(tui.JoinVertical(tui.Left, title, "", body))
+}
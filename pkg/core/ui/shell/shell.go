@@ -3,17 +3,36 @@ package shell
 
 import (
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/overlay"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/viewbox"
 	"github.com/DippingCode/easyenv/pkg/modules/home/presenter"
 )
 
 // Ensure Shell implements the tui.Model interface.
 var _ tui.Model = (*Shell)(nil)
+var _ tui.KeyScoper = (*Shell)(nil)
+var _ tui.Accessible = (*Shell)(nil)
+var _ tui.Disposable = (*Shell)(nil)
+
+// keyScope is the keymap scope of the Shell's own bindings.
+const keyScope = "shell"
+
+var (
+	actionQuitTwice = tui.Keys.Register(keyScope, "shell.quit_twice", "Quit (press twice)", "esc")
+	actionHelp      = tui.Keys.Register(keyScope, "shell.help", "Show key bindings", "?")
+)
+
+func init() {
+	// The Shell's keys surround every screen and widget.
+	tui.Keys.AddOuterScopes(keyScope)
+}
 
 // Shell is the root model of the application.
-// It holds the active screen (ViewBox) and handles global commands.
+// It holds the active screen (ViewBox), the overlays drawn on top of it and
+// handles global commands.
 type Shell struct {
 	activeViewBox  viewbox.ViewBox
+	overlays       *overlay.Stack
 	escPressedOnce bool
 }
 
@@ -21,6 +40,7 @@ type Shell struct {
 func New() *Shell {
 	return &Shell{
 		activeViewBox:  presenter.New(),
+		overlays:       overlay.NewStack(),
 		escPressedOnce: false,
 	}
 }
@@ -31,19 +51,33 @@ func (s *Shell) Init() tui.Cmd {
 }
 
 func (s *Shell) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
-	switch msg := msg.(type) {
-	case tui.KeyMsg:
-		switch msg.Type {
-		case tui.KeyCtrlC:
-			return s, tui.Quit
+	if key, ok := msg.(tui.KeyMsg); ok && tui.Keys.Matches(key, tui.ActionQuit) {
+		return s, tui.Quit
+	}
 
-		case tui.KeyEsc:
+	// Open overlays capture the input; other messages reach them and then
+	// the active screen.
+	handled, overlayCmd := s.overlays.Update(msg)
+	if handled {
+		s.escPressedOnce = false
+		return s, overlayCmd
+	}
+
+	// The screen gets first claim on keys, so "?" can be typed into an input
+	// and Esc clears a filter; the Shell's bindings apply to the rest.
+	if key, ok := msg.(tui.KeyMsg); ok && !tui.HandlesKey(s.activeViewBox, key) {
+		switch {
+		case tui.Keys.Matches(key, actionQuitTwice):
 			if s.escPressedOnce {
 				return s, tui.Quit
 			}
 			s.escPressedOnce = true
 			// We don't return a command here, just wait for the next message.
 			return s, nil
+
+		case tui.Keys.Matches(key, actionHelp):
+			s.escPressedOnce = false
+			return s, openHelp(s.KeyScopes())
 		}
 	}
 
@@ -52,11 +86,46 @@ func (s *Shell) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 	// Delegate the message to the active screen.
 	// This will also cause a compile error until viewbox.ViewBox is updated.
 	newViewBox, cmd := s.activeViewBox.Update(msg)
-	s.activeViewBox = newViewBox.(viewbox.ViewBox)
-	return s, cmd
+	s.setViewBox(newViewBox.(viewbox.ViewBox))
+	return s, tui.Batch(overlayCmd, cmd)
+}
+
+// setViewBox makes v the active screen, disposing the screen it replaces.
+func (s *Shell) setViewBox(v viewbox.ViewBox) {
+	if v == s.activeViewBox {
+		return
+	}
+	if d, ok := s.activeViewBox.(tui.Disposable); ok {
+		d.Dispose()
+	}
+	s.activeViewBox = v
+}
+
+// Dispose disposes the active screen once the Shell has exited.
+func (s *Shell) Dispose() {
+	if d, ok := s.activeViewBox.(tui.Disposable); ok {
+		d.Dispose()
+	}
+}
+
+// KeyScopes returns the scopes of the active screen followed by the
+// Shell's own.
+func (s *Shell) KeyScopes() []string {
+	var scopes []string
+	if scoper, ok := s.activeViewBox.(tui.KeyScoper); ok {
+		scopes = scoper.KeyScopes()
+	}
+	return append(scopes, keyScope)
 }
 
 func (s *Shell) View() string {
-	// The shell's view is simply the view of the active screen.
-	return s.activeViewBox.View()
-}
\ No newline at end of file
+	// The shell's view is the view of the active screen with the open
+	// overlays drawn on top.
+	return s.overlays.View(s.activeViewBox.View())
+}
+
+// AccessibleView returns the active screen and the open overlays as plain
+// text, for the non-interactive renderer.
+func (s *Shell) AccessibleView() string {
+	return s.overlays.AccessibleView(tui.AccessibleText(s.activeViewBox))
+}
@@ -0,0 +1,219 @@
+package shell
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/overlay"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/dialog"
+)
+
+func TestShellRendersHome(t *testing.T) {
+	d := tuitest.New(t, New(), tuitest.WithSize(60, 20))
+	d.Golden("initial")
+
+	d.Resize(80, 24)
+	d.Golden("resized")
+}
+
+func TestShellQuit(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tui.KeyType
+		quit bool
+	}{
+		{"ctrl+c", []tui.KeyType{tui.KeyCtrlC}, true},
+		{"double esc", []tui.KeyType{tui.KeyEsc, tui.KeyEsc}, true},
+		{"single esc", []tui.KeyType{tui.KeyEsc}, false},
+		{"esc interrupted", []tui.KeyType{tui.KeyEsc, tui.KeyDown, tui.KeyEsc}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tuitest.New(t, New(), tuitest.WithSize(60, 20))
+			d.Press(tt.keys...)
+			if d.Quit() != tt.quit {
+				t.Errorf("Quit() = %v, want %v", d.Quit(), tt.quit)
+			}
+		})
+	}
+}
+
+func TestShellOverlayCapturesInput(t *testing.T) {
+	d := tuitest.New(t, New(), tuitest.WithSize(60, 20))
+	home := d.Frame()
+
+	confirm := dialog.NewConfirm("Install 12 tools?", dialog.WithTitle("Install"))
+	d.Send(confirm.Open()())
+	d.Golden("confirm")
+
+	// Esc answers the dialog instead of arming the double-esc quit.
+	d.Press(tui.KeyEsc, tui.KeyEsc)
+	if d.Quit() {
+		t.Fatal("esc inside a dialog quit the shell")
+	}
+
+	var result *dialog.ConfirmResultMsg
+	for _, msg := range d.Messages() {
+		if r, ok := msg.(dialog.ConfirmResultMsg); ok {
+			result = &r
+		}
+	}
+	if result == nil || result.ID != confirm.ID() || result.Confirmed {
+		t.Fatalf("result = %+v, want a declined answer for dialog %d", result, confirm.ID())
+	}
+	if d.Frame() != home {
+		t.Errorf("frame after closing the dialog:\n%s\nwant\n%s", d.Frame(), home)
+	}
+}
+
+func TestShellOverlayPlacement(t *testing.T) {
+	d := tuitest.New(t, New(), tuitest.WithSize(60, 20))
+	d.Send(dialog.NewAlert("Done").Open()())
+	d.Send(overlay.Open(popover{}, overlay.WithPlacement(overlay.BottomRight))())
+	d.Golden("stacked")
+}
+
+// popover is a minimal overlay model.
+type popover struct{}
+
+func (popover) Init() tui.Cmd                         { return nil }
+func (p popover) Update(tui.Msg) (tui.Model, tui.Cmd) { return p, nil }
+func (popover) View() string                          { return "+------+\n| tip  |\n+------+" }
+
+func TestShellHelpListsActiveBindings(t *testing.T) {
+	d := tuitest.New(t, New(), tuitest.WithSize(60, 20))
+	d.Type("?")
+	d.Golden("help")
+
+	d.Type("q")
+	if d.Quit() {
+		t.Fatal("closing the help quit the shell")
+	}
+	if strings.Contains(d.Frame(), "Key bindings") {
+		t.Error("help still shown after q")
+	}
+}
+
+// typingScreen claims every key but Ctrl+C, as a screen whose focused
+// widget is a text input does.
+type typingScreen struct {
+	keys []string
+}
+
+func (s *typingScreen) Init() tui.Cmd { return nil }
+
+func (s *typingScreen) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if key, ok := msg.(tui.KeyMsg); ok {
+		s.keys = append(s.keys, key.String())
+	}
+	return s, nil
+}
+
+func (s *typingScreen) View() string { return "" }
+
+func (s *typingScreen) HandlesKey(msg tui.KeyMsg) bool {
+	return msg.Type != tui.KeyCtrlC
+}
+
+func TestShellOffersKeysToScreen(t *testing.T) {
+	screen := &typingScreen{}
+	s := New()
+	s.activeViewBox = screen
+	d := tuitest.New(t, s, tuitest.WithSize(60, 20))
+
+	d.Type("?")
+	d.Press(tui.KeyEsc, tui.KeyEsc)
+	if d.Quit() {
+		t.Fatal("esc quit while the screen was using it")
+	}
+	if s.overlays.Len() != 0 {
+		t.Fatal("? opened the help while the screen was using it")
+	}
+	if got := strings.Join(screen.keys, " "); got != "? esc esc" {
+		t.Errorf("screen got %q, want \"? esc esc\"", got)
+	}
+
+	d.Press(tui.KeyCtrlC)
+	if !d.Quit() {
+		t.Error("ctrl+c did not quit")
+	}
+}
+
+func TestShellQuitIsRebindable(t *testing.T) {
+	t.Cleanup(tui.Keys.Reset)
+	if err := tui.Keys.Load(map[string][]string{"app.quit": {"ctrl+q"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	d := tuitest.New(t, New(), tuitest.WithSize(60, 20))
+	d.Press(tui.KeyCtrlC)
+	if d.Quit() {
+		t.Fatal("ctrl+c still quits after rebinding")
+	}
+	d.Send(tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune("q"), Ctrl: true})
+	if !d.Quit() {
+		t.Error("ctrl+q does not quit")
+	}
+}
+
+func TestShellPlainTranscript(t *testing.T) {
+	s := New()
+	// Navigate from the side menu, then open and answer a confirm dialog.
+	confirm := dialog.NewConfirm("Install 12 tools?", dialog.WithTitle("Install"))
+	in := strings.NewReader("shift+tab\ndown\nenter\n")
+	var out strings.Builder
+
+	model, err := tui.RunPlain(context.Background(), s, in, &out)
+	if err != nil {
+		t.Fatalf("RunPlain: %v", err)
+	}
+
+	model.Update(confirm.Open()())
+	out.WriteString("\n" + tui.AccessibleText(model) + "\n")
+	tuitest.AssertGolden(t, "transcript", out.String())
+}
+
+// nextScreen replaces itself with next on any message, as a screen that
+// navigates away does, and records its disposal.
+type nextScreen struct {
+	next     tui.Model
+	disposed bool
+}
+
+func (s *nextScreen) Init() tui.Cmd { return nil }
+
+func (s *nextScreen) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if s.next != nil {
+		return s.next, nil
+	}
+	return s, nil
+}
+
+func (s *nextScreen) View() string { return "" }
+
+func (s *nextScreen) Dispose() { s.disposed = true }
+
+func TestShellDisposesScreens(t *testing.T) {
+	second := &nextScreen{}
+	first := &nextScreen{next: second}
+	s := New()
+	s.Dispose() // the home screen
+	s.activeViewBox = first
+
+	s.Update(tui.WindowSizeMsg{Width: 60, Height: 20})
+	if !first.disposed {
+		t.Error("the replaced screen was not disposed")
+	}
+	if second.disposed {
+		t.Fatal("the active screen was disposed")
+	}
+
+	s.Dispose()
+	if !second.disposed {
+		t.Error("Dispose did not dispose the active screen")
+	}
+}
@@ -5,40 +5,52 @@ package stage
 import (
 	"strings"
 
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
 )
 
 // Um Stage minimalista: tela em branco com BG do terminal.
-// Teclas: q/ctrl+c para sair. Resize ajusta o "palco".
+// Teclas: q/esc (ou tui.ActionQuit) para sair. Resize ajusta o "palco".
+
+// keyScope is the keymap scope of the Stage's bindings.
+const keyScope = "stage"
+
+var actionQuit = tui.Keys.Register(keyScope, "stage.quit", "Quit", "q", "esc")
+
+// Ensure Model implements the tui.Model and tui.KeyScoper interfaces.
+var _ tui.Model = Model{}
+var _ tui.KeyScoper = Model{}
 
 type Model struct {
 	width, height int
 }
 
-func New() tea.Model { return Model{} }
+func New() tui.Model { return Model{} }
 
-func (m Model) Init() tea.Cmd { return nil }
+func (m Model) Init() tui.Cmd { return nil }
 
-func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+func (m Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
+	case tui.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c", "esc":
-			return m, tea.Quit
+	case tui.KeyMsg:
+		if tui.Keys.Matches(msg, actionQuit) || tui.Keys.Matches(msg, tui.ActionQuit) {
+			return m, tui.Quit
 		}
 	}
 	return m, nil
 }
 
+// KeyScopes returns the Stage's key binding scope.
+func (m Model) KeyScopes() []string {
+	return []string{keyScope}
+}
+
 func (m Model) View() string {
 	if m.width <= 0 || m.height <= 0 {
 		return ""
 	}
 	blank := strings.Repeat(" ", max(0, m.width))
-	line := lipgloss.NewStyle().Render(blank)
+	line := tui.NewStyle().Render(blank)
 	var b strings.Builder
 	for i := 0; i < m.height; i++ {
 		if i > 0 {
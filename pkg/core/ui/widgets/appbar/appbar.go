@@ -1,12 +1,18 @@
 package appbar
 
 import (
+	"strings"
+
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
 )
 
 // Ensure Model implements the tui.Model and tui.Layout interfaces.
 var _ tui.Model = (*Model)(nil)
 var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
 
 // Option is a functional option for configuring the AppBar.
 type Option func(*Model)
@@ -14,11 +20,19 @@ type Option func(*Model)
 type Model struct {
 	width, height int
 	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+	tui.Subscriptions
 	// Components
 	leading tui.Model
 	title   tui.Model
 	actions []tui.Model
 
+	// heading is the title of the current route, shown when no title
+	// component is set.
+	nav     *tui.Subscription[navigation.Route]
+	heading string
+
 	// Styling
 	style tui.Style
 }
@@ -28,6 +42,7 @@ func New(opts ...Option) *Model {
 	m := &Model{
 		style: tui.NewStyle(),
 	}
+	m.nav = tui.Subscribe(&m.Subscriptions, navigation.Navigated)
 
 	for _, opt := range opts {
 		opt(m)
@@ -88,6 +103,11 @@ func (m *Model) Init() tui.Cmd {
 func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 	var cmds []tui.Cmd
 
+	if route, ok := m.nav.Receive(msg); ok {
+		m.heading = route.Title
+		m.MarkDirty()
+	}
+
 	switch msg := msg.(type) {
 	case tui.WindowSizeMsg:
 		// Calculate available space from parent
@@ -108,6 +128,7 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 		}
 
 		// No longer subtracting GetFrameSize here. The style's Width/Height will handle it.
+		return m, m.resizeChildren()
 	}
 
 	// Propagate updates to children
@@ -130,6 +151,35 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 	return m, tui.Batch(cmds...)
 }
 
+// resizeChildren sizes the children to the bar's content area. The title
+// gets the width the leading and action components leave, so text that
+// wraps or truncates stays clear of the actions.
+func (m *Model) resizeChildren() tui.Cmd {
+	var cmds []tui.Cmd
+	hFrame, vFrame := m.style.GetFrameSize()
+	area := tui.WindowSizeMsg{Width: max(m.width-hFrame, 0), Height: max(m.height-vFrame, 0)}
+
+	used := 0
+	if m.leading != nil {
+		newModel, cmd := m.leading.Update(area)
+		m.leading = newModel
+		cmds = append(cmds, cmd)
+		used += tui.Width(m.leading.View())
+	}
+	for i, action := range m.actions {
+		newModel, cmd := action.Update(area)
+		m.actions[i] = newModel
+		cmds = append(cmds, cmd)
+		used += tui.Width(m.actions[i].View())
+	}
+	if m.title != nil {
+		newModel, cmd := m.title.Update(tui.WindowSizeMsg{Width: max(area.Width-used, 0), Height: area.Height})
+		m.title = newModel
+		cmds = append(cmds, cmd)
+	}
+	return tui.Batch(cmds...)
+}
+
 func (m *Model) View() string {
 	var leadingView, titleView string
 	var actionsView []string
@@ -142,6 +192,8 @@ func (m *Model) View() string {
 
 	if m.title != nil {
 		titleView = m.title.View()
+	} else if m.heading != "" {
+		titleView = tui.NewStyle().Bold(true).Render(m.heading)
 	}
 
 	actionsWidth := 0
@@ -150,12 +202,29 @@ func (m *Model) View() string {
 		actionsWidth += tui.Width(view) // Use tui.Width
 		actionsView = append(actionsView, view)
 	}
+	parts := append([]string{leadingView, titleView}, actionsView...)
+	key := tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused(), Parts: parts}
+	return m.CachedView(key, func() string {
+		return m.render(leadingView, leadingWidth, titleView, actionsView, actionsWidth)
+	})
+}
+
+// render lays out the children's views in the bar; View caches the result.
+func (m *Model) render(leadingView string, leadingWidth int, titleView string, actionsView []string, actionsWidth int) string {
 	actionsCombined := tui.JoinHorizontal(tui.Center, actionsView...)
 
 	hFrame, vFrame := m.style.GetFrameSize()
-	remainingWidth := m.width - leadingWidth - actionsWidth - hFrame // Use hFrame
 
-	spacer := tui.NewStyle().Width(remainingWidth).Render("")
+	// Leading and title sit on the left, actions on the right; the spacer
+	// between them takes whatever width is left.
+	slots := tui.NewFlex(tui.Row).Add(
+		tui.Fixed(leadingWidth),
+		tui.Fixed(tui.Width(titleView)),
+		tui.Grow(1),
+		tui.Fixed(actionsWidth),
+	).Arrange(tui.Rect{Width: m.width - hFrame, Height: 1})
+
+	spacer := tui.NewStyle().Width(slots[2].Width).Render("")
 
 	content := tui.JoinHorizontal(
 		tui.Center,
@@ -169,29 +238,53 @@ func (m *Model) View() string {
 	contentWidth := m.width - hFrame
 	contentHeight := m.height - vFrame
 
+	style := designsystem.FocusBorder(m.style, m.Focused())
+
 	// Render the content with the calculated content dimensions
-	return m.style.Width(contentWidth).Height(contentHeight).Render(content)
+	return style.Width(contentWidth).Height(contentHeight).Render(content)
+}
+
+// AccessibleView returns the bar's leading, title and actions as a single
+// line of text.
+func (m *Model) AccessibleView() string {
+	var parts []string
+	for _, c := range append([]tui.Model{m.leading, m.title}, m.actions...) {
+		if c == nil {
+			continue
+		}
+		if text := tui.AccessibleText(c); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	if m.title == nil && m.heading != "" {
+		parts = append(parts, m.heading)
+	}
+	return strings.Join(parts, " ")
 }
 
 // --- layout.Layout Implementation ---
 
 func (m *Model) BackgroundColor(color string) tui.Layout {
-	m.style.Background(color)
+	m.style = m.style.Background(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
-	m.style.Border(border, sides...)
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) BorderForeground(color string) tui.Layout {
-	m.style.BorderForeground(color)
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Padding(p ...int) tui.Layout {
-	m.style.Padding(p...)
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
 	return m
 }
 
@@ -208,6 +301,7 @@ func (m *Model) Height(height int) tui.Layout {
 }
 
 func (m *Model) Align(pos tui.Position) tui.Layout {
-	m.style.Align(pos)
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
 	return m
 }
@@ -2,11 +2,15 @@ package bottombar
 
 import (
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
 )
 
 // Ensure Model implements the tui.Model and tui.Layout interfaces.
 var _ tui.Model = (*Model)(nil)
 var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
 
 // Option is a functional option for configuring the BottomBar.
 type Option func(*Model)
@@ -14,14 +18,23 @@ type Option func(*Model)
 type Model struct {
 	width, height int
 	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+	tui.Subscriptions
 	style tui.Style
+
+	// status is the text shown in the bar; it follows navigation.
+	status string
+	nav    *tui.Subscription[navigation.Route]
 }
 
 // New creates a new BottomBar with the given options.
 func New(opts ...Option) *Model {
 	m := &Model{
-		style: tui.NewStyle(),
+		style:  tui.NewStyle(),
+		status: "BottomBar",
 	}
+	m.nav = tui.Subscribe(&m.Subscriptions, navigation.Navigated)
 
 	for _, opt := range opts {
 		opt(m)
@@ -56,6 +69,11 @@ func WithHeight(height int) Option {
 	return func(m *Model) { m.Height(height) }
 }
 
+// WithStatus sets the initial status text.
+func WithStatus(status string) Option {
+	return func(m *Model) { m.status = status }
+}
+
 func WithAlign(pos tui.Position) Option {
 	return func(m *Model) { m.Align(pos) }
 }
@@ -67,6 +85,11 @@ func (m *Model) Init() tui.Cmd {
 }
 
 func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if route, ok := m.nav.Receive(msg); ok {
+		m.status = route.Title
+		m.MarkDirty()
+	}
+
 	switch msg := msg.(type) {
 	case tui.WindowSizeMsg:
 		// Calculate available space from parent
@@ -92,34 +115,50 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 }
 
 func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// render draws the slot; View caches the result.
+func (m *Model) render() string {
 	// Calculate content dimensions based on total width/height and frame size
 	hFrame, vFrame := m.style.GetFrameSize()
 	contentWidth := m.width - hFrame
 	contentHeight := m.height - vFrame
 
+	style := designsystem.FocusBorder(m.style, m.Focused())
+
 	// Render the content with the calculated content dimensions
-	return m.style.Width(contentWidth).Height(contentHeight).Render("BottomBar")
+	return style.Width(contentWidth).Height(contentHeight).Render(m.status)
+}
+
+// AccessibleView returns the status text.
+func (m *Model) AccessibleView() string {
+	return m.status
 }
 
 // --- tui.Layout Implementation ---
 
 func (m *Model) BackgroundColor(color string) tui.Layout {
-	m.style.Background(color)
+	m.style = m.style.Background(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
-	m.style.Border(border, sides...)
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) BorderForeground(color string) tui.Layout {
-	m.style.BorderForeground(color)
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Padding(p ...int) tui.Layout {
-	m.style.Padding(p...)
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
 	return m
 }
 
@@ -136,6 +175,7 @@ func (m *Model) Height(height int) tui.Layout {
 }
 
 func (m *Model) Align(pos tui.Position) tui.Layout {
-	m.style.Align(pos)
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
 	return m
 }
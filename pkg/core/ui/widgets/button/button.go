@@ -0,0 +1,359 @@
+// Package button provides the Button and ToggleButton widgets.
+//
+// A button runs its click command when it is clicked, when Enter or Space
+// is pressed while it has focus, or when Alt and its access key are
+// pressed; the access key is marked with "&" in the label:
+//
+//	install := button.New("&Install", button.WithVariant(designsystem.ButtonPrimary))
+//	install.SetOnClick(func() tui.Cmd { return m.install() })
+//
+// Its look comes from the theme's Button tokens, by variant and state
+// (default, hover, focus, active and disabled).
+package button
+
+import (
+	"time"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Clickable interface.
+var _ tui.Clickable = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// keyScope is the keymap scope of the button actions.
+const keyScope = "button"
+
+// actionPress presses the focused button.
+var actionPress = tui.Keys.Register(keyScope, "button.press", "Press", "enter", "space")
+
+// activeFor is how long a button pressed from the keyboard looks active.
+const activeFor = 120 * time.Millisecond
+
+// Option is a functional option for configuring the Button.
+type Option func(*Model)
+
+// Model is a button. NewToggle makes it a ToggleButton, which flips between
+// on and off each time it is pressed.
+type Model struct {
+	width, height int // 0 means sized to the label
+	tui.FocusState
+	tui.ViewCache
+	tui.ClickZone
+
+	label    Mnemonic
+	variant  designsystem.ButtonVariant
+	disabled bool
+	onClick  func() tui.Cmd
+
+	// hovered is set while the pointer is over the button, pressed while it
+	// is held down.
+	hovered bool
+	pressed bool
+
+	// toggle makes the button a ToggleButton; on is its state.
+	toggle bool
+	on     bool
+
+	// style holds the widget's own styling, which wins over the theme's.
+	style tui.Style
+}
+
+// New creates a new Button. An "&" in label marks its access key.
+func New(label string, opts ...Option) *Model {
+	m := &Model{
+		label:   ParseMnemonic(label),
+		variant: designsystem.ButtonSecondary,
+		style:   tui.NewStyle().Padding(0, designsystem.Current().Components.Button.Padding),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithVariant sets the variant; buttons are secondary by default.
+func WithVariant(variant designsystem.ButtonVariant) Option {
+	return func(m *Model) { m.variant = variant }
+}
+
+// WithDisabled disables the button: it is drawn muted and ignores input.
+func WithDisabled(disabled bool) Option {
+	return func(m *Model) { m.disabled = disabled }
+}
+
+// WithOnClick sets the command run when the button is pressed.
+func WithOnClick(onClick func() tui.Cmd) Option {
+	return func(m *Model) { m.onClick = onClick }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+// --- Messages ---
+
+// clickMsg reports a click on the button's zone.
+type clickMsg struct {
+	zone tui.ZoneID
+}
+
+// releaseMsg ends the active look of a button pressed from the keyboard.
+type releaseMsg struct {
+	zone tui.ZoneID
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.KeyMsg:
+		if m.disabled {
+			return m, nil
+		}
+		if m.label.Matches(msg) || (m.Focused() && tui.Keys.Matches(msg, actionPress)) {
+			m.setPressed(true)
+			release := tui.Tick(activeFor, func(time.Time) tui.Msg { return releaseMsg{zone: m.ZoneID()} })
+			return m, tui.Batch(m.press(), release)
+		}
+
+	case tui.MouseMsg:
+		m.trackMouse(msg)
+
+	case clickMsg:
+		if msg.zone == m.ZoneID() && !m.disabled {
+			return m, m.press()
+		}
+
+	case releaseMsg:
+		if msg.zone == m.ZoneID() {
+			m.setPressed(false)
+		}
+	}
+	return m, nil
+}
+
+// press flips a toggle and returns the click command.
+func (m *Model) press() tui.Cmd {
+	if m.toggle {
+		m.on = !m.on
+		m.MarkDirty()
+	}
+	if m.onClick == nil {
+		return nil
+	}
+	return m.onClick()
+}
+
+// trackMouse follows the pointer for the hover and active looks. The click
+// itself is routed by the zone map.
+func (m *Model) trackMouse(msg tui.MouseMsg) {
+	r, ok := tui.Zones.Get(m.ZoneID())
+	over := ok && r.Contains(msg.X, msg.Y) && !m.disabled
+	if over != m.hovered {
+		m.hovered = over
+		m.MarkDirty()
+	}
+	switch {
+	case msg.Action == tui.MouseActionPress && msg.Button == tui.MouseButtonLeft:
+		m.setPressed(over)
+	case msg.Action == tui.MouseActionRelease:
+		m.setPressed(false)
+	}
+}
+
+func (m *Model) setPressed(pressed bool) {
+	if pressed != m.pressed {
+		m.pressed = pressed
+		m.MarkDirty()
+	}
+}
+
+func (m *Model) View() string {
+	m.bindZone()
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// bindZone registers the click handler the first time the button is drawn,
+// so a button that is never shown leaves nothing in the zone map. Clicks
+// arrive through the zone map; they are handled in Update like any other
+// input.
+func (m *Model) bindZone() {
+	if tui.Zones.Registered(m.ZoneID()) {
+		return
+	}
+	tui.Zones.OnClick(m.ZoneID(), func() tui.Cmd {
+		id := m.ZoneID()
+		return func() tui.Msg { return clickMsg{zone: id} }
+	})
+}
+
+// render draws the button in its current state; View caches the result.
+func (m *Model) render() string {
+	tokens := designsystem.Current().Components.Button
+	states := tokens.VariantStates(m.variant)
+
+	// States are layered over the default look.
+	theme := tui.NewStyle().Align(tui.Center)
+	if tokens.Bordered(m.variant) {
+		theme = theme.Border(tokens.Border).BorderForeground(tokens.BorderColor)
+	}
+	theme = states.Default.Apply(theme)
+	if m.disabled {
+		theme = states.Disabled.Apply(theme)
+	} else {
+		if m.hovered {
+			theme = states.Hover.Apply(theme)
+		}
+		if m.Focused() {
+			theme = states.Focus.Apply(theme)
+		}
+		if m.pressed || m.on {
+			theme = states.Active.Apply(theme)
+		}
+	}
+	box := m.style.Inherit(theme)
+
+	label := m.label
+	if m.toggle {
+		glyph := "○ "
+		if m.on {
+			glyph = "● "
+		}
+		label.Label = glyph + label.Label
+		if label.Index >= 0 {
+			label.Index += len([]rune(glyph))
+		}
+	}
+
+	// The label is styled like the box, without its frame, so the access
+	// key can be underlined on its own.
+	text := tui.NewStyle().Inherit(box).UnsetBorder().UnsetAlign()
+
+	top, right, bottom, left := box.GetPadding()
+	hFrame, vFrame := box.GetFrameSize()
+	width := max(tui.Width(label.Label)+left+right, tokens.MinWidth)
+	if m.width > 0 {
+		width = max(m.width-(hFrame-left-right), 0)
+	}
+	if m.height > 0 {
+		box = box.Height(max(m.height-(vFrame-top-bottom), 0))
+	}
+	return m.MarkZone(box.Width(width).Render(label.Render(text)))
+}
+
+// AccessibleView returns the label, with the toggle and disabled states.
+func (m *Model) AccessibleView() string {
+	text := m.label.Label
+	if m.toggle {
+		if m.on {
+			text += " (on)"
+		} else {
+			text += " (off)"
+		}
+	}
+	if m.disabled {
+		text += " (disabled)"
+	}
+	return text
+}
+
+// --- State ---
+
+// Label returns the label, without the access key marker.
+func (m *Model) Label() string {
+	return m.label.Label
+}
+
+// SetLabel replaces the label; "&" marks the access key.
+func (m *Model) SetLabel(label string) {
+	m.label = ParseMnemonic(label)
+	m.MarkDirty()
+}
+
+// Disabled reports whether the button is disabled.
+func (m *Model) Disabled() bool {
+	return m.disabled
+}
+
+// SetDisabled enables or disables the button.
+func (m *Model) SetDisabled(disabled bool) {
+	m.disabled = disabled
+	if disabled {
+		m.hovered, m.pressed = false, false
+	}
+	m.MarkDirty()
+}
+
+// Close forgets the button's zone. Call it when the button is discarded.
+func (m *Model) Close() {
+	tui.Zones.Remove(m.ZoneID())
+}
+
+// --- tui.Clickable Implementation ---
+
+// SetOnClick sets the command run when the button is pressed.
+func (m *Model) SetOnClick(onClick func() tui.Cmd) {
+	m.onClick = onClick
+}
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.width = width
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.height = height
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
@@ -0,0 +1,168 @@
+package button
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// clickedMsg is delivered by the test buttons' click command.
+type clickedMsg struct{}
+
+func onClick() tui.Cmd {
+	return func() tui.Msg { return clickedMsg{} }
+}
+
+func clicks(d *tuitest.Driver) int {
+	n := 0
+	for _, msg := range d.Messages() {
+		if _, ok := msg.(clickedMsg); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func TestParseMnemonic(t *testing.T) {
+	tests := []struct {
+		label string
+		want  Mnemonic
+	}{
+		{"&Install", Mnemonic{Label: "Install", Index: 0, Key: 'i'}},
+		{"Re&move", Mnemonic{Label: "Remove", Index: 2, Key: 'm'}},
+		{"Save && &Quit", Mnemonic{Label: "Save & Quit", Index: 7, Key: 'q'}},
+		{"&Ação", Mnemonic{Label: "Ação", Index: 0, Key: 'a'}},
+		{"Plain", Mnemonic{Label: "Plain", Index: -1}},
+		{"Trailing&", Mnemonic{Label: "Trailing&", Index: -1}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseMnemonic(tt.label); got != tt.want {
+			t.Errorf("ParseMnemonic(%q) = %+v, want %+v", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestButtonActivation(t *testing.T) {
+	alt := func(r rune) tui.KeyMsg { return tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune{r}, Alt: true} }
+
+	tests := []struct {
+		name    string
+		focused bool
+		key     tui.KeyMsg
+		want    int
+	}{
+		{"enter when focused", true, tui.KeyMsg{Type: tui.KeyEnter}, 1},
+		{"space when focused", true, tui.KeyMsg{Type: tui.KeySpace}, 1},
+		{"enter without focus", false, tui.KeyMsg{Type: tui.KeyEnter}, 0},
+		{"mnemonic without focus", false, alt('i'), 1},
+		{"mnemonic with shift", false, alt('I'), 1},
+		{"other mnemonic", false, alt('x'), 0},
+		{"letter without alt", true, tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune("i")}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := New("&Install", WithOnClick(onClick))
+			t.Cleanup(b.Close)
+			if tt.focused {
+				b.Focus()
+			}
+			d := tuitest.New(t, b).Send(tt.key)
+			if got := clicks(d); got != tt.want {
+				t.Errorf("clicks = %d, want %d", got, tt.want)
+			}
+			if b.pressed {
+				t.Error("the button still looks active after the key")
+			}
+		})
+	}
+}
+
+func TestButtonClick(t *testing.T) {
+	b := New("Install")
+	b.SetOnClick(onClick)
+	t.Cleanup(b.Close)
+	d := tuitest.New(t, b, tuitest.WithSize(20, 3))
+
+	r, ok := tui.Zones.Get(b.ZoneID())
+	if !ok {
+		t.Fatal("the button was not drawn")
+	}
+	d.Send(tui.MouseMsg{X: r.X + 1, Y: r.Y + 1, Action: tui.MouseActionMotion, Button: tui.MouseButtonNone})
+	if !b.hovered {
+		t.Error("the button is not hovered under the pointer")
+	}
+	d.Send(tui.MouseMsg{X: r.X + 1, Y: r.Y + 1, Action: tui.MouseActionPress, Button: tui.MouseButtonLeft})
+	if !b.pressed {
+		t.Error("the button does not look active while held")
+	}
+	d.Send(tui.MouseMsg{X: r.X + 1, Y: r.Y + 1, Action: tui.MouseActionRelease, Button: tui.MouseButtonLeft})
+	if got := clicks(d); got != 1 {
+		t.Errorf("clicks = %d, want 1", got)
+	}
+
+	b.SetDisabled(true)
+	d.Send(
+		tui.MouseMsg{X: r.X + 1, Y: r.Y + 1, Action: tui.MouseActionPress, Button: tui.MouseButtonLeft},
+		tui.MouseMsg{X: r.X + 1, Y: r.Y + 1, Action: tui.MouseActionRelease, Button: tui.MouseButtonLeft},
+		tui.KeyMsg{Type: tui.KeyEnter},
+	)
+	if got := clicks(d); got != 1 {
+		t.Errorf("a disabled button was pressed: clicks = %d, want 1", got)
+	}
+}
+
+func TestButtonZoneRegisteredWhenDrawn(t *testing.T) {
+	b := New("Install")
+	t.Cleanup(b.Close)
+	if tui.Zones.Registered(b.ZoneID()) {
+		t.Fatal("a button that was never drawn registered its zone")
+	}
+	b.View()
+	if !tui.Zones.Registered(b.ZoneID()) {
+		t.Fatal("the drawn button has no zone")
+	}
+	b.Close()
+	if tui.Zones.Registered(b.ZoneID()) {
+		t.Error("Close left the zone registered")
+	}
+}
+
+func TestToggleButton(t *testing.T) {
+	b := NewToggle("&Dark mode", WithOnClick(onClick))
+	t.Cleanup(b.Close)
+	b.Focus()
+	d := tuitest.New(t, b, tuitest.WithSize(20, 3))
+	d.Golden("off")
+
+	d.Press(tui.KeyEnter)
+	if !b.On() || clicks(d) != 1 {
+		t.Fatalf("after Enter: on = %v, clicks = %d", b.On(), clicks(d))
+	}
+	d.Golden("on")
+
+	d.Send(tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune("d"), Alt: true})
+	if b.On() || clicks(d) != 2 {
+		t.Errorf("after Alt+D: on = %v, clicks = %d", b.On(), clicks(d))
+	}
+	if got := tui.AccessibleText(b); got != "Dark mode (off)" {
+		t.Errorf("AccessibleText = %q", got)
+	}
+}
+
+func TestButtonVariants(t *testing.T) {
+	row := func() string {
+		var views []string
+		for _, variant := range []designsystem.ButtonVariant{
+			designsystem.ButtonPrimary, designsystem.ButtonSecondary, designsystem.ButtonGhost, designsystem.ButtonDanger,
+		} {
+			views = append(views, New("&"+string(variant), WithVariant(variant)).View())
+		}
+		views = append(views, New("Off", WithDisabled(true)).View(), New("Wide", WithWidth(12)).View())
+		return tui.JoinHorizontal(tui.Center, views...)
+	}
+	tuitest.AssertGolden(t, "variants", tui.StripZones(row()))
+}
@@ -0,0 +1,62 @@
+package button
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+// Mnemonic is the access key of a label, marked with "&" before the letter:
+// "&Install" is shown as "Install" and pressed with Alt+I. "&&" stands for
+// a literal "&".
+type Mnemonic struct {
+	// Label is the text without markers.
+	Label string
+	// Index is the rune index of the access key in Label, or -1.
+	Index int
+	// Key is the lowercase access key, or 0 when the label has none.
+	Key rune
+}
+
+// ParseMnemonic splits label into its text and access key. Only the first
+// marker counts; later ones are dropped.
+func ParseMnemonic(label string) Mnemonic {
+	m := Mnemonic{Index: -1}
+	var b strings.Builder
+	runes := []rune(label)
+	count := 0
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '&' && i+1 < len(runes) {
+			i++
+			r = runes[i]
+			if r != '&' && m.Index < 0 {
+				m.Index, m.Key = count, unicode.ToLower(r)
+			}
+		}
+		b.WriteRune(r)
+		count++
+	}
+	m.Label = b.String()
+	return m
+}
+
+// Matches reports whether key is Alt plus the access key.
+func (m Mnemonic) Matches(key tui.KeyMsg) bool {
+	return m.Key != 0 && key.Alt && key.Type == tui.KeyRunes &&
+		len(key.Runes) == 1 && unicode.ToLower(key.Runes[0]) == m.Key
+}
+
+// Render draws the label with style, underlining the access key.
+func (m Mnemonic) Render(style tui.Style) string {
+	if m.Index < 0 {
+		return style.Render(m.Label)
+	}
+	// Each part is styled on its own: a styled part nested in another ends
+	// with a reset that would drop the outer style for the rest.
+	runes := []rune(m.Label)
+	return style.Render(string(runes[:m.Index])) +
+		style.Underline(true).Render(string(runes[m.Index])) +
+		style.Render(string(runes[m.Index+1:]))
+}
@@ -0,0 +1,26 @@
+package button
+
+// NewToggle creates a ToggleButton: a button that flips between on and off
+// each time it is pressed, before its click command runs. It is drawn
+// active, with a filled dot, while on.
+func NewToggle(label string, opts ...Option) *Model {
+	m := New(label, opts...)
+	m.toggle = true
+	return m
+}
+
+// WithOn sets the initial state of a ToggleButton.
+func WithOn(on bool) Option {
+	return func(m *Model) { m.on = on }
+}
+
+// On reports whether a ToggleButton is on.
+func (m *Model) On() bool {
+	return m.on
+}
+
+// SetOn turns a ToggleButton on or off without running its click command.
+func (m *Model) SetOn(on bool) {
+	m.on = on
+	m.MarkDirty()
+}
@@ -0,0 +1,725 @@
+// Package cardbox provides the CardBox widget: a titled, bordered surface
+// that stacks its children and floats over the screen with a simulated
+// shadow (components.CardBox and elevation in the theme files).
+//
+// Horizontal dividers among the children are drawn across the card's
+// padding and border, joined to its edges with tees:
+//
+//	card := cardbox.New(cardbox.WithTitle("Go"), cardbox.WithElevation(designsystem.ElevationHigh)).
+//		AddChild(version).
+//		AddChild(divider.New()).
+//		AddChild(install)
+//
+// Vertical dividers split the section they are in, between horizontal
+// dividers, into panes side by side. Their ends meet the card's border and
+// the horizontal dividers with tees, and cross the dividers that go on
+// past them:
+//
+//	┌─ Go ─────┬──────────┐
+//	│ 1.25     │ installed│
+//	├──────────┼──────────┤
+//	│ ~/go     │ 3 tools  │
+//	└──────────┴──────────┘
+//
+// The shadow is part of the card's view: the view is a rectangle whose
+// leading corners are left blank, so cards line up in JoinHorizontal and
+// JoinVertical like any other view.
+package cardbox
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/divider"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/spacer"
+)
+
+// Ensure Model implements the tui.Layout interface.
+var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// Option is a functional option for configuring the CardBox.
+type Option func(*Model)
+
+// Model is a card holding a column of children.
+type Model struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	// sized is false until the card gets a window size; until then it
+	// takes the size of its children on the axes without a desired size.
+	sized bool
+	tui.FocusState
+	tui.ViewCache
+
+	title     string
+	children  []tui.Model
+	elevation designsystem.Elevation
+
+	// border is drawn on the sides set by Border; the style keeps the
+	// sides, the padding, the background and the alignment.
+	border designsystem.BorderToken
+	bg     string
+	style  tui.Style
+}
+
+// New creates a new CardBox with the theme's border, padding, background
+// and elevation.
+func New(opts ...Option) *Model {
+	tokens := designsystem.Current().Components.CardBox
+	m := &Model{
+		elevation: tokens.Elevation,
+		border:    tokens.Border,
+		bg:        tokens.Bg,
+		style: tui.NewStyle().
+			Border(tokens.Border.Set.Border()).
+			Padding(0, tokens.Padding).
+			Background(tokens.Bg),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithTitle sets the title drawn in the top border.
+func WithTitle(title string) Option {
+	return func(m *Model) { m.SetTitle(title) }
+}
+
+// WithElevation sets how high the card floats, i.e. the size of its
+// shadow.
+func WithElevation(elevation designsystem.Elevation) Option {
+	return func(m *Model) { m.SetElevation(elevation) }
+}
+
+// WithBorderToken draws the border with a theme token, e.g.
+// designsystem.Current().Borders.Strong.
+func WithBorderToken(token designsystem.BorderToken) Option {
+	return func(m *Model) {
+		m.border = token
+		m.style = m.style.Border(token.Set.Border())
+	}
+}
+
+// WithChildren sets the children, stacked top to bottom.
+func WithChildren(children ...tui.Model) Option {
+	return func(m *Model) { m.children = children }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithBorderForeground(color string) Option {
+	return func(m *Model) { m.BorderForeground(color) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+func WithAlign(pos tui.Position) Option {
+	return func(m *Model) { m.Align(pos) }
+}
+
+// AddChild appends child below the other children.
+func (m *Model) AddChild(child tui.Model) *Model {
+	m.children = append(m.children, child)
+	m.MarkDirty()
+	return m
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	cmds := make([]tui.Cmd, len(m.children))
+	for i, child := range m.children {
+		cmds[i] = child.Init()
+	}
+	return tui.Batch(cmds...)
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if msg, ok := msg.(tui.WindowSizeMsg); ok {
+		m.width, m.height, m.sized = msg.Width, msg.Height, true
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		}
+		m.MarkDirty()
+		return m, m.layout()
+	}
+
+	cmds := make([]tui.Cmd, len(m.children))
+	for i, child := range m.children {
+		m.children[i], cmds[i] = child.Update(msg)
+	}
+	return m, tui.Batch(cmds...)
+}
+
+// layout sizes the children to their share of the card's content area.
+func (m *Model) layout() tui.Cmd {
+	width, height := m.contentSize()
+	rects := m.arrange(tui.Rect{Width: width, Height: height})
+	cmds := make([]tui.Cmd, len(m.children))
+	for i, child := range m.children {
+		m.children[i], cmds[i] = child.Update(tui.WindowSizeMsg{Width: rects[i].Width, Height: rects[i].Height})
+	}
+	return tui.Batch(cmds...)
+}
+
+// part is a run of the card's column: a horizontal divider, or a section
+// of the children between them. Vertical dividers split a section into
+// panes side by side.
+type part struct {
+	divider int     // the index of a horizontal divider, or -1
+	panes   [][]int // the children of each pane of a section
+	splits  []int   // the vertical dividers between the panes
+}
+
+// parts groups the children into horizontal dividers and sections.
+func (m *Model) parts() []part {
+	var parts []part
+	section := part{divider: -1, panes: [][]int{nil}}
+	flush := func() {
+		if len(section.splits) > 0 || len(section.panes[0]) > 0 {
+			parts = append(parts, section)
+		}
+		section = part{divider: -1, panes: [][]int{nil}}
+	}
+	for i, child := range m.children {
+		d, ok := child.(*divider.Model)
+		switch {
+		case ok && !d.Vertical():
+			flush()
+			parts = append(parts, part{divider: i})
+		case ok:
+			section.splits = append(section.splits, i)
+			section.panes = append(section.panes, nil)
+		default:
+			last := len(section.panes) - 1
+			section.panes[last] = append(section.panes[last], i)
+		}
+	}
+	flush()
+	return parts
+}
+
+// arrange lays the children out in area: dividers and spacers keep their
+// size and the other children share the rest. A split section takes one
+// share of the height, and its panes share its width evenly.
+func (m *Model) arrange(area tui.Rect) []tui.Rect {
+	parts := m.parts()
+	column := tui.NewFlex(tui.Column)
+	for _, p := range parts {
+		switch {
+		case p.divider >= 0:
+			column.Add(tui.Fixed(1))
+		case len(p.splits) > 0:
+			column.Add(tui.Grow(1))
+		default:
+			for _, i := range p.panes[0] {
+				column.Add(m.item(i))
+			}
+		}
+	}
+	rows := column.Arrange(area)
+
+	rects := make([]tui.Rect, len(m.children))
+	next := 0
+	for _, p := range parts {
+		switch {
+		case p.divider >= 0:
+			rects[p.divider] = rows[next]
+			next++
+		case len(p.splits) > 0:
+			m.arrangePanes(p, rows[next], rects)
+			next++
+		default:
+			for _, i := range p.panes[0] {
+				rects[i] = rows[next]
+				next++
+			}
+		}
+	}
+	return rects
+}
+
+// arrangePanes lays the panes of a split section out side by side in
+// area, each one stacking its children, and sets their rects.
+func (m *Model) arrangePanes(p part, area tui.Rect, rects []tui.Rect) {
+	row := tui.NewFlex(tui.Row)
+	for j := range p.panes {
+		if j > 0 {
+			row.Add(tui.Fixed(1))
+		}
+		row.Add(tui.Grow(1))
+	}
+	cells := row.Arrange(area)
+	for j, pane := range p.panes {
+		if j > 0 {
+			rects[p.splits[j-1]] = cells[2*j-1]
+		}
+		column := tui.NewFlex(tui.Column)
+		for _, i := range pane {
+			column.Add(m.item(i))
+		}
+		for k, r := range column.Arrange(cells[2*j]) {
+			rects[pane[k]] = r
+		}
+	}
+}
+
+// item returns how child i is sized in its column: spacers keep their
+// size and the other children grow.
+func (m *Model) item(i int) tui.FlexItem {
+	if child, ok := m.children[i].(*spacer.Model); ok {
+		if child.Grows() {
+			return tui.Grow(1)
+		}
+		_, size := child.Size()
+		return tui.Fixed(size)
+	}
+	return tui.Grow(1)
+}
+
+// frame returns the cells taken around the content by the border and the
+// padding on each side. Without a top border the title takes a line.
+func (m *Model) frame() (top, right, bottom, left int) {
+	_, bt, br, bb, bl := m.style.GetBorder()
+	pt, pr, pb, pl := m.style.GetPadding()
+	top = boolInt(bt) + pt
+	if m.title != "" && !bt {
+		top++
+	}
+	return top, boolInt(br) + pr, boolInt(bb) + pb, boolInt(bl) + pl
+}
+
+// shadow returns the shadow of the card's elevation in the current theme.
+func (m *Model) shadow() designsystem.ElevationTokens {
+	return designsystem.Current().Elevation[m.elevation]
+}
+
+// contentSize returns the area left to the children inside the frame and
+// the shadow.
+func (m *Model) contentSize() (width, height int) {
+	top, right, bottom, left := m.frame()
+	shadow := m.shadow()
+	return max(m.width-shadow.OffsetX-left-right, 0), max(m.height-shadow.OffsetY-top-bottom, 0)
+}
+
+func (m *Model) View() string {
+	views := make([]string, len(m.children))
+	for i, child := range m.children {
+		views[i] = child.View()
+	}
+	key := tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused(), Parts: views}
+	return m.CachedView(key, func() string { return m.render(views) })
+}
+
+// bodyLine is a line between the card's side borders: content, or a
+// divider drawn across the padding and the borders. splits holds the
+// content columns where vertical dividers run through the line.
+type bodyLine struct {
+	text    string
+	divider *divider.Model
+	splits  []int
+}
+
+// render draws the children's views in the card, then its shadow; View
+// caches the result.
+func (m *Model) render(views []string) string {
+	theme := designsystem.Current()
+	set := m.border.Set.For(theme.Charset)
+	_, hasTop, hasRight, hasBottom, hasLeft := m.style.GetBorder()
+	pt, pr, pb, pl := m.style.GetPadding()
+	shadow := m.shadow()
+	fixedWidth, fixedHeight := m.sized || m.desiredWidth > 0, m.sized || m.desiredHeight > 0
+
+	// Sized cards give each child the area arrange gave it.
+	var rects []tui.Rect
+	if fixedWidth && fixedHeight {
+		width, height := m.contentSize()
+		rects = m.arrange(tui.Rect{Width: width, Height: height})
+	}
+
+	var body []bodyLine
+	if m.title != "" && !hasTop {
+		body = append(body, bodyLine{text: m.title})
+	}
+	for _, p := range m.parts() {
+		switch {
+		case p.divider >= 0:
+			body = append(body, bodyLine{divider: m.children[p.divider].(*divider.Model)})
+		case len(p.splits) > 0:
+			body = append(body, m.section(p, views, rects, set)...)
+		default:
+			for _, i := range p.panes[0] {
+				for _, line := range m.childLines(i, views, rects) {
+					body = append(body, bodyLine{text: line})
+				}
+			}
+		}
+	}
+
+	contentWidth, _ := m.contentSize()
+	if !fixedWidth {
+		// Unsized cards take the width of their content and title.
+		contentWidth = tui.Width(m.title) + 4
+		for _, line := range body {
+			if line.divider == nil {
+				contentWidth = max(contentWidth, tui.Width(line.text))
+			}
+		}
+	}
+	innerWidth := contentWidth + pl + pr
+	boxWidth := innerWidth + boolInt(hasLeft) + boolInt(hasRight)
+
+	borderStyle := tui.NewStyle().Foreground(m.border.Color).Background(m.bg)
+	if m.Focused() {
+		borderStyle = theme.State.Focus.ApplyBorder(borderStyle)
+	}
+	lineStyle := m.style.UnsetBorder().UnsetPadding().Padding(0, pr, 0, pl).Width(innerWidth)
+	side := func(show bool, glyph string) string {
+		if !show {
+			return ""
+		}
+		return borderStyle.Render(glyph)
+	}
+	// inner turns the content columns of vertical dividers into columns of
+	// the inner width.
+	inner := func(splits []int) []int {
+		columns := make([]int, len(splits))
+		for i, x := range splits {
+			columns[i] = pl + x
+		}
+		return columns
+	}
+
+	var topTees, bottomTees []int
+	if len(body) > 0 && pt == 0 {
+		topTees = inner(body[0].splits)
+	}
+	if len(body) > 0 && pb == 0 {
+		bottomTees = inner(body[len(body)-1].splits)
+	}
+
+	var lines []string
+	if hasTop {
+		lines = append(lines, side(hasLeft, set.TopLeft)+m.titleBar(set, borderStyle, innerWidth, topTees)+side(hasRight, set.TopRight))
+	}
+	for range pt {
+		lines = append(lines, side(hasLeft, set.Vertical)+lineStyle.Render("")+side(hasRight, set.Vertical))
+	}
+	for i, line := range body {
+		if line.divider != nil {
+			var up, down []int
+			if i > 0 {
+				up = inner(body[i-1].splits)
+			}
+			if i < len(body)-1 {
+				down = inner(body[i+1].splits)
+			}
+			lines = append(lines, dividerLine(line.divider, set, borderStyle, innerWidth, hasLeft, hasRight, up, down))
+			continue
+		}
+		text := tui.Truncate(line.text, contentWidth, "")
+		lines = append(lines, side(hasLeft, set.Vertical)+lineStyle.Render(text)+side(hasRight, set.Vertical))
+	}
+	for range pb {
+		lines = append(lines, side(hasLeft, set.Vertical)+lineStyle.Render("")+side(hasRight, set.Vertical))
+	}
+	if hasBottom {
+		lines = append(lines, side(hasLeft, set.BottomLeft)+borderStyle.Render(edge(set, set.TeeBottom, 0, innerWidth, bottomTees))+side(hasRight, set.BottomRight))
+	}
+
+	if fixedWidth {
+		boxWidth = max(m.width-shadow.OffsetX, 0)
+	}
+	if fixedHeight {
+		lines = fitLines(lines, max(m.height-shadow.OffsetY, 0))
+	}
+	if boxWidth <= 0 || len(lines) == 0 {
+		return ""
+	}
+	for i, line := range lines {
+		lines[i] = tui.Truncate(line, boxWidth, "")
+	}
+	return dropShadow(lines, boxWidth, shadow)
+}
+
+// childLines returns the lines of child i: as many as its rect has in a
+// sized card, its whole view otherwise.
+func (m *Model) childLines(i int, views []string, rects []tui.Rect) []string {
+	lines := strings.Split(views[i], "\n")
+	if rects != nil {
+		lines = fitLines(lines, rects[i].Height)
+	}
+	return lines
+}
+
+// section draws the panes of a split section side by side, with the
+// vertical dividers between them. Unsized panes take the width of their
+// widest line.
+func (m *Model) section(p part, views []string, rects []tui.Rect, set designsystem.BorderSet) []bodyLine {
+	panes := make([][]string, len(p.panes))
+	widths := make([]int, len(p.panes))
+	height := 0
+	for j, pane := range p.panes {
+		for _, i := range pane {
+			panes[j] = append(panes[j], m.childLines(i, views, rects)...)
+		}
+		for _, line := range panes[j] {
+			widths[j] = max(widths[j], tui.Width(line))
+		}
+		height = max(height, len(panes[j]))
+	}
+	if rects != nil {
+		// The panes fill the section, even when they hold no child: they
+		// take the cells between the dividers around them.
+		height = rects[p.splits[0]].Height
+		start := 0
+		for j := range p.panes {
+			end, _ := m.contentSize()
+			if j < len(p.splits) {
+				end = rects[p.splits[j]].X
+			}
+			widths[j] = max(end-start, 0)
+			start = end + 1
+		}
+	}
+
+	splits := make([]int, len(p.splits))
+	x := 0
+	for j := range p.splits {
+		x += widths[j]
+		splits[j] = x
+		x++
+	}
+
+	body := make([]bodyLine, height)
+	for row := range body {
+		var b strings.Builder
+		for j := range p.panes {
+			if j > 0 {
+				b.WriteString(m.children[p.splits[j-1]].(*divider.Model).Line(set, 1, false, false))
+			}
+			var line string
+			if row < len(panes[j]) {
+				line = tui.Truncate(panes[j][row], widths[j], "")
+			}
+			b.WriteString(line + strings.Repeat(" ", widths[j]-tui.Width(line)))
+		}
+		body[row] = bodyLine{text: b.String(), splits: splits}
+	}
+	return body
+}
+
+// titleBar draws the top edge, innerWidth cells wide, with the title set
+// into it: "─ Title ──────". Vertical dividers that reach it meet it with
+// tees at the inner columns in tees, except under the title.
+func (m *Model) titleBar(set designsystem.BorderSet, borderStyle tui.Style, innerWidth int, tees []int) string {
+	if m.title == "" || innerWidth < 5 {
+		return borderStyle.Render(edge(set, set.TeeTop, 0, innerWidth, tees))
+	}
+	tokens := designsystem.Current().Components
+	titleStyle := tokens.Text.Variant(tokens.CardBox.Title).Apply(tui.NewStyle().Background(m.bg))
+	title := tui.Truncate(m.title, innerWidth-4, "…")
+	rest := innerWidth - tui.Width(title) - 3
+	return borderStyle.Render(edge(set, set.TeeTop, 0, 1, tees)+" ") + titleStyle.Render(title) +
+		borderStyle.Render(" "+edge(set, set.TeeTop, innerWidth-rest, rest, tees))
+}
+
+// edge draws n cells of a horizontal edge starting at inner column from,
+// with tee at the inner columns in tees.
+func edge(set designsystem.BorderSet, tee string, from, n int, tees []int) string {
+	cells := make([]string, n)
+	for i := range cells {
+		cells[i] = set.Horizontal
+		if slices.Contains(tees, from+i) {
+			cells[i] = tee
+		}
+	}
+	return strings.Join(cells, "")
+}
+
+// dividerLine draws the horizontal divider d across the card: the line in
+// its color, joined to the vertical dividers above (up) and below (down)
+// at those inner columns, and to the card's sides with tees in the border
+// color.
+func dividerLine(d *divider.Model, set designsystem.BorderSet, borderStyle tui.Style, innerWidth int, hasLeft, hasRight bool, up, down []int) string {
+	cells := make([]string, innerWidth)
+	for i := range cells {
+		cells[i] = set.Junction(slices.Contains(up, i), true, slices.Contains(down, i), true)
+	}
+	line := tui.NewStyle().Foreground(d.Color()).Render(strings.Join(cells, ""))
+	if hasLeft {
+		line = borderStyle.Render(set.TeeLeft) + line
+	}
+	if hasRight {
+		line += borderStyle.Render(set.TeeRight)
+	}
+	return line
+}
+
+// dropShadow adds the shadow to the lines of a box boxWidth cells wide:
+// a column on the right and a row at the bottom, shifted by the offsets.
+// The corners the shadow does not reach are blank, so the view stays a
+// rectangle.
+func dropShadow(lines []string, boxWidth int, shadow designsystem.ElevationTokens) string {
+	dx, dy := max(shadow.OffsetX, 0), max(shadow.OffsetY, 0)
+	if dx == 0 && dy == 0 {
+		return strings.Join(lines, "\n")
+	}
+	shade := shadow.Shade
+	if shade == "" {
+		shade = " "
+	}
+	shadowStyle := tui.NewStyle().Background(shadow.Shadow)
+
+	out := make([]string, 0, len(lines)+dy)
+	for i, line := range lines {
+		line += strings.Repeat(" ", boxWidth-tui.Width(line))
+		if i < dy {
+			line += strings.Repeat(" ", dx)
+		} else {
+			line += shadowStyle.Render(strings.Repeat(shade, dx))
+		}
+		out = append(out, line)
+	}
+	for range dy {
+		out = append(out, strings.Repeat(" ", dx)+shadowStyle.Render(strings.Repeat(shade, boxWidth)))
+	}
+	return strings.Join(out, "\n")
+}
+
+// fitLines cuts lines to height, or pads them with blank lines.
+func fitLines(lines []string, height int) []string {
+	if len(lines) >= height {
+		return lines[:height]
+	}
+	return append(lines, make([]string, height-len(lines))...)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// AccessibleView returns the title and the children's content, one after
+// the other.
+func (m *Model) AccessibleView() string {
+	var parts []string
+	if m.title != "" {
+		parts = append(parts, m.title)
+	}
+	for _, child := range m.children {
+		if text := tui.AccessibleText(child); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// --- State ---
+
+// Title returns the title drawn in the top border.
+func (m *Model) Title() string {
+	return m.title
+}
+
+// SetTitle replaces the title.
+func (m *Model) SetTitle(title string) {
+	m.title = title
+	m.MarkDirty()
+}
+
+// Elevation returns how high the card floats.
+func (m *Model) Elevation() designsystem.Elevation {
+	return m.elevation
+}
+
+// SetElevation changes how high the card floats. The children are laid
+// out again on the next window size.
+func (m *Model) SetElevation(elevation designsystem.Elevation) {
+	m.elevation = elevation
+	m.MarkDirty()
+}
+
+// Children returns the children, top to bottom.
+func (m *Model) Children() []tui.Model {
+	return m.children
+}
+
+// --- tui.Layout Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Layout {
+	m.bg = color
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+// Border draws the card's frame with border, on the given sides. Dividers
+// meet it with the junctions of the matching theme set.
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
+	m.border.Set = designsystem.BorderSetOf(border)
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Layout {
+	m.border.Color = color
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Layout {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Layout {
+	m.desiredWidth = width
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Layout {
+	m.desiredHeight = height
+	m.height = height
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Layout {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
@@ -0,0 +1,241 @@
+package cardbox
+
+import (
+	"strings"
+	"testing"
+
+	lg "github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/divider"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/text"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// useTheme draws the test's cards with Unicode glyphs and a visible shade,
+// so the shadow shows in the golden files.
+func useTheme(t *testing.T) {
+	theme := designsystem.Default()
+	theme.Charset = designsystem.CharsetUnicode
+	for elevation, shadow := range theme.Elevation {
+		shadow.Shade = "░"
+		theme.Elevation[elevation] = shadow
+	}
+	tuitest.UseTheme(t, theme)
+}
+
+// toolCard returns a card of the given size about a tool.
+func toolCard(name, version string, opts ...Option) *Model {
+	card := New(append([]Option{WithTitle(name)}, opts...)...).
+		AddChild(text.New("version " + version)).
+		AddChild(divider.New()).
+		AddChild(text.New("installed"))
+	return card
+}
+
+// size lays out the card in a slot of width by height.
+func size(m *Model, width, height int) *Model {
+	m.Update(tui.WindowSizeMsg{Width: width, Height: height})
+	return m
+}
+
+func TestCardBox(t *testing.T) {
+	useTheme(t)
+	card := toolCard("Go", "1.25")
+	d := tuitest.New(t, card, tuitest.WithSize(24, 7))
+	d.Golden("low")
+
+	card.SetElevation(designsystem.ElevationNone)
+	card.Border(tui.DoubleBorder)
+	d.Resize(24, 7)
+	d.Golden("double_flat")
+
+	if got := tui.AccessibleText(card); got != "Go\nversion 1.25\ninstalled" {
+		t.Errorf("AccessibleText = %q", got)
+	}
+}
+
+func TestCardBoxSizedToContent(t *testing.T) {
+	useTheme(t)
+	card := New(WithTitle("Node"), WithElevation(designsystem.ElevationNone), WithPadding(0, 1)).
+		AddChild(text.New("22.1.0"))
+	want := "┌─ Node ───┐\n│ 22.1.0   │\n└──────────┘"
+	if got := ansi.Strip(tui.StripZones(card.View())); got != want {
+		t.Errorf("view =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCardBoxWidthBeforeWindowSize(t *testing.T) {
+	useTheme(t)
+	card := New(WithTitle("Node"), WithElevation(designsystem.ElevationNone), WithPadding(0, 1), WithWidth(14)).
+		AddChild(text.New("22.1.0"))
+	want := "┌─ Node ─────┐\n│ 22.1.0     │\n└────────────┘"
+	if got := ansi.Strip(tui.StripZones(card.View())); got != want {
+		t.Errorf("view =\n%s\nwant\n%s", got, want)
+	}
+
+	card.Height(4)
+	want = "┌─ Node ─────┐\n│ 22.1.0     │\n│            │\n└────────────┘"
+	if got := ansi.Strip(tui.StripZones(card.View())); got != want {
+		t.Errorf("view after Height(4) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestElevation(t *testing.T) {
+	useTheme(t)
+	tests := []struct {
+		elevation designsystem.Elevation
+		// corner is the blank cell at the top right of the view.
+		dx, dy int
+	}{
+		{designsystem.ElevationNone, 0, 0},
+		{designsystem.ElevationLow, 1, 1},
+		{designsystem.ElevationMedium, 1, 1},
+		{designsystem.ElevationHigh, 1, 2},
+		{"unknown", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.elevation), func(t *testing.T) {
+			card := size(toolCard("Go", "1.25", WithElevation(tt.elevation)), 20, 8)
+			lines := strings.Split(ansi.Strip(card.View()), "\n")
+			if len(lines) != 8 {
+				t.Fatalf("%d lines, want the slot's 8", len(lines))
+			}
+			for i, line := range lines {
+				if w := tui.Width(line); w != 20 {
+					t.Fatalf("line %d is %d cells wide, want 20", i, w)
+				}
+			}
+			// The box ends where the shadow starts.
+			if got := strings.Count(lines[len(lines)-1], "░"); tt.dy > 0 && got != 20-tt.dx {
+				t.Errorf("bottom shadow is %d cells, want %d", got, 20-tt.dx)
+			}
+			if tt.dx > 0 && !strings.HasSuffix(lines[0], strings.Repeat(" ", tt.dx)) {
+				t.Errorf("top line %q does not end with the blank corner", lines[0])
+			}
+			box := lines[len(lines)-1-tt.dy]
+			if !strings.HasPrefix(box, "└") {
+				t.Errorf("bottom border %q is not on line %d", box, len(lines)-1-tt.dy)
+			}
+		})
+	}
+}
+
+// TestShadowInJoins lays cards out side by side and stacked: their
+// shadows must not shift the cards around them.
+func TestShadowInJoins(t *testing.T) {
+	useTheme(t)
+	goCard := size(toolCard("Go", "1.25"), 20, 6)
+	nodeCard := size(toolCard("Node", "22.1.0", WithElevation(designsystem.ElevationHigh)), 20, 7)
+	flat := size(toolCard("Rust", "1.89", WithElevation(designsystem.ElevationNone)), 20, 5)
+
+	tuitest.AssertGolden(t, "horizontal", ansi.Strip(tui.JoinHorizontal(tui.Top, goCard.View(), nodeCard.View(), flat.View())))
+	tuitest.AssertGolden(t, "vertical", ansi.Strip(tui.JoinVertical(tui.Left, goCard.View(), flat.View())))
+}
+
+func TestCardBoxASCII(t *testing.T) {
+	useTheme(t)
+	theme := designsystem.Current()
+	theme.Charset = designsystem.CharsetASCII
+	designsystem.SetCurrent(theme)
+
+	card := size(toolCard("Go", "1.25", WithElevation(designsystem.ElevationNone), WithPadding(0)), 14, 5)
+	want := "+- Go -------+\n|version 1.25|\n+------------+\n|installed   |\n+------------+"
+	if got := ansi.Strip(card.View()); got != want {
+		t.Errorf("view =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// splitCard returns a flat card whose sections are split by vertical
+// dividers, as in the package example.
+func splitCard() *Model {
+	return New(WithTitle("Go"), WithElevation(designsystem.ElevationNone), WithPadding(0)).
+		AddChild(text.New("1.25")).
+		AddChild(divider.NewVertical()).
+		AddChild(text.New("installed")).
+		AddChild(divider.New()).
+		AddChild(text.New("~/go")).
+		AddChild(divider.NewVertical()).
+		AddChild(text.New("3 tools"))
+}
+
+func TestVerticalDividers(t *testing.T) {
+	useTheme(t)
+	card := splitCard()
+	want := "┌─ Go ────┬─────────┐\n" +
+		"│1.25     │installed│\n" +
+		"├─────────┼─────────┤\n" +
+		"│~/go     │3 tools  │\n" +
+		"└─────────┴─────────┘"
+	if got := ansi.Strip(size(card, 21, 5).View()); got != want {
+		t.Errorf("sized view =\n%s\nwant\n%s", got, want)
+	}
+
+	// Unsized panes take the width of their content; a divider only
+	// crosses the vertical ones on both of its sides.
+	card = New(WithTitle("Go"), WithElevation(designsystem.ElevationNone), WithPadding(0)).
+		AddChild(text.New("1.25.0")).
+		AddChild(divider.NewVertical()).
+		AddChild(text.New("installed")).
+		AddChild(divider.New()).
+		AddChild(text.New("~/go"))
+	want = "┌─ Go ─┬─────────┐\n" +
+		"│1.25.0│installed│\n" +
+		"├──────┴─────────┤\n" +
+		"│~/go            │\n" +
+		"└────────────────┘"
+	if got := ansi.Strip(card.View()); got != want {
+		t.Errorf("unsized view =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDividerJoinsInBorderColor(t *testing.T) {
+	useTheme(t)
+	profile := lg.ColorProfile()
+	lg.SetColorProfile(termenv.ANSI256)
+	t.Cleanup(func() { lg.SetColorProfile(profile) })
+
+	card := splitCard()
+	card.BorderForeground("1")
+	lines := strings.Split(size(card, 21, 5).View(), "\n")
+
+	tokens := designsystem.Current().Components
+	border := tui.NewStyle().Foreground("1").Background(tokens.CardBox.Bg)
+	rule := tui.NewStyle().Foreground(tokens.Divider.Border.Color)
+	want := border.Render("├") + rule.Render("─────────┼─────────") + border.Render("┤")
+	if lines[2] != want {
+		t.Errorf("divider line = %q, want %q", lines[2], want)
+	}
+	if want := border.Render("┌"); !strings.HasPrefix(lines[0], want) || !strings.Contains(lines[0], border.Render(" ────┬─────────")) {
+		t.Errorf("top edge = %q, want the tee in the border color", lines[0])
+	}
+}
+
+// TestAnySize renders the card at every size up to 30x10: it must never
+// panic nor draw outside the slot.
+func TestAnySize(t *testing.T) {
+	useTheme(t)
+	for _, card := range []*Model{toolCard("Go", "1.25", WithElevation(designsystem.ElevationHigh)), splitCard()} {
+		for width := 0; width <= 30; width++ {
+			for height := 0; height <= 10; height++ {
+				view := size(card, width, height).View()
+				if view == "" {
+					continue
+				}
+				lines := strings.Split(view, "\n")
+				if len(lines) > height {
+					t.Fatalf("%dx%d: %d lines", width, height, len(lines))
+				}
+				for _, line := range lines {
+					if tui.Width(line) > width {
+						t.Fatalf("%dx%d: line %q overflows", width, height, line)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,340 @@
+// Package checkbox provides the Checkbox widget and the checkbox Tree,
+// whose groups show "mixed" while only some of their items are checked.
+//
+// When the user toggles them with Space or a click, a Checkbox sends a
+// ChangeMsg and a Tree sends a TreeChangeMsg:
+//
+//	case checkbox.ChangeMsg:
+//		if msg.ID == m.telemetry.ID() { m.cfg.Telemetry = msg.State == checkbox.Checked }
+//	case checkbox.TreeChangeMsg:
+//		if msg.ID == m.features.ID() { m.cfg.Features = msg.Checked }
+package checkbox
+
+import (
+	"sync/atomic"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Component interface.
+var _ tui.Component = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// keyScope is the keymap scope of the checkbox actions.
+const keyScope = "checkbox"
+
+// Checkbox actions, active while a checkbox or a tree has focus.
+var (
+	actionToggle = tui.Keys.Register(keyScope, "checkbox.toggle", "Toggle", "space", "x")
+	actionUp     = tui.Keys.Register(keyScope, "checkbox.up", "Previous item", "up", "k")
+	actionDown   = tui.Keys.Register(keyScope, "checkbox.down", "Next item", "down", "j")
+	actionHome   = tui.Keys.Register(keyScope, "checkbox.home", "First item", "home")
+	actionEnd    = tui.Keys.Register(keyScope, "checkbox.end", "Last item", "end")
+)
+
+// State is the state of a checkbox.
+type State int
+
+const (
+	Unchecked State = iota
+	Checked
+	// Mixed is shown by a group with only some of its items checked.
+	Mixed
+)
+
+// String returns the state as the accessible view writes it.
+func (s State) String() string {
+	switch s {
+	case Checked:
+		return "checked"
+	case Mixed:
+		return "mixed"
+	default:
+		return "unchecked"
+	}
+}
+
+// toggled returns the state a toggle leads to: mixed checks everything.
+func (s State) toggled() State {
+	if s == Checked {
+		return Unchecked
+	}
+	return Checked
+}
+
+// ID identifies a checkbox or a tree in its messages.
+type ID int64
+
+var lastID atomic.Int64
+
+// ChangeMsg is sent when the user toggles a checkbox.
+type ChangeMsg struct {
+	ID    ID
+	State State
+}
+
+// Option is a functional option for configuring the Checkbox.
+type Option func(*Model)
+
+// Model is a checkbox with a label.
+type Model struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+	tui.ClickZone
+
+	id       ID
+	label    string
+	state    State
+	disabled bool
+
+	// style holds the widget's own styling, which wins over the theme's.
+	style tui.Style
+}
+
+// New creates a new, unchecked Checkbox.
+func New(label string, opts ...Option) *Model {
+	m := &Model{
+		id:    ID(lastID.Add(1)),
+		label: label,
+		style: tui.NewStyle(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithState sets the initial state.
+func WithState(state State) Option {
+	return func(m *Model) { m.state = state }
+}
+
+// WithChecked checks the checkbox or not.
+func WithChecked(checked bool) Option {
+	return func(m *Model) { m.state = checkedState(checked) }
+}
+
+// WithDisabled disables the checkbox: it is drawn muted and ignores input.
+func WithDisabled(disabled bool) Option {
+	return func(m *Model) { m.disabled = disabled }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+// clickMsg reports a click on a checkbox, or on an item of a tree.
+type clickMsg struct {
+	id   ID
+	item int
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		}
+
+	case tui.KeyMsg:
+		if m.Focused() && tui.Keys.Matches(msg, actionToggle) {
+			return m, m.toggle()
+		}
+
+	case clickMsg:
+		if msg.id == m.id {
+			return m, m.toggle()
+		}
+	}
+	return m, nil
+}
+
+// toggle flips the state and reports it.
+func (m *Model) toggle() tui.Cmd {
+	if m.disabled {
+		return nil
+	}
+	m.state = m.state.toggled()
+	m.MarkDirty()
+	msg := ChangeMsg{ID: m.id, State: m.state}
+	return func() tui.Msg { return msg }
+}
+
+func (m *Model) View() string {
+	m.bindZone()
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// bindZone registers the click handler the first time the checkbox is
+// drawn, so a checkbox that is never shown leaves nothing in the zone map.
+func (m *Model) bindZone() {
+	if tui.Zones.Registered(m.ZoneID()) {
+		return
+	}
+	tui.Zones.OnClick(m.ZoneID(), func() tui.Cmd {
+		id := m.id
+		return func() tui.Msg { return clickMsg{id: id} }
+	})
+}
+
+// render draws the glyph and the label; View caches the result.
+func (m *Model) render() string {
+	line := renderItem(m.state, m.label, m.Focused(), m.disabled)
+	if m.width > 0 {
+		hFrame, _ := m.style.GetFrameSize()
+		line = tui.Truncate(line, max(m.width-hFrame, 0), "…")
+	}
+	return m.MarkZone(m.style.Render(line))
+}
+
+// renderItem draws a checkbox glyph in the color of state, then the label,
+// highlighted when focused.
+func renderItem(state State, label string, focused, disabled bool) string {
+	theme := designsystem.Current()
+	tokens := theme.Components.Checkbox
+
+	glyph, color := tokens.Unchecked, tokens.UncheckedColor
+	switch state {
+	case Checked:
+		glyph, color = tokens.Checked, tokens.CheckedColor
+	case Mixed:
+		glyph, color = tokens.Mixed, tokens.MixedColor
+	}
+
+	box := tui.NewStyle().Foreground(color)
+	text := tui.NewStyle()
+	switch {
+	case disabled:
+		box = theme.State.Disabled.Apply(box)
+		text = theme.State.Disabled.Apply(text)
+	case focused:
+		text = theme.State.Focus.Apply(text)
+	}
+	return box.Render(glyph.For(theme.Charset)) + " " + text.Render(label)
+}
+
+// AccessibleView returns the label and the state.
+func (m *Model) AccessibleView() string {
+	text := m.label + " (" + m.state.String() + ")"
+	if m.disabled {
+		text += " (disabled)"
+	}
+	return text
+}
+
+// --- State ---
+
+// ID returns the identifier carried by the checkbox's messages.
+func (m *Model) ID() ID {
+	return m.id
+}
+
+// State returns the state of the checkbox.
+func (m *Model) State() State {
+	return m.state
+}
+
+// SetState sets the state without sending a ChangeMsg.
+func (m *Model) SetState(state State) {
+	m.state = state
+	m.MarkDirty()
+}
+
+// Checked reports whether the checkbox is checked.
+func (m *Model) Checked() bool {
+	return m.state == Checked
+}
+
+// SetDisabled enables or disables the checkbox.
+func (m *Model) SetDisabled(disabled bool) {
+	m.disabled = disabled
+	m.MarkDirty()
+}
+
+// Close forgets the checkbox's zone. Call it when the checkbox is
+// discarded.
+func (m *Model) Close() {
+	tui.Zones.Remove(m.ZoneID())
+}
+
+func checkedState(checked bool) State {
+	if checked {
+		return Checked
+	}
+	return Unchecked
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.desiredWidth = width
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.desiredHeight = height
+	m.height = height
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
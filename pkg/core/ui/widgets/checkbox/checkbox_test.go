@@ -0,0 +1,221 @@
+package checkbox
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// last returns the last message of type T.
+func last[T tui.Msg](msgs []tui.Msg) (T, bool) {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msg, ok := msgs[i].(T); ok {
+			return msg, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// click presses and releases the left button on the zone.
+func click(t *testing.T, d *tuitest.Driver, zone tui.ZoneID) {
+	t.Helper()
+	r, ok := tui.Zones.Get(zone)
+	if !ok {
+		t.Fatal("the zone was not drawn")
+	}
+	d.Send(
+		tui.MouseMsg{X: r.X, Y: r.Y, Action: tui.MouseActionPress, Button: tui.MouseButtonLeft},
+		tui.MouseMsg{X: r.X, Y: r.Y, Action: tui.MouseActionRelease, Button: tui.MouseButtonLeft},
+	)
+}
+
+func TestCheckbox(t *testing.T) {
+	c := New("Send anonymous usage data")
+	t.Cleanup(c.Close)
+	c.Focus()
+	d := tuitest.New(t, c, tuitest.WithSize(30, 1))
+	d.Golden("unchecked")
+
+	d.Press(tui.KeySpace)
+	msg, ok := last[ChangeMsg](d.Messages())
+	if !ok || msg.State != Checked || msg.ID != c.ID() {
+		t.Fatalf("change = %+v, want checked", msg)
+	}
+	d.Golden("checked")
+
+	click(t, d, c.ZoneID())
+	if c.Checked() {
+		t.Error("a click did not uncheck the checkbox")
+	}
+	if got := tui.AccessibleText(c); got != "Send anonymous usage data (unchecked)" {
+		t.Errorf("AccessibleText = %q", got)
+	}
+}
+
+func TestCheckboxIgnoresInputWhenDisabledOrUnfocused(t *testing.T) {
+	c := New("Telemetry", WithDisabled(true))
+	t.Cleanup(c.Close)
+	d := tuitest.New(t, c, tuitest.WithSize(20, 1))
+	d.Press(tui.KeySpace)
+	click(t, d, c.ZoneID())
+
+	c.SetDisabled(false)
+	d.Press(tui.KeySpace)
+	if c.State() != Unchecked {
+		t.Errorf("state = %v, want unchecked", c.State())
+	}
+}
+
+func TestMixedToggle(t *testing.T) {
+	c := New("Group", WithState(Mixed))
+	t.Cleanup(c.Close)
+	c.Focus()
+	tuitest.New(t, c).Press(tui.KeySpace)
+	if c.State() != Checked {
+		t.Errorf("state = %v, want a mixed checkbox checked by a toggle", c.State())
+	}
+}
+
+func TestASCIIFallback(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+
+	c := New("Go", WithChecked(true))
+	t.Cleanup(c.Close)
+	if got := tui.StripZones(c.View()); got != "[x] Go" {
+		t.Errorf("view = %q, want the ASCII glyph", got)
+	}
+
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	if got := tui.StripZones(c.View()); got != "☑ Go" {
+		t.Errorf("view = %q, want the Unicode glyph", got)
+	}
+}
+
+// stack returns the tools of a stack, grouped by language.
+func stack() []Node {
+	return []Node{
+		NewNode("Go",
+			NewNode("golang").WithChecked(true),
+			NewNode("golangci-lint"),
+			NewNode("delve"),
+		),
+		NewNode("Node",
+			NewNode("node").WithChecked(true),
+			NewNode("pnpm").WithChecked(true),
+		),
+		NewNode("docker"),
+	}
+}
+
+func TestNodeState(t *testing.T) {
+	tests := []struct {
+		name string
+		node Node
+		want State
+	}{
+		{"unchecked item", NewNode("a"), Unchecked},
+		{"checked item", NewNode("a").WithChecked(true), Checked},
+		{"some items checked", NewNode("g", NewNode("a").WithChecked(true), NewNode("b")), Mixed},
+		{"all items checked", NewNode("g", NewNode("a").WithChecked(true), NewNode("b").WithChecked(true)), Checked},
+		{"no item checked", NewNode("g", NewNode("a"), NewNode("b")), Unchecked},
+		{"mixed subgroup", NewNode("g", NewNode("s", NewNode("a").WithChecked(true), NewNode("b"))), Mixed},
+		// A group's own flag is ignored: its items decide.
+		{"checked group of unchecked items", NewNode("g", NewNode("a")).WithChecked(true), Unchecked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node.State(); got != tt.want {
+				t.Errorf("State() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTree(t *testing.T) {
+	nodes := stack()
+	tree := NewTree(nodes)
+	t.Cleanup(tree.Close)
+	tree.Focus()
+	d := tuitest.New(t, tree, tuitest.WithSize(24, 8))
+	d.Golden("mixed")
+
+	// Toggling the mixed group checks all of its tools.
+	d.Press(tui.KeySpace)
+	msg, ok := last[TreeChangeMsg](d.Messages())
+	if !ok || msg.Node.Label != "Go" || msg.Node.State() != Checked {
+		t.Fatalf("change = %+v, want Go checked", msg)
+	}
+	if got := labels(msg.Checked); got != "golang golangci-lint delve node pnpm" {
+		t.Errorf("checked = %q", got)
+	}
+
+	// Unchecking one tool makes its group mixed again.
+	d.Press(tui.KeyDown, tui.KeyDown, tui.KeySpace)
+	d.Golden("item_unchecked")
+	if tree.Nodes()[0].State() != Mixed {
+		t.Errorf("Go = %v, want mixed", tree.Nodes()[0].State())
+	}
+
+	// The caller's nodes are left alone.
+	if nodes[0].Children[1].Checked {
+		t.Error("toggling wrote to the nodes given to NewTree")
+	}
+}
+
+func TestTreeClick(t *testing.T) {
+	tree := NewTree(stack())
+	t.Cleanup(tree.Close)
+	d := tuitest.New(t, tree, tuitest.WithSize(24, 8))
+
+	click(t, d, tree.rows[4].zone) // Node
+	if got := labels(tree.Checked()); got != "golang" {
+		t.Errorf("checked = %q, want the Node group unchecked", got)
+	}
+	if tree.cursor != 4 {
+		t.Errorf("cursor = %d, want the clicked row", tree.cursor)
+	}
+}
+
+func TestTreeScrolls(t *testing.T) {
+	// The tree takes the height of its slot.
+	tree := NewTree(stack())
+	t.Cleanup(tree.Close)
+	tree.Focus()
+	d := tuitest.New(t, tree, tuitest.WithSize(24, 3))
+	d.Press(tui.KeyEnd)
+	d.Golden("end")
+
+	// A taller slot brings the rows above back into view.
+	d.Resize(24, 6)
+	if tree.offset != len(tree.rows)-6 {
+		t.Errorf("offset = %d after growing, want %d", tree.offset, len(tree.rows)-6)
+	}
+	if lines := strings.Count(tree.View(), "\n") + 1; lines != 6 {
+		t.Errorf("%d lines in a slot of 6", lines)
+	}
+}
+
+func TestCheckboxFitsSlot(t *testing.T) {
+	c := New("Send anonymous usage data")
+	t.Cleanup(c.Close)
+	tuitest.New(t, c, tuitest.WithSize(12, 1))
+	if got := tui.Width(c.View()); got > 12 {
+		t.Errorf("view is %d cells wide in a slot of 12", got)
+	}
+}
+
+func labels(nodes []Node) string {
+	var s string
+	for i, node := range nodes {
+		if i > 0 {
+			s += " "
+		}
+		s += node.Label
+	}
+	return s
+}
@@ -0,0 +1,418 @@
+package checkbox
+
+import (
+	"strings"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Tree implements the tui.Component interface.
+var _ tui.Component = (*Tree)(nil)
+var _ tui.Focusable = (*Tree)(nil)
+var _ tui.Accessible = (*Tree)(nil)
+
+// Node is an item of a Tree, or a group of items when it has children.
+type Node struct {
+	Label string
+	// Value is free for the caller, e.g. the tool the item installs.
+	Value any
+	// Checked is the state of an item. A group is checked when all of its
+	// items are, and mixed when only some are.
+	Checked  bool
+	Children []Node
+}
+
+// NewNode creates an item, or a group of the given children.
+func NewNode(label string, children ...Node) Node {
+	return Node{Label: label, Children: children}
+}
+
+// WithChecked returns the item checked or not.
+func (n Node) WithChecked(checked bool) Node {
+	n.Checked = checked
+	return n
+}
+
+// WithValue returns the node with the given value.
+func (n Node) WithValue(value any) Node {
+	n.Value = value
+	return n
+}
+
+// State returns the state of the node, derived from its items for groups.
+func (n Node) State() State {
+	if len(n.Children) == 0 {
+		return checkedState(n.Checked)
+	}
+	var checked, unchecked bool
+	for _, child := range n.Children {
+		switch child.State() {
+		case Checked:
+			checked = true
+		case Unchecked:
+			unchecked = true
+		default:
+			return Mixed
+		}
+	}
+	switch {
+	case checked && unchecked:
+		return Mixed
+	case checked:
+		return Checked
+	default:
+		return Unchecked
+	}
+}
+
+// setChecked checks or unchecks the node and all of its items.
+func (n *Node) setChecked(checked bool) {
+	n.Checked = checked
+	for i := range n.Children {
+		n.Children[i].setChecked(checked)
+	}
+}
+
+// cloneNodes returns a deep copy of nodes, so toggling does not write to
+// the caller's nodes.
+func cloneNodes(nodes []Node) []Node {
+	if nodes == nil {
+		return nil
+	}
+	clone := make([]Node, len(nodes))
+	for i, node := range nodes {
+		node.Children = cloneNodes(node.Children)
+		clone[i] = node
+	}
+	return clone
+}
+
+// appendChecked appends the checked items under n to items.
+func (n Node) appendChecked(items []Node) []Node {
+	if len(n.Children) == 0 {
+		if n.Checked {
+			items = append(items, n)
+		}
+		return items
+	}
+	for _, child := range n.Children {
+		items = child.appendChecked(items)
+	}
+	return items
+}
+
+// TreeChangeMsg is sent when the user toggles a node of a Tree. Checked
+// holds every checked item afterwards, in tree order.
+type TreeChangeMsg struct {
+	ID      ID
+	Node    Node
+	Checked []Node
+}
+
+// TreeOption is a functional option for configuring the Tree.
+type TreeOption func(*Tree)
+
+// treeRow is a node drawn on its own line.
+type treeRow struct {
+	path  []int
+	depth int
+	zone  tui.ZoneID
+}
+
+// Tree is a list of checkboxes in groups, e.g. the tools of a stack.
+type Tree struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+
+	id    ID
+	nodes []Node
+	rows  []treeRow
+
+	// cursor indexes rows; offset is the first row in view.
+	cursor int
+	offset int
+
+	style tui.Style
+}
+
+// NewTree creates a new Tree of the given nodes.
+func NewTree(nodes []Node, opts ...TreeOption) *Tree {
+	m := &Tree{
+		id:    ID(lastID.Add(1)),
+		style: tui.NewStyle(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.SetNodes(nodes)
+
+	return m
+}
+
+// --- Functional Options ---
+
+func WithTreeBackgroundColor(color string) TreeOption {
+	return func(m *Tree) { m.BackgroundColor(color) }
+}
+
+func WithTreeBorder(border tui.Border, sides ...bool) TreeOption {
+	return func(m *Tree) { m.Border(border, sides...) }
+}
+
+func WithTreePadding(p ...int) TreeOption {
+	return func(m *Tree) { m.Padding(p...) }
+}
+
+func WithTreeWidth(width int) TreeOption {
+	return func(m *Tree) { m.Width(width) }
+}
+
+func WithTreeHeight(height int) TreeOption {
+	return func(m *Tree) { m.Height(height) }
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Tree) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Tree) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		}
+		m.moveTo(m.cursor)
+
+	case tui.KeyMsg:
+		if !m.Focused() {
+			return m, nil
+		}
+		switch {
+		case tui.Keys.Matches(msg, actionToggle):
+			return m, m.toggle(m.cursor)
+		case tui.Keys.Matches(msg, actionUp):
+			m.moveTo(m.cursor - 1)
+		case tui.Keys.Matches(msg, actionDown):
+			m.moveTo(m.cursor + 1)
+		case tui.Keys.Matches(msg, actionHome):
+			m.moveTo(0)
+		case tui.Keys.Matches(msg, actionEnd):
+			m.moveTo(len(m.rows) - 1)
+		}
+
+	case clickMsg:
+		if msg.id == m.id && msg.item < len(m.rows) {
+			m.moveTo(msg.item)
+			return m, m.toggle(msg.item)
+		}
+	}
+	return m, nil
+}
+
+// moveTo moves the cursor, clamped to the rows, and scrolls to it.
+func (m *Tree) moveTo(cursor int) {
+	m.cursor = min(max(cursor, 0), max(len(m.rows)-1, 0))
+	if rows := m.visibleRows(); rows > 0 {
+		m.offset = min(m.offset, m.cursor)
+		m.offset = max(m.offset, m.cursor-rows+1)
+		// No empty rows at the bottom while there are rows above.
+		m.offset = max(min(m.offset, len(m.rows)-rows), 0)
+	} else {
+		m.offset = 0
+	}
+	m.MarkDirty()
+}
+
+// toggle checks or unchecks the node at row i with all of its items, and
+// reports the change.
+func (m *Tree) toggle(i int) tui.Cmd {
+	if i >= len(m.rows) {
+		return nil
+	}
+	node := m.node(m.rows[i].path)
+	node.setChecked(node.State().toggled() == Checked)
+	m.MarkDirty()
+
+	msg := TreeChangeMsg{ID: m.id, Node: *node, Checked: m.Checked()}
+	return func() tui.Msg { return msg }
+}
+
+// node returns the node at path.
+func (m *Tree) node(path []int) *Node {
+	node := &m.nodes[path[0]]
+	for _, i := range path[1:] {
+		node = &node.Children[i]
+	}
+	return node
+}
+
+// visibleRows returns the number of rows in view; 0 means all of them.
+func (m *Tree) visibleRows() int {
+	if m.height <= 0 {
+		return 0
+	}
+	_, vFrame := m.style.GetFrameSize()
+	return max(m.height-vFrame, 1)
+}
+
+func (m *Tree) View() string {
+	m.bindZones()
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// bindZones registers the click handlers of the rows the first time the
+// tree is drawn, so a tree that is never shown leaves nothing in the zone
+// map.
+func (m *Tree) bindZones() {
+	for item, row := range m.rows {
+		if tui.Zones.Registered(row.zone) {
+			continue
+		}
+		tui.Zones.OnClick(row.zone, func() tui.Cmd {
+			id := m.id
+			return func() tui.Msg { return clickMsg{id: id, item: item} }
+		})
+	}
+}
+
+// render draws the rows in view, items indented under their group; View
+// caches the result.
+func (m *Tree) render() string {
+	indent := designsystem.Current().Components.Checkbox.Indent
+	hFrame, _ := m.style.GetFrameSize()
+
+	end := len(m.rows)
+	if rows := m.visibleRows(); rows > 0 {
+		end = min(m.offset+rows, end)
+	}
+	lines := make([]string, 0, end-m.offset)
+	for i := m.offset; i < end; i++ {
+		row := m.rows[i]
+		node := m.node(row.path)
+		line := strings.Repeat(" ", row.depth*indent) +
+			renderItem(node.State(), node.Label, m.Focused() && i == m.cursor, false)
+		if m.width > 0 {
+			line = tui.Truncate(line, max(m.width-hFrame, 0), "…")
+		}
+		lines = append(lines, tui.MarkZone(row.zone, line))
+	}
+	return m.style.Render(strings.Join(lines, "\n"))
+}
+
+// AccessibleView returns the nodes with their states, indented by level,
+// the cursor marked with "> ".
+func (m *Tree) AccessibleView() string {
+	lines := make([]string, len(m.rows))
+	for i, row := range m.rows {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		node := m.node(row.path)
+		lines[i] = prefix + strings.Repeat("  ", row.depth) + node.Label + " (" + node.State().String() + ")"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// --- State ---
+
+// ID returns the identifier carried by the tree's messages.
+func (m *Tree) ID() ID {
+	return m.id
+}
+
+// Nodes returns the nodes with their current states.
+func (m *Tree) Nodes() []Node {
+	return m.nodes
+}
+
+// SetNodes replaces the nodes. The tree works on a copy of them.
+func (m *Tree) SetNodes(nodes []Node) {
+	m.Close()
+	m.nodes = cloneNodes(nodes)
+	nodes = m.nodes
+	m.rows = m.rows[:0]
+	var walk func(nodes []Node, path []int)
+	walk = func(nodes []Node, path []int) {
+		for i, node := range nodes {
+			nodePath := append(path[:len(path):len(path)], i)
+			row := treeRow{path: nodePath, depth: len(path), zone: tui.NewZoneID()}
+			m.rows = append(m.rows, row)
+			walk(node.Children, nodePath)
+		}
+	}
+	walk(nodes, nil)
+	m.moveTo(m.cursor)
+}
+
+// Checked returns the checked items, in tree order.
+func (m *Tree) Checked() []Node {
+	var items []Node
+	for _, node := range m.nodes {
+		items = node.appendChecked(items)
+	}
+	return items
+}
+
+// Close forgets the zones of the tree's rows. Call it when the tree is
+// discarded.
+func (m *Tree) Close() {
+	for _, row := range m.rows {
+		tui.Zones.Remove(row.zone)
+	}
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Tree) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Tree) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Tree) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Tree) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Tree) Width(width int) tui.Component {
+	m.desiredWidth = width
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Tree) Height(height int) tui.Component {
+	m.desiredHeight = height
+	m.height = height
+	m.moveTo(m.cursor)
+	return m
+}
+
+func (m *Tree) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
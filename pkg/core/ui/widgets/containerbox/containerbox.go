@@ -2,11 +2,16 @@ package containerbox
 
 import (
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
 )
 
 // Ensure Model implements the ContainerBox and tui.Layout interfaces.
 var _ ContainerBox = (*Model)(nil)
 var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+var _ tui.KeyHandler = (*Model)(nil)
+var _ tui.Disposable = (*Model)(nil)
 
 // ContainerBox is the interface for a screen that can be loaded into the Scaffold.
 // It is an alias for tui.Model.
@@ -22,7 +27,12 @@ type Option func(*Model)
 type Model struct {
 	width, height int
 	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
 	style tui.Style
+
+	// content is the screen shown inside the box; nil shows a placeholder.
+	content tui.Model
 }
 
 // New creates a new ContainerBox with the given options.
@@ -68,9 +78,18 @@ func WithAlign(pos tui.Position) Option {
 	return func(m *Model) { m.Align(pos) }
 }
 
+// WithContent sets the model shown inside the box. It is sized to the
+// box's content area and gets the messages that reach the box.
+func WithContent(content tui.Model) Option {
+	return func(m *Model) { m.content = content }
+}
+
 // --- tui.Model Implementation ---
 
 func (m *Model) Init() tui.Cmd {
+	if m.content != nil {
+		return m.content.Init()
+	}
 	return nil
 }
 
@@ -95,39 +114,94 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 		}
 
 		// No longer subtracting GetFrameSize here. The style's Width/Height will handle it.
+
+		// The content gets the area inside the box's frame.
+		hFrame, vFrame := m.style.GetFrameSize()
+		return m, m.updateContent(tui.WindowSizeMsg{Width: max(m.width-hFrame, 0), Height: max(m.height-vFrame, 0)})
 	}
-	return m, nil
+	return m, m.updateContent(msg)
+}
+
+// HandlesKey reports whether the content uses msg itself, so the Scaffold
+// leaves it the keys it would otherwise take, such as Tab.
+func (m *Model) HandlesKey(msg tui.KeyMsg) bool {
+	return m.content != nil && tui.HandlesKey(m.content, msg)
+}
+
+// Dispose disposes the content, when it holds resources of its own.
+func (m *Model) Dispose() {
+	if content, ok := m.content.(tui.Disposable); ok {
+		content.Dispose()
+	}
+}
+
+// updateContent delivers msg to the content, if any.
+func (m *Model) updateContent(msg tui.Msg) tui.Cmd {
+	if m.content == nil {
+		return nil
+	}
+	content, cmd := m.content.Update(msg)
+	m.content = content
+	return cmd
 }
 
 func (m *Model) View() string {
+	key := tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}
+	body := defaultContent
+	if m.content != nil {
+		body = m.content.View()
+		key.Parts = []string{body}
+	}
+	return m.CachedView(key, func() string { return m.render(body) })
+}
+
+// render draws body inside the box; View caches the result.
+func (m *Model) render(body string) string {
 	// Calculate content dimensions based on total width/height and frame size
 	hFrame, vFrame := m.style.GetFrameSize()
 	contentWidth := m.width - hFrame
 	contentHeight := m.height - vFrame
 
+	style := designsystem.FocusBorder(m.style, m.Focused())
+
 	// Render the content with the calculated content dimensions
-	return m.style.Width(contentWidth).Height(contentHeight).Render("Default ContainerBox")
+	return style.Width(contentWidth).Height(contentHeight).Render(body)
+}
+
+// defaultContent is shown until the box gets content of its own.
+const defaultContent = "Default ContainerBox"
+
+// AccessibleView returns the box's content without its frame.
+func (m *Model) AccessibleView() string {
+	if m.content != nil {
+		return tui.AccessibleText(m.content)
+	}
+	return defaultContent
 }
 
 // --- tui.Layout Implementation ---
 
 func (m *Model) BackgroundColor(color string) tui.Layout {
-	m.style.Background(color)
+	m.style = m.style.Background(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
-	m.style.Border(border, sides...)
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) BorderForeground(color string) tui.Layout {
-	m.style.BorderForeground(color)
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Padding(p ...int) tui.Layout {
-	m.style.Padding(p...)
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
 	return m
 }
 
@@ -144,6 +218,7 @@ func (m *Model) Height(height int) tui.Layout {
 }
 
 func (m *Model) Align(pos tui.Position) tui.Layout {
-	m.style.Align(pos)
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
 	return m
 }
@@ -0,0 +1,54 @@
+package dialog
+
+import (
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+// Ensure Alert implements the tui.Model interface.
+var _ tui.Model = (*Alert)(nil)
+var _ tui.Accessible = (*Alert)(nil)
+var _ tui.Disposable = (*Alert)(nil)
+
+// AlertClosedMsg is delivered when an alert is dismissed.
+type AlertClosedMsg struct {
+	ID ID
+}
+
+// Alert shows a message with a single "OK" button. Enter and Esc dismiss it.
+type Alert struct {
+	dialog
+}
+
+// NewAlert creates an alert dialog.
+func NewAlert(message string, opts ...Option) *Alert {
+	a := &Alert{dialog: newDialog(message, []string{"OK"}, 0, opts)}
+	a.bind(a, func(int) tui.Cmd {
+		return func() tui.Msg { return AlertClosedMsg{ID: a.id} }
+	})
+	return a
+}
+
+// Open returns the command that shows the dialog.
+func (a *Alert) Open() tui.Cmd {
+	return a.open()
+}
+
+// --- tui.Model Implementation ---
+
+func (a *Alert) Init() tui.Cmd {
+	a.init()
+	return nil
+}
+
+func (a *Alert) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	return a, a.update(msg)
+}
+
+func (a *Alert) View() string {
+	return a.view()
+}
+
+// AccessibleView returns the alert as plain text.
+func (a *Alert) AccessibleView() string {
+	return a.accessible()
+}
@@ -0,0 +1,65 @@
+package dialog
+
+import (
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+// Ensure Confirm implements the tui.Model interface.
+var _ tui.Model = (*Confirm)(nil)
+var _ tui.Accessible = (*Confirm)(nil)
+var _ tui.Disposable = (*Confirm)(nil)
+
+// ConfirmResultMsg is delivered when a confirm dialog is answered.
+type ConfirmResultMsg struct {
+	ID        ID
+	Confirmed bool
+}
+
+// Confirm asks a yes/no question. Enter answers with the focused button,
+// y and n answer directly and Esc answers no.
+type Confirm struct {
+	dialog
+}
+
+// NewConfirm creates a confirm dialog with "Yes" and "No" buttons; "Yes"
+// starts focused.
+func NewConfirm(message string, opts ...Option) *Confirm {
+	c := &Confirm{dialog: newDialog(message, []string{"Yes", "No"}, 1, opts)}
+	c.bind(c, func(i int) tui.Cmd {
+		return func() tui.Msg { return ConfirmResultMsg{ID: c.id, Confirmed: i == 0} }
+	})
+	return c
+}
+
+// Open returns the command that shows the dialog.
+func (c *Confirm) Open() tui.Cmd {
+	return c.open()
+}
+
+// --- tui.Model Implementation ---
+
+func (c *Confirm) Init() tui.Cmd {
+	c.init()
+	return nil
+}
+
+func (c *Confirm) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if key, ok := msg.(tui.KeyMsg); ok && key.Type == tui.KeyRunes && !key.Alt && !key.Ctrl {
+		switch string(key.Runes) {
+		case "y", "Y":
+			return c, c.answer(0)
+		case "n", "N":
+			return c, c.answer(1)
+		}
+	}
+	return c, c.update(msg)
+}
+
+func (c *Confirm) View() string {
+	return c.view()
+}
+
+// AccessibleView returns the question and its answers as plain text.
+func (c *Confirm) AccessibleView() string {
+	return c.accessible()
+}
@@ -0,0 +1,253 @@
+// Package dialog provides the confirm, alert and prompt modals.
+//
+// A dialog opens as an overlay on top of the current screen, captures the
+// input until it is answered and then closes itself and delivers a result
+// message carrying its ID:
+//
+//	d := dialog.NewConfirm("Install 12 tools?", dialog.WithTitle("Install"))
+//	m.pending = d.ID()
+//	return m, d.Open()
+//
+//	case dialog.ConfirmResultMsg:
+//		if msg.ID == m.pending && msg.Confirmed { ... }
+package dialog
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/overlay"
+)
+
+// ID identifies a dialog in its result message.
+type ID int64
+
+var lastID atomic.Int64
+
+// Option is a functional option for configuring a dialog.
+type Option func(*dialog)
+
+// Default sizes of the dialog box.
+const (
+	minWidth = 30
+	maxWidth = 60
+)
+
+// dialog is the frame shared by every dialog kind: a bordered box with a
+// title, a message and a row of buttons, one of which has focus.
+type dialog struct {
+	id      ID
+	title   string
+	message string
+	danger  bool
+
+	labels  []string
+	zones   []tui.ZoneID
+	focused int
+	cancel  int // button chosen by Esc
+
+	// choose builds the result of pressing the button at index i.
+	choose func(i int) tui.Cmd
+	// self is the model shown in the overlay, closed once answered.
+	self tui.Model
+
+	// Prompt only: the initial value and the text shown while it is empty.
+	initial     string
+	placeholder string
+
+	width, height int
+}
+
+// newDialog creates the shared frame. labels are the default button labels;
+// cancel is the index of the button chosen by Esc.
+func newDialog(message string, labels []string, cancel int, opts []Option) dialog {
+	d := dialog{
+		id:      ID(lastID.Add(1)),
+		message: message,
+		labels:  labels,
+		cancel:  cancel,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d
+}
+
+// --- Functional Options ---
+
+// WithTitle sets the title shown at the top of the dialog.
+func WithTitle(title string) Option {
+	return func(d *dialog) { d.title = title }
+}
+
+// WithLabels replaces the button labels, e.g. "Install" and "Skip" for a
+// confirm dialog. Missing labels keep their default.
+func WithLabels(labels ...string) Option {
+	return func(d *dialog) {
+		for i, label := range labels {
+			if i < len(d.labels) {
+				d.labels[i] = label
+			}
+		}
+	}
+}
+
+// WithDanger styles the dialog for destructive actions.
+func WithDanger() Option {
+	return func(d *dialog) { d.danger = true }
+}
+
+// WithValue sets the initial value of a prompt.
+func WithValue(value string) Option {
+	return func(d *dialog) { d.initial = value }
+}
+
+// WithPlaceholder sets the text a prompt shows while its value is empty.
+func WithPlaceholder(placeholder string) Option {
+	return func(d *dialog) { d.placeholder = placeholder }
+}
+
+// --- Shared Behavior ---
+
+// ID returns the identifier carried by the dialog's result message.
+func (d *dialog) ID() ID {
+	return d.id
+}
+
+// bind sets model as the dialog shown in the overlay and reserves the zones
+// of its buttons. Their click handlers are registered while the dialog is
+// open.
+func (d *dialog) bind(model tui.Model, choose func(i int) tui.Cmd) {
+	d.self = model
+	d.choose = choose
+	d.zones = make([]tui.ZoneID, len(d.labels))
+	for i := range d.labels {
+		d.zones[i] = tui.NewZoneID()
+	}
+}
+
+// init registers the button click handlers. The overlay stack runs it,
+// through Init, when the dialog opens.
+func (d *dialog) init() {
+	for i, id := range d.zones {
+		tui.Zones.OnClick(id, func() tui.Cmd { return d.answer(i) })
+	}
+}
+
+// Dispose removes the button zones. The overlay stack calls it when the
+// dialog closes.
+func (d *dialog) Dispose() {
+	for _, id := range d.zones {
+		tui.Zones.Remove(id)
+	}
+}
+
+// open returns the command that shows the dialog as a modal overlay.
+func (d *dialog) open() tui.Cmd {
+	return overlay.Open(d.self, overlay.WithScrim(true))
+}
+
+// answer closes the dialog and then delivers the result of button i.
+func (d *dialog) answer(i int) tui.Cmd {
+	return tui.Sequence(overlay.Close(d.self), d.choose(i))
+}
+
+// update handles the keys common to all dialogs: moving between buttons,
+// Enter to press the focused one and Esc to cancel.
+func (d *dialog) update(msg tui.Msg) tui.Cmd {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		d.width, d.height = msg.Width, msg.Height
+
+	case tui.KeyMsg:
+		switch msg.Type {
+		case tui.KeyLeft:
+			d.focused = max(d.focused-1, 0)
+		case tui.KeyRight:
+			d.focused = min(d.focused+1, len(d.labels)-1)
+		case tui.KeyTab:
+			n := len(d.labels)
+			if msg.Shift {
+				d.focused = (d.focused + n - 1) % n
+			} else {
+				d.focused = (d.focused + 1) % n
+			}
+		case tui.KeyEnter:
+			return d.answer(d.focused)
+		case tui.KeyEsc:
+			return d.answer(d.cancel)
+		}
+	}
+	return nil
+}
+
+// accessible returns the dialog as plain text: the title, the message,
+// extra lines and the buttons, the focused one in brackets.
+func (d *dialog) accessible(extra ...string) string {
+	var lines []string
+	if d.title != "" {
+		lines = append(lines, d.title)
+	}
+	lines = append(lines, d.message)
+	lines = append(lines, extra...)
+
+	buttons := make([]string, len(d.labels))
+	for i, label := range d.labels {
+		if i == d.focused {
+			buttons[i] = "[" + label + "]"
+		} else {
+			buttons[i] = label
+		}
+	}
+	return strings.Join(append(lines, strings.Join(buttons, "  ")), "\n")
+}
+
+// view renders the dialog box around extra, the lines specific to the
+// dialog kind (e.g. a prompt's input), placed below the message.
+func (d *dialog) view(extra ...string) string {
+	theme := designsystem.Current()
+	palette := theme.Palette
+
+	accent := palette.Primary
+	if d.danger {
+		accent = palette.Danger
+	}
+
+	buttons := make([]string, len(d.labels))
+	for i, label := range d.labels {
+		style := tui.NewStyle().Padding(0, 1).Foreground(palette.TextMuted)
+		if i == d.focused {
+			style = theme.State.Focus.Apply(style.Bold(true))
+		}
+		buttons[i] = tui.MarkZone(d.zones[i], style.Render("[ "+label+" ]"))
+	}
+	buttonRow := tui.JoinHorizontal(tui.Top, buttons...)
+
+	width := max(minWidth, tui.Width(d.title), tui.Width(buttonRow))
+	for _, line := range strings.Split(d.message, "\n") {
+		width = max(width, tui.Width(line))
+	}
+	width = min(width, maxWidth)
+	if d.width > 0 {
+		// Leave room for the border and padding on small screens.
+		width = min(width, max(d.width-4, 1))
+	}
+
+	text := tui.NewStyle().Width(width).Foreground(palette.Text)
+	lines := []string{}
+	if d.title != "" {
+		lines = append(lines, text.Bold(true).Foreground(accent).Render(d.title), "")
+	}
+	lines = append(lines, text.Render(d.message))
+	lines = append(lines, extra...)
+	lines = append(lines, "", tui.NewStyle().Width(width).Align(tui.Right).Render(buttonRow))
+
+	return tui.NewStyle().
+		Border(tui.RoundedBorder).
+		BorderForeground(accent).
+		Background(palette.Surface).
+		Padding(0, 1).
+		Render(tui.JoinVertical(tui.Left, lines...))
+}
@@ -0,0 +1,133 @@
+package dialog
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/overlay"
+)
+
+// lastMsg returns the last message of type T delivered to the driver.
+func lastMsg[T any](d *tuitest.Driver) (T, bool) {
+	var found T
+	ok := false
+	for _, msg := range d.Messages() {
+		if m, is := msg.(T); is {
+			found, ok = m, true
+		}
+	}
+	return found, ok
+}
+
+func TestConfirmAnswers(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []tui.Msg
+		want bool
+	}{
+		{"enter on yes", []tui.Msg{tui.KeyMsg{Type: tui.KeyEnter}}, true},
+		{"right then enter", []tui.Msg{tui.KeyMsg{Type: tui.KeyRight}, tui.KeyMsg{Type: tui.KeyEnter}}, false},
+		{"tab wraps back to yes", []tui.Msg{tui.KeyMsg{Type: tui.KeyTab}, tui.KeyMsg{Type: tui.KeyTab}, tui.KeyMsg{Type: tui.KeyEnter}}, true},
+		{"y", []tui.Msg{tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune("y")}}, true},
+		{"n", []tui.Msg{tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune("n")}}, false},
+		{"esc", []tui.Msg{tui.KeyMsg{Type: tui.KeyEsc}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConfirm("Install 12 tools?")
+			d := tuitest.New(t, c).Send(tt.keys...)
+
+			result, ok := lastMsg[ConfirmResultMsg](d)
+			if !ok {
+				t.Fatal("no ConfirmResultMsg delivered")
+			}
+			if result.ID != c.ID() || result.Confirmed != tt.want {
+				t.Errorf("result = %+v, want ID %d confirmed %v", result, c.ID(), tt.want)
+			}
+			if closed, ok := lastMsg[overlay.CloseMsg](d); !ok || closed.Model != c {
+				t.Error("the dialog did not close its overlay")
+			}
+		})
+	}
+}
+
+func TestConfirmClick(t *testing.T) {
+	c := NewConfirm("Remove node?", WithLabels("Remove", "Keep"), WithDanger())
+	t.Cleanup(c.Dispose)
+	d := tuitest.New(t, c, tuitest.WithSize(50, 10))
+
+	r, ok := tui.Zones.Get(c.zones[1])
+	if !ok {
+		t.Fatal("the Keep button was not drawn")
+	}
+	d.Send(
+		tui.MouseMsg{X: r.X, Y: r.Y, Action: tui.MouseActionPress, Button: tui.MouseButtonLeft},
+		tui.MouseMsg{X: r.X, Y: r.Y, Action: tui.MouseActionRelease, Button: tui.MouseButtonLeft},
+	)
+
+	result, ok := lastMsg[ConfirmResultMsg](d)
+	if !ok || result.Confirmed {
+		t.Errorf("result = %+v (delivered %v), want declined", result, ok)
+	}
+}
+
+func TestDialogZonesLiveWhileOpen(t *testing.T) {
+	c := NewConfirm("Install 12 tools?")
+	registered := func() bool {
+		for _, id := range c.zones {
+			if !tui.Zones.Registered(id) {
+				return false
+			}
+		}
+		return true
+	}
+	if tui.Zones.Registered(c.zones[0]) {
+		t.Fatal("a dialog that was never opened registered its buttons")
+	}
+
+	stack := overlay.NewStack()
+	stack.Update(c.Open()())
+	if !registered() {
+		t.Fatal("the open dialog has no click handlers")
+	}
+
+	stack.Update(overlay.Close(c)())
+	for _, id := range c.zones {
+		if tui.Zones.Registered(id) {
+			t.Errorf("zone %d still registered after the dialog closed", id)
+		}
+	}
+}
+
+func TestPromptValue(t *testing.T) {
+	p := NewPrompt("Node version?", WithValue("2"), WithPlaceholder("e.g. 22"))
+	d := tuitest.New(t, p, tuitest.WithSize(50, 12))
+	d.Type("0.x").Press(tui.KeyBackspace).Send(tui.PasteMsg{Text: "1"})
+	d.Golden("typed")
+	d.Press(tui.KeyEnter)
+
+	result, ok := lastMsg[PromptResultMsg](d)
+	if !ok || result.Value != "20.1" || result.Cancelled {
+		t.Errorf("result = %+v (delivered %v), want value 20.1", result, ok)
+	}
+
+	p = NewPrompt("Node version?", WithValue("20"))
+	d = tuitest.New(t, p).Press(tui.KeyEsc)
+	result, _ = lastMsg[PromptResultMsg](d)
+	if !result.Cancelled || result.Value != "" {
+		t.Errorf("cancelled result = %+v", result)
+	}
+}
+
+func TestAlertDismiss(t *testing.T) {
+	a := NewAlert("Installed.", WithTitle("Done"))
+	d := tuitest.New(t, a, tuitest.WithSize(50, 10))
+	d.Golden("alert")
+	d.Press(tui.KeyEsc)
+
+	if result, ok := lastMsg[AlertClosedMsg](d); !ok || result.ID != a.ID() {
+		t.Errorf("result = %+v (delivered %v)", result, ok)
+	}
+}
@@ -0,0 +1,97 @@
+package dialog
+
+import (
+	"strings"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Prompt implements the tui.Model interface.
+var _ tui.Model = (*Prompt)(nil)
+var _ tui.Accessible = (*Prompt)(nil)
+var _ tui.Disposable = (*Prompt)(nil)
+
+// PromptResultMsg is delivered when a prompt is answered. Value is empty
+// when the prompt was cancelled.
+type PromptResultMsg struct {
+	ID        ID
+	Value     string
+	Cancelled bool
+}
+
+// Prompt asks for a line of text. Typing edits the value, Enter answers
+// with the focused button and Esc cancels.
+type Prompt struct {
+	dialog
+	value []rune
+}
+
+// NewPrompt creates a prompt dialog with "OK" and "Cancel" buttons.
+func NewPrompt(message string, opts ...Option) *Prompt {
+	p := &Prompt{dialog: newDialog(message, []string{"OK", "Cancel"}, 1, opts)}
+	p.value = []rune(p.initial)
+	p.bind(p, func(i int) tui.Cmd {
+		result := PromptResultMsg{ID: p.id, Value: string(p.value)}
+		if i != 0 {
+			result = PromptResultMsg{ID: p.id, Cancelled: true}
+		}
+		return func() tui.Msg { return result }
+	})
+	return p
+}
+
+// Value returns the text typed so far.
+func (p *Prompt) Value() string {
+	return string(p.value)
+}
+
+// Open returns the command that shows the dialog.
+func (p *Prompt) Open() tui.Cmd {
+	return p.open()
+}
+
+// --- tui.Model Implementation ---
+
+func (p *Prompt) Init() tui.Cmd {
+	p.init()
+	return nil
+}
+
+func (p *Prompt) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.PasteMsg:
+		p.value = append(p.value, []rune(strings.ReplaceAll(msg.Text, "\n", " "))...)
+		return p, nil
+	case tui.KeyMsg:
+		switch {
+		case msg.Type == tui.KeyRunes && !msg.Alt && !msg.Ctrl:
+			p.value = append(p.value, msg.Runes...)
+			return p, nil
+		case msg.Type == tui.KeySpace:
+			p.value = append(p.value, ' ')
+			return p, nil
+		case msg.Type == tui.KeyBackspace:
+			if len(p.value) > 0 {
+				p.value = p.value[:len(p.value)-1]
+			}
+			return p, nil
+		}
+	}
+	return p, p.update(msg)
+}
+
+func (p *Prompt) View() string {
+	palette := designsystem.Current().Palette
+
+	field := tui.NewStyle().Foreground(palette.Text).Render(string(p.value) + "█")
+	if len(p.value) == 0 && p.placeholder != "" {
+		field = "█" + tui.NewStyle().Foreground(palette.TextMuted).Render(p.placeholder)
+	}
+	return p.view("", "> "+field)
+}
+
+// AccessibleView returns the prompt and its current value as plain text.
+func (p *Prompt) AccessibleView() string {
+	return p.accessible("> " + string(p.value))
+}
@@ -0,0 +1,243 @@
+// Package divider provides the Divider widget: a horizontal or vertical
+// line drawn with the theme's border sets.
+//
+// A divider fills the width (or height) of its slot unless a length is
+// set. Joined ends are drawn as tees, so a divider placed against a frame
+// meets it instead of stopping short of it:
+//
+//	rule := divider.New(divider.WithJoined(true, true)) // ├──────┤
+package divider
+
+import (
+	"strings"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Component interface.
+var _ tui.Component = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// Option is a functional option for configuring the Divider.
+type Option func(*Model)
+
+// Model is a horizontal or vertical line.
+type Model struct {
+	width, height int // set by Width and Height; 0 fills the slot
+	slot          tui.Rect
+	tui.ViewCache
+
+	vertical bool
+	// joinStart and joinEnd draw the ends as junctions with a frame.
+	joinStart, joinEnd bool
+	// set overrides the theme's border set when not nil.
+	set   *designsystem.BorderSet
+	color string
+
+	style tui.Style
+}
+
+// New creates a new horizontal Divider.
+func New(opts ...Option) *Model {
+	m := &Model{
+		style: tui.NewStyle(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// NewVertical creates a new vertical Divider.
+func NewVertical(opts ...Option) *Model {
+	return New(append([]Option{WithVertical(true)}, opts...)...)
+}
+
+// --- Functional Options ---
+
+// WithVertical draws the line top to bottom.
+func WithVertical(vertical bool) Option {
+	return func(m *Model) { m.vertical = vertical }
+}
+
+// WithJoined draws the start (left or top) and the end (right or bottom)
+// of the line as tees, for a divider drawn across the edges of a frame.
+func WithJoined(start, end bool) Option {
+	return func(m *Model) { m.joinStart, m.joinEnd = start, end }
+}
+
+// WithLength sets the length of the line, instead of filling the slot.
+func WithLength(length int) Option {
+	return func(m *Model) {
+		if m.vertical {
+			m.Height(length)
+		} else {
+			m.Width(length)
+		}
+	}
+}
+
+// WithBorderSet draws the line with set instead of the theme's.
+func WithBorderSet(set designsystem.BorderSet) Option {
+	return func(m *Model) { m.set = &set }
+}
+
+// WithForeground overrides the theme's line color.
+func WithForeground(color string) Option {
+	return func(m *Model) { m.BorderForeground(color) }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+// Update keeps the size of the slot, which the line fills.
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if msg, ok := msg.(tui.WindowSizeMsg); ok {
+		m.slot = tui.Rect{Width: msg.Width, Height: msg.Height}
+		m.MarkDirty()
+	}
+	return m, nil
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+}
+
+// render draws the line inside the padding; View caches the result.
+func (m *Model) render() string {
+	hFrame, vFrame := m.style.GetFrameSize()
+	theme := designsystem.Current()
+	set := m.BorderSet().For(theme.Charset)
+	if m.vertical {
+		length := max(m.size(m.height, m.slot.Height)-vFrame, 0)
+		return m.style.Render(m.Line(set, length, m.joinStart, m.joinEnd))
+	}
+	length := max(m.size(m.width, m.slot.Width)-hFrame, 0)
+	return m.style.Render(m.Line(set, length, m.joinStart, m.joinEnd))
+}
+
+// size returns the set size, or the slot's when none is set.
+func (m *Model) size(set, slot int) int {
+	if set > 0 {
+		return set
+	}
+	return slot
+}
+
+// Line draws the divider length cells long with set, in its color. The
+// ends are tees when joined. Containers that draw their own frame, such as
+// the CardBox, use it to lay the divider across their edges.
+func (m *Model) Line(set designsystem.BorderSet, length int, joinStart, joinEnd bool) string {
+	if length <= 0 {
+		return ""
+	}
+	cells := make([]string, length)
+	for i := range cells {
+		atStart, atEnd := i == 0 && joinStart, i == length-1 && joinEnd
+		switch {
+		case !atStart && !atEnd && m.vertical:
+			cells[i] = set.Vertical
+		case !atStart && !atEnd:
+			cells[i] = set.Horizontal
+		case m.vertical:
+			// A joined end meets a horizontal edge, running on both sides.
+			cells[i] = set.Junction(!atStart, true, !atEnd, true)
+		default:
+			cells[i] = set.Junction(true, !atEnd, true, !atStart)
+		}
+	}
+	sep := ""
+	if m.vertical {
+		sep = "\n"
+	}
+	return tui.NewStyle().Foreground(m.Color()).Render(strings.Join(cells, sep))
+}
+
+// AccessibleView returns nothing: a divider only separates what is around
+// it.
+func (m *Model) AccessibleView() string {
+	return ""
+}
+
+// --- State ---
+
+// Vertical reports whether the line is drawn top to bottom.
+func (m *Model) Vertical() bool {
+	return m.vertical
+}
+
+// Color returns the color the line is drawn in: its own, or the theme's.
+func (m *Model) Color() string {
+	if m.color != "" {
+		return m.color
+	}
+	return designsystem.Current().Components.Divider.Border.Color
+}
+
+// BorderSet returns the set the line is drawn with, before the charset
+// fallback.
+func (m *Model) BorderSet() designsystem.BorderSet {
+	if m.set != nil {
+		return *m.set
+	}
+	return designsystem.Current().Components.Divider.Border.Set
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+// Border draws the line with the glyphs of border; the sides are ignored.
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	set := designsystem.BorderSetOf(border)
+	m.set = &set
+	m.MarkDirty()
+	return m
+}
+
+// BorderForeground sets the line color.
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.color = color
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.width = width
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.height = height
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
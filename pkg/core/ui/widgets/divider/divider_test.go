@@ -0,0 +1,83 @@
+package divider
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestDivider(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	tests := []struct {
+		name string
+		m    *Model
+		want string
+	}{
+		{"horizontal", New(WithLength(5)), "─────"},
+		{"joined", New(WithLength(5), WithJoined(true, true)), "├───┤"},
+		{"joined start", New(WithLength(4), WithJoined(true, false)), "├───"},
+		{"vertical", NewVertical(WithLength(3)), "│\n│\n│"},
+		{"vertical joined", NewVertical(WithLength(3), WithJoined(true, true)), "┬\n│\n┴"},
+		{"double set", New(WithLength(4), WithJoined(true, true), WithBorderSet(designsystem.UnicodeDouble)), "╠══╣"},
+		{"tui border", New(WithLength(3)).Border(tui.DoubleBorder).(*Model), "═══"},
+		{"padded", New(WithLength(5), WithPadding(0, 1)), " ─── "},
+		{"no room", New(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi.Strip(tt.m.View()); got != tt.want {
+				t.Errorf("View() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDividerFillsSlot(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	h, v := New(), NewVertical()
+	h.Update(tui.WindowSizeMsg{Width: 6, Height: 3})
+	v.Update(tui.WindowSizeMsg{Width: 6, Height: 3})
+	if got := ansi.Strip(h.View()); got != "──────" {
+		t.Errorf("horizontal = %q, want the slot's width", got)
+	}
+	if got := ansi.Strip(v.View()); got != "│\n│\n│" {
+		t.Errorf("vertical = %q, want the slot's height", got)
+	}
+}
+
+func TestDividerASCIIFallback(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	if got := ansi.Strip(New(WithLength(5), WithJoined(true, true)).View()); got != "+---+" {
+		t.Errorf("View() = %q, want ASCII glyphs", got)
+	}
+}
+
+func TestJunction(t *testing.T) {
+	set := designsystem.UnicodeLight
+	tests := []struct {
+		up, right, down, left bool
+		want                  string
+	}{
+		{true, true, true, true, "┼"},
+		{false, true, true, true, "┬"},
+		{true, true, false, true, "┴"},
+		{true, true, true, false, "├"},
+		{true, false, true, true, "┤"},
+		{false, true, true, false, "┌"},
+		{false, false, true, true, "┐"},
+		{true, true, false, false, "└"},
+		{true, false, false, true, "┘"},
+		{true, false, true, false, "│"},
+		{false, true, false, true, "─"},
+	}
+
+	for _, tt := range tests {
+		if got := set.Junction(tt.up, tt.right, tt.down, tt.left); got != tt.want {
+			t.Errorf("Junction(%v, %v, %v, %v) = %q, want %q", tt.up, tt.right, tt.down, tt.left, got, tt.want)
+		}
+	}
+}
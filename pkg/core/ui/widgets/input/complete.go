@@ -0,0 +1,39 @@
+package input
+
+import "strings"
+
+// Completer returns suggestions for value. Suggestions that extend value
+// are shown inline after the cursor, the rest of them dimmed, and accepted
+// with Right or End; Ctrl+N and Ctrl+P cycle through them.
+type Completer func(value string) []string
+
+// Words returns a Completer suggesting the words that start with the value,
+// e.g. the names of the installable tools.
+func Words(words ...string) Completer {
+	return func(value string) []string {
+		if value == "" {
+			return nil
+		}
+		var matches []string
+		for _, word := range words {
+			if strings.HasPrefix(word, value) {
+				matches = append(matches, word)
+			}
+		}
+		return matches
+	}
+}
+
+// extensions returns the suggestions of complete that extend value.
+func extensions(complete Completer, value string) []string {
+	if complete == nil {
+		return nil
+	}
+	var matches []string
+	for _, s := range complete(value) {
+		if len(s) > len(value) && strings.HasPrefix(s, value) {
+			matches = append(matches, s)
+		}
+	}
+	return matches
+}
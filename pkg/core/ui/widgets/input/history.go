@@ -0,0 +1,48 @@
+package input
+
+import "slices"
+
+// History is the list of values submitted in a field, oldest first. Inputs
+// add to it on submit and browse it with Up and Down. Share one History
+// between the inputs of the same field (e.g. every "install" prompt) to
+// keep it across screens; persisting it is up to the caller.
+type History struct {
+	entries []string
+	limit   int
+}
+
+// NewHistory creates a history keeping the last limit entries (all of them
+// when limit is 0), starting with entries.
+func NewHistory(limit int, entries ...string) *History {
+	h := &History{limit: limit}
+	for _, entry := range entries {
+		h.Add(entry)
+	}
+	return h
+}
+
+// Add appends entry, unless it is empty or repeats the last entry.
+func (h *History) Add(entry string) {
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if h.limit > 0 && len(h.entries) > h.limit {
+		h.entries = slices.Delete(h.entries, 0, len(h.entries)-h.limit)
+	}
+}
+
+// Entries returns the entries, oldest first.
+func (h *History) Entries() []string {
+	return slices.Clone(h.entries)
+}
+
+// Len returns the number of entries.
+func (h *History) Len() int {
+	return len(h.entries)
+}
+
+// at returns the entry at i.
+func (h *History) at(i int) string {
+	return h.entries[i]
+}
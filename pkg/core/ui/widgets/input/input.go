@@ -0,0 +1,763 @@
+// Package input provides the single-line text Input widget.
+//
+// An Input edits a line of text with the usual readline keys, validates it
+// as it changes and reports it when Enter is pressed:
+//
+//	name := input.New(
+//		input.WithPlaceholder("tool@version"),
+//		input.WithValidator(validateTool),
+//		input.WithHistory(installHistory),
+//		input.WithCompleter(input.Words(tools...)),
+//		input.WithOnSubmit(func(value string) tui.Cmd { return m.install(value) }),
+//	)
+//
+// The validator's error is shown below the field, in place of the help
+// text, once the value was edited or submitted.
+package input
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.TextWidget interface.
+var _ tui.TextWidget = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+var _ tui.KeyHandler = (*Model)(nil)
+
+// keyScope is the keymap scope of the input actions.
+const keyScope = "input"
+
+// Input actions, active while an input has focus.
+var (
+	actionLeft       = tui.Keys.Register(keyScope, "input.left", "Move left", "left", "ctrl+b")
+	actionRight      = tui.Keys.Register(keyScope, "input.right", "Move right or accept suggestion", "right", "ctrl+f")
+	actionWordLeft   = tui.Keys.Register(keyScope, "input.word_left", "Previous word", "ctrl+left", "alt+left", "alt+b")
+	actionWordRight  = tui.Keys.Register(keyScope, "input.word_right", "Next word", "ctrl+right", "alt+right", "alt+f")
+	actionHome       = tui.Keys.Register(keyScope, "input.home", "Start of line", "home", "ctrl+a")
+	actionEnd        = tui.Keys.Register(keyScope, "input.end", "End of line or accept suggestion", "end", "ctrl+e")
+	actionBackspace  = tui.Keys.Register(keyScope, "input.backspace", "Delete left", "backspace", "ctrl+h")
+	actionDelete     = tui.Keys.Register(keyScope, "input.delete", "Delete right", "delete")
+	actionDeleteWord = tui.Keys.Register(keyScope, "input.delete_word", "Delete previous word", "ctrl+w", "alt+backspace")
+	actionDeleteNext = tui.Keys.Register(keyScope, "input.delete_next_word", "Delete next word", "alt+d", "alt+delete")
+	actionKillStart  = tui.Keys.Register(keyScope, "input.delete_to_start", "Delete to start", "ctrl+u")
+	actionKillEnd    = tui.Keys.Register(keyScope, "input.delete_to_end", "Delete to end", "ctrl+k")
+	actionPaste      = tui.Keys.Register(keyScope, "input.paste", "Paste", "ctrl+v")
+	actionPrev       = tui.Keys.Register(keyScope, "input.history_prev", "Previous entry", "up")
+	actionNext       = tui.Keys.Register(keyScope, "input.history_next", "Next entry", "down")
+	actionSuggNext   = tui.Keys.Register(keyScope, "input.suggestion_next", "Next suggestion", "ctrl+n")
+	actionSuggPrev   = tui.Keys.Register(keyScope, "input.suggestion_prev", "Previous suggestion", "ctrl+p")
+	actionComplete   = tui.Keys.Register(keyScope, "input.complete", "Accept suggestion", "tab")
+	actionSubmit     = tui.Keys.Register(keyScope, "input.submit", "Submit", "enter")
+)
+
+// DefaultMask is the rune password inputs show for each character.
+const DefaultMask = '•'
+
+// Option is a functional option for configuring the Input.
+type Option func(*Model)
+
+// Model is a single-line text input.
+type Model struct {
+	width        int
+	desiredWidth int // 0 means the width the parent offers
+	tui.FocusState
+	tui.ViewCache
+
+	value  []rune
+	cursor int
+	// offset is the first rune shown when the value is wider than the field.
+	offset int
+
+	placeholder string
+	help        string
+	maxLength   int
+	mask        rune
+	disabled    bool
+
+	validate func(string) error
+	err      error
+	// touched is set once the value was edited or submitted; errors are
+	// only shown from then on.
+	touched bool
+
+	history *History
+	// browsing is the history entry shown, or history.Len() when editing
+	// the draft.
+	browsing int
+	draft    []rune
+
+	complete    Completer
+	suggestions []string
+	suggestion  int
+
+	clipboard func() (string, error)
+	onChange  func(value string) tui.Cmd
+	onSubmit  func(value string) tui.Cmd
+
+	// style holds the widget's own styling, which wins over the theme's.
+	style tui.Style
+}
+
+// New creates a new, empty Input with the given options.
+func New(opts ...Option) *Model {
+	m := &Model{
+		style: tui.NewStyle().Padding(0, designsystem.Current().Components.Input.Padding),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.browsing = m.historyLen()
+	m.refresh()
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithValue sets the initial value, with the cursor at its end.
+func WithValue(value string) Option {
+	return func(m *Model) {
+		m.value = []rune(value)
+		m.cursor = len(m.value)
+	}
+}
+
+// WithPlaceholder sets the text shown while the value is empty.
+func WithPlaceholder(placeholder string) Option {
+	return func(m *Model) { m.placeholder = placeholder }
+}
+
+// WithHelp sets the help text shown below the field.
+func WithHelp(help string) Option {
+	return func(m *Model) { m.help = help }
+}
+
+// WithMaxLength limits the value to n characters.
+func WithMaxLength(n int) Option {
+	return func(m *Model) { m.maxLength = n }
+}
+
+// WithPassword hides the value behind DefaultMask. Password inputs keep no
+// history, offer no suggestions and move over the value as a single word.
+func WithPassword() Option {
+	return WithMask(DefaultMask)
+}
+
+// WithMask hides the value behind mask, like WithPassword.
+func WithMask(mask rune) Option {
+	return func(m *Model) { m.mask = mask }
+}
+
+// WithValidator sets the function that checks the value on every change.
+// Its error is shown below the field and blocks submitting.
+func WithValidator(validate func(value string) error) Option {
+	return func(m *Model) { m.validate = validate }
+}
+
+// WithHistory sets the history browsed with Up and Down, to which the
+// submitted values are added.
+func WithHistory(history *History) Option {
+	return func(m *Model) { m.history = history }
+}
+
+// WithCompleter sets the source of the suggestions shown inline.
+func WithCompleter(complete Completer) Option {
+	return func(m *Model) { m.complete = complete }
+}
+
+// WithClipboard sets the paste hook: the function run by Ctrl+V to read
+// the clipboard. Terminal pastes (bracketed paste) work without it.
+func WithClipboard(read func() (string, error)) Option {
+	return func(m *Model) { m.clipboard = read }
+}
+
+// WithOnChange sets the command run after every change of the value.
+func WithOnChange(onChange func(value string) tui.Cmd) Option {
+	return func(m *Model) { m.onChange = onChange }
+}
+
+// WithOnSubmit sets the command run when Enter is pressed on a valid value.
+func WithOnSubmit(onSubmit func(value string) tui.Cmd) Option {
+	return func(m *Model) { m.onSubmit = onSubmit }
+}
+
+// WithDisabled disables the input: it is drawn muted and ignores input.
+func WithDisabled(disabled bool) Option {
+	return func(m *Model) { m.disabled = disabled }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+// --- Messages ---
+
+// clipboardMsg carries the text read by the paste hook.
+type clipboardMsg struct {
+	target *Model
+	text   string
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.width = msg.Width
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		m.scroll()
+		return m, nil
+
+	case clipboardMsg:
+		if msg.target == m && !m.disabled {
+			return m, m.insert([]rune(msg.text))
+		}
+		return m, nil
+
+	case tui.PasteMsg:
+		if m.Focused() && !m.disabled {
+			return m, m.insert([]rune(msg.Text))
+		}
+		return m, nil
+
+	case tui.KeyMsg:
+		if m.Focused() && !m.disabled {
+			return m, m.handleKey(msg)
+		}
+	}
+	return m, nil
+}
+
+// HandlesKey reports whether the focused input uses msg before its
+// container: text, including "?", and its editing keys. Tab accepts the
+// suggestion shown, and moves focus on when there is none.
+func (m *Model) HandlesKey(msg tui.KeyMsg) bool {
+	if !m.Focused() || m.disabled {
+		return false
+	}
+	switch {
+	case tui.Keys.Matches(msg, actionComplete):
+		return m.Suggestion() != ""
+	case (msg.Type == tui.KeySpace || msg.Type == tui.KeyRunes) && !msg.Ctrl && !msg.Alt:
+		return true
+	}
+	return tui.Keys.MatchesScope(msg, keyScope)
+}
+
+// handleKey applies an editing key.
+func (m *Model) handleKey(msg tui.KeyMsg) tui.Cmd {
+	switch {
+	case tui.Keys.Matches(msg, actionSubmit):
+		return m.submit()
+	case tui.Keys.Matches(msg, actionLeft):
+		m.moveTo(m.cursor - 1)
+	case tui.Keys.Matches(msg, actionRight):
+		if m.cursor == len(m.value) {
+			return m.acceptSuggestion()
+		}
+		m.moveTo(m.cursor + 1)
+	case tui.Keys.Matches(msg, actionWordLeft):
+		m.moveTo(m.wordStart(m.cursor))
+	case tui.Keys.Matches(msg, actionWordRight):
+		m.moveTo(m.wordEnd(m.cursor))
+	case tui.Keys.Matches(msg, actionHome):
+		m.moveTo(0)
+	case tui.Keys.Matches(msg, actionEnd):
+		if m.cursor == len(m.value) {
+			return m.acceptSuggestion()
+		}
+		m.moveTo(len(m.value))
+	case tui.Keys.Matches(msg, actionBackspace):
+		return m.deleteRange(m.cursor-1, m.cursor)
+	case tui.Keys.Matches(msg, actionDelete):
+		return m.deleteRange(m.cursor, m.cursor+1)
+	case tui.Keys.Matches(msg, actionDeleteWord):
+		return m.deleteRange(m.wordStart(m.cursor), m.cursor)
+	case tui.Keys.Matches(msg, actionDeleteNext):
+		return m.deleteRange(m.cursor, m.wordEnd(m.cursor))
+	case tui.Keys.Matches(msg, actionKillStart):
+		return m.deleteRange(0, m.cursor)
+	case tui.Keys.Matches(msg, actionKillEnd):
+		return m.deleteRange(m.cursor, len(m.value))
+	case tui.Keys.Matches(msg, actionPaste):
+		return m.paste()
+	case tui.Keys.Matches(msg, actionPrev):
+		return m.browse(-1)
+	case tui.Keys.Matches(msg, actionNext):
+		return m.browse(1)
+	case tui.Keys.Matches(msg, actionSuggNext):
+		m.cycleSuggestion(1)
+	case tui.Keys.Matches(msg, actionSuggPrev):
+		m.cycleSuggestion(-1)
+	case tui.Keys.Matches(msg, actionComplete):
+		return m.acceptSuggestion()
+	case msg.Type == tui.KeySpace && !msg.Ctrl && !msg.Alt:
+		return m.insert([]rune{' '})
+	case msg.Type == tui.KeyRunes && !msg.Ctrl && !msg.Alt:
+		return m.insert(msg.Runes)
+	}
+	return nil
+}
+
+// --- Editing ---
+
+// insert types runes at the cursor. Line breaks and tabs become spaces and
+// other control characters are dropped; the value is cut at the maximum
+// length.
+func (m *Model) insert(runes []rune) tui.Cmd {
+	clean := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		switch {
+		case r == '\n' || r == '\r' || r == '\t':
+			clean = append(clean, ' ')
+		case unicode.IsControl(r):
+		default:
+			clean = append(clean, r)
+		}
+	}
+	if m.maxLength > 0 {
+		clean = clean[:min(len(clean), max(m.maxLength-len(m.value), 0))]
+	}
+	if len(clean) == 0 {
+		return nil
+	}
+
+	value := make([]rune, 0, len(m.value)+len(clean))
+	value = append(value, m.value[:m.cursor]...)
+	value = append(value, clean...)
+	value = append(value, m.value[m.cursor:]...)
+	m.value = value
+	m.cursor += len(clean)
+	return m.changed()
+}
+
+// deleteRange removes the runes in [from, to), clamped to the value.
+func (m *Model) deleteRange(from, to int) tui.Cmd {
+	from, to = max(from, 0), min(to, len(m.value))
+	if from >= to {
+		return nil
+	}
+	m.value = append(m.value[:from:from], m.value[to:]...)
+	m.cursor = from
+	return m.changed()
+}
+
+// moveTo moves the cursor, clamped to the value.
+func (m *Model) moveTo(cursor int) {
+	m.cursor = min(max(cursor, 0), len(m.value))
+	m.scroll()
+	m.MarkDirty()
+}
+
+// changed is called after every edit: the value leaves the history, is
+// validated again and gets new suggestions.
+func (m *Model) changed() tui.Cmd {
+	m.touched = true
+	m.browsing = m.historyLen()
+	m.refresh()
+	if m.onChange == nil {
+		return nil
+	}
+	return m.onChange(string(m.value))
+}
+
+// refresh validates the value and looks up its suggestions.
+func (m *Model) refresh() {
+	m.cursor = min(max(m.cursor, 0), len(m.value))
+	m.err = nil
+	if m.validate != nil {
+		m.err = m.validate(string(m.value))
+	}
+	m.suggestions, m.suggestion = nil, 0
+	if m.mask == 0 {
+		m.suggestions = extensions(m.complete, string(m.value))
+	}
+	m.scroll()
+	m.MarkDirty()
+}
+
+// isWordRune reports whether r belongs to a word for word-wise moves.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordStart returns the start of the word before i. A masked value is a
+// single word, so its word boundaries don't leak.
+func (m *Model) wordStart(i int) int {
+	if m.mask != 0 {
+		return 0
+	}
+	for i > 0 && !isWordRune(m.value[i-1]) {
+		i--
+	}
+	for i > 0 && isWordRune(m.value[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns the end of the word after i.
+func (m *Model) wordEnd(i int) int {
+	if m.mask != 0 {
+		return len(m.value)
+	}
+	for i < len(m.value) && !isWordRune(m.value[i]) {
+		i++
+	}
+	for i < len(m.value) && isWordRune(m.value[i]) {
+		i++
+	}
+	return i
+}
+
+// paste runs the paste hook; its text arrives as a clipboardMsg. Errors
+// reading the clipboard are ignored: nothing is pasted.
+func (m *Model) paste() tui.Cmd {
+	if m.clipboard == nil {
+		return nil
+	}
+	read := m.clipboard
+	return func() tui.Msg {
+		text, err := read()
+		if err != nil {
+			return nil
+		}
+		return clipboardMsg{target: m, text: text}
+	}
+}
+
+// submit runs the submit command if the value is valid and records it in
+// the history.
+func (m *Model) submit() tui.Cmd {
+	m.touched = true
+	m.MarkDirty()
+	if m.err != nil {
+		return nil
+	}
+	value := string(m.value)
+	if m.history != nil && m.mask == 0 {
+		m.history.Add(value)
+	}
+	m.browsing, m.draft = m.historyLen(), nil
+	if m.onSubmit == nil {
+		return nil
+	}
+	return m.onSubmit(value)
+}
+
+// --- History and Suggestions ---
+
+func (m *Model) historyLen() int {
+	if m.history == nil || m.mask != 0 {
+		return 0
+	}
+	return m.history.Len()
+}
+
+// browse shows the previous (-1) or next (+1) history entry. Leaving the
+// newest entry brings back the value being typed.
+func (m *Model) browse(delta int) tui.Cmd {
+	n := m.historyLen()
+	next := m.browsing + delta
+	if next < 0 || next > n || n == 0 {
+		return nil
+	}
+	if m.browsing == n {
+		m.draft = m.value
+	}
+	m.browsing = next
+	if next == n {
+		m.value, m.draft = m.draft, nil
+	} else {
+		m.value = []rune(m.history.at(next))
+	}
+	m.cursor = len(m.value)
+	m.refresh()
+	if m.onChange == nil {
+		return nil
+	}
+	return m.onChange(string(m.value))
+}
+
+// cycleSuggestion shows the next or previous suggestion.
+func (m *Model) cycleSuggestion(delta int) {
+	if n := len(m.suggestions); n > 0 {
+		m.suggestion = (m.suggestion + delta + n) % n
+		m.MarkDirty()
+	}
+}
+
+// acceptSuggestion replaces the value with the suggestion shown.
+func (m *Model) acceptSuggestion() tui.Cmd {
+	if len(m.suggestions) == 0 {
+		return nil
+	}
+	value := []rune(m.suggestions[m.suggestion])
+	if m.maxLength > 0 && len(value) > m.maxLength {
+		return nil
+	}
+	m.value, m.cursor = value, len(value)
+	return m.changed()
+}
+
+// --- Rendering ---
+
+// display returns the runes shown for the value.
+func (m *Model) display() []rune {
+	if m.mask == 0 {
+		return m.value
+	}
+	return []rune(strings.Repeat(string(m.mask), len(m.value)))
+}
+
+// frame returns the field's style without state colors: the widget's own
+// styling over the theme's border.
+func (m *Model) frame(tokens designsystem.InputTokens) tui.Style {
+	return m.style.Inherit(tui.NewStyle().Border(tokens.Border).BorderForeground(tokens.BorderColor))
+}
+
+// textWidth returns the cells available for text inside the frame.
+func (m *Model) textWidth() int {
+	hFrame, _ := m.frame(designsystem.Current().Components.Input).GetFrameSize()
+	return max(m.width-hFrame, 1)
+}
+
+// scroll moves the visible window so the cursor (and the cell after it)
+// stays on screen.
+func (m *Model) scroll() {
+	if m.width == 0 {
+		return
+	}
+	display := m.display()
+	width := m.textWidth()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	// The caret takes a cell of its own at the end of the value.
+	for m.offset < m.cursor && tui.Width(string(display[m.offset:m.cursor]))+1 > width {
+		m.offset++
+	}
+	m.offset = min(m.offset, len(display))
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Focused: m.Focused()}, m.render)
+}
+
+// render draws the field and the help line; View caches the result.
+func (m *Model) render() string {
+	tokens := designsystem.Current().Components.Input
+
+	box := tokens.States.Default.Apply(m.frame(tokens))
+	switch {
+	case m.disabled:
+		box = tokens.States.Disabled.Apply(box)
+	case m.showError():
+		box = tokens.States.Error.Apply(box)
+	case m.Focused():
+		box = tokens.States.Focus.Apply(box)
+	}
+	text := tui.NewStyle().Inherit(box).UnsetBorder()
+
+	width := m.textWidth()
+	_, right, _, left := box.GetPadding()
+	field := box.Width(width + left + right).Render(m.line(tokens, text, width))
+
+	help := m.help
+	helpStyle := tui.NewStyle().Foreground(tokens.HelpText)
+	if m.showError() {
+		help = m.err.Error()
+		helpStyle = tui.NewStyle().Foreground(tokens.States.Error.Text)
+	}
+	if help == "" && m.validate == nil {
+		return field
+	}
+	// Inputs with a validator keep the line, so errors don't move the
+	// layout.
+	if m.width > 0 {
+		help = tui.Truncate(help, m.width, "…")
+	}
+	return field + "\n" + helpStyle.Render(help)
+}
+
+// line renders the visible part of the value with the caret and the
+// inline suggestion, in width cells.
+func (m *Model) line(tokens designsystem.InputTokens, text tui.Style, width int) string {
+	caret := text.Foreground(tokens.Caret).Reverse(true)
+	focused := m.Focused() && !m.disabled
+
+	if len(m.value) == 0 && m.placeholder != "" {
+		placeholder := []rune(tui.Truncate(m.placeholder, width, "…"))
+		muted := tokens.Placeholder.Apply(text)
+		if !focused {
+			return muted.Render(string(placeholder))
+		}
+		return caret.Render(string(placeholder[:1])) + muted.Render(string(placeholder[1:]))
+	}
+
+	// The rest of the suggestion follows the value, the caret on its first
+	// character.
+	var rest []rune
+	if focused && m.cursor == len(m.value) && len(m.suggestions) > 0 {
+		rest = []rune(strings.TrimPrefix(m.suggestions[m.suggestion], string(m.value)))
+	}
+
+	display := m.display()
+	var b strings.Builder
+	used := 0
+	for i := m.offset; i <= len(display); i++ {
+		cell := " "
+		switch {
+		case i < len(display):
+			cell = string(display[i])
+		case !focused || i != m.cursor:
+			continue
+		case len(rest) > 0:
+			cell, rest = string(rest[0]), rest[1:]
+		}
+		cellWidth := tui.Width(cell)
+		if used+cellWidth > width {
+			break
+		}
+		used += cellWidth
+		if focused && i == m.cursor {
+			b.WriteString(caret.Render(cell))
+		} else {
+			b.WriteString(text.Render(cell))
+		}
+	}
+
+	if room := width - used; len(rest) > 0 && room > 0 {
+		b.WriteString(tokens.Suggestion.Apply(text).Render(tui.Truncate(string(rest), room, "")))
+	}
+	return b.String()
+}
+
+// showError reports whether the validation error is shown.
+func (m *Model) showError() bool {
+	return m.err != nil && m.touched
+}
+
+// AccessibleView returns the value (masked for passwords) or the
+// placeholder, followed by the error or help text.
+func (m *Model) AccessibleView() string {
+	text := "> " + string(m.display())
+	if len(m.value) == 0 && m.placeholder != "" {
+		text = "> (" + m.placeholder + ")"
+	}
+	if m.showError() {
+		return text + "\nError: " + m.err.Error()
+	}
+	if m.help != "" {
+		return text + "\n" + m.help
+	}
+	return text
+}
+
+// --- State ---
+
+// Value returns the text typed.
+func (m *Model) Value() string {
+	return string(m.value)
+}
+
+// Err returns the validation error of the value, nil when it is valid.
+func (m *Model) Err() error {
+	return m.err
+}
+
+// Suggestion returns the suggestion shown inline, or "" if none.
+func (m *Model) Suggestion() string {
+	if len(m.suggestions) == 0 {
+		return ""
+	}
+	return m.suggestions[m.suggestion]
+}
+
+// SetDisabled enables or disables the input.
+func (m *Model) SetDisabled(disabled bool) {
+	m.disabled = disabled
+	m.MarkDirty()
+}
+
+// --- tui.TextWidget Implementation ---
+
+// SetText replaces the value, with the cursor at its end. The new value is
+// validated, but its error is not shown until it is edited again.
+func (m *Model) SetText(text string) {
+	m.value = []rune(text)
+	m.cursor = len(m.value)
+	m.touched = false
+	m.browsing, m.draft = m.historyLen(), nil
+	m.refresh()
+}
+
+// TextStyle returns the style of the value's text.
+func (m *Model) TextStyle() tui.Style {
+	theme := designsystem.Current().Components.Input.States.Default.Apply(tui.NewStyle())
+	return tui.NewStyle().Inherit(m.style.Inherit(theme)).UnsetBorder()
+}
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.scroll()
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.scroll()
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.desiredWidth = width
+	m.width = width
+	m.scroll()
+	return m
+}
+
+// Height is ignored: an input is one line tall, plus its help line.
+func (m *Model) Height(height int) tui.Component {
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
@@ -0,0 +1,215 @@
+package input
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+)
+
+func TestEditing(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		keys       []string
+		want       string
+		wantCursor int
+	}{
+		{"backspace", "node", []string{"backspace"}, "nod", 3},
+		{"insert in the middle", "nde", []string{"left", "left", "o"}, "node", 2},
+		{"delete", "node", []string{"home", "delete"}, "ode", 0},
+		{"word left", "go install tool", []string{"alt+b", "alt+b"}, "go install tool", 3},
+		{"word right", "go install tool", []string{"home", "ctrl+right"}, "go install tool", 2},
+		{"delete previous word", "go install tool", []string{"ctrl+w"}, "go install ", 11},
+		{"delete word over punctuation", "node@22.1 ", []string{"alt+backspace"}, "node@22.", 8},
+		{"delete next word", "go install tool", []string{"home", "alt+d"}, " install tool", 0},
+		{"delete to start", "go install", []string{"alt+b", "ctrl+u"}, "install", 0},
+		{"delete to end", "go install", []string{"alt+b", "ctrl+k"}, "go ", 3},
+		{"space", "go", []string{"space", "x"}, "go x", 4},
+		{"ctrl letters are not typed", "go", []string{"ctrl+x"}, "go", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(WithValue(tt.value))
+			d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+			d.PressKeys(tt.keys...)
+			if m.Value() != tt.want || m.cursor != tt.wantCursor {
+				t.Errorf("value %q cursor %d, want %q cursor %d", m.Value(), m.cursor, tt.want, tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestUnfocusedInputIgnoresKeys(t *testing.T) {
+	m := New()
+	tuitest.New(t, m).Type("go").Send(tui.PasteMsg{Text: "x"})
+	if m.Value() != "" {
+		t.Errorf("value = %q, want empty", m.Value())
+	}
+}
+
+func TestPasteAndMaxLength(t *testing.T) {
+	var pastes int
+	clipboard := func() (string, error) {
+		pastes++
+		if pastes > 1 {
+			return "", errors.New("no clipboard")
+		}
+		return "tool\tname", nil
+	}
+	m := New(WithMaxLength(12), WithClipboard(clipboard))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+
+	d.Send(tui.PasteMsg{Text: "my\nold"})
+	d.PressKeys("ctrl+v")
+	if m.Value() != "my oldtool n" {
+		t.Errorf("value = %q, want the pastes cut at 12 characters", m.Value())
+	}
+	d.PressKeys("ctrl+u", "ctrl+v")
+	if m.Value() != "" || pastes != 2 {
+		t.Errorf("value = %q after a failed paste (%d reads), want empty", m.Value(), pastes)
+	}
+}
+
+func TestValidation(t *testing.T) {
+	validate := func(value string) error {
+		if !strings.Contains(value, "@") {
+			return errors.New("use tool@version")
+		}
+		return nil
+	}
+	var submitted []string
+	m := New(
+		WithValidator(validate),
+		WithHelp("e.g. node@22"),
+		WithPlaceholder("tool@version"),
+		WithOnSubmit(func(value string) tui.Cmd {
+			submitted = append(submitted, value)
+			return nil
+		}),
+	)
+	d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+	d.Golden("empty")
+
+	d.Type("node")
+	d.Golden("invalid")
+	d.Press(tui.KeyEnter)
+	if len(submitted) != 0 {
+		t.Fatalf("an invalid value was submitted: %q", submitted)
+	}
+
+	d.Type("@22").Press(tui.KeyEnter)
+	d.Golden("valid")
+	if !slices.Equal(submitted, []string{"node@22"}) || m.Err() != nil {
+		t.Errorf("submitted %q (err %v), want node@22", submitted, m.Err())
+	}
+}
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(3, "go@1.22", "node@20")
+	m := New(WithHistory(history))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+
+	d.Type("py")
+	steps := []struct {
+		key  tui.KeyType
+		want string
+	}{
+		{tui.KeyUp, "node@20"},
+		{tui.KeyUp, "go@1.22"},
+		{tui.KeyUp, "go@1.22"},
+		{tui.KeyDown, "node@20"},
+		{tui.KeyDown, "py"},
+		{tui.KeyDown, "py"},
+	}
+	for i, step := range steps {
+		d.Press(step.key)
+		if m.Value() != step.want {
+			t.Fatalf("step %d (%s): value %q, want %q", i, step.key, m.Value(), step.want)
+		}
+	}
+
+	d.Type("thon").Press(tui.KeyEnter)
+	m.SetText("")
+	d.Type("x").Press(tui.KeyEnter)
+	if got := history.Entries(); !slices.Equal(got, []string{"node@20", "python", "x"}) {
+		t.Errorf("history = %q, want the last 3 entries", got)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	m := New(WithCompleter(Words("golang", "golangci-lint", "gradle")))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+
+	d.Type("go")
+	if m.Suggestion() != "golang" {
+		t.Fatalf("suggestion = %q, want golang", m.Suggestion())
+	}
+	d.Golden("suggested")
+
+	d.PressKeys("ctrl+n")
+	if m.Suggestion() != "golangci-lint" {
+		t.Errorf("suggestion after ctrl+n = %q", m.Suggestion())
+	}
+	d.Press(tui.KeyRight)
+	if m.Value() != "golangci-lint" || m.Suggestion() != "" {
+		t.Errorf("value %q suggestion %q after accepting", m.Value(), m.Suggestion())
+	}
+}
+
+func TestTabCompletes(t *testing.T) {
+	m := New(WithCompleter(Words("golang")))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+	tab := tui.KeyMsg{Type: tui.KeyTab}
+	if m.HandlesKey(tab) {
+		t.Error("an input without a suggestion claimed Tab from focus traversal")
+	}
+
+	d.Type("go")
+	if !m.HandlesKey(tab) {
+		t.Fatal("an input showing a suggestion left Tab to its container")
+	}
+	d.Send(tab)
+	if m.Value() != "golang" {
+		t.Errorf("value = %q after Tab, want the suggestion", m.Value())
+	}
+}
+
+func TestPassword(t *testing.T) {
+	history := NewHistory(0)
+	m := New(WithPassword(), WithHistory(history), WithCompleter(Words("secret-token")))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+	d.Type("secret to")
+	d.Golden("masked")
+
+	if m.Suggestion() != "" {
+		t.Errorf("a password input suggested %q", m.Suggestion())
+	}
+	d.PressKeys("alt+b")
+	if m.cursor != 0 {
+		t.Errorf("word left stopped at %d, want the start of the masked value", m.cursor)
+	}
+	d.Press(tui.KeyEnter)
+	if history.Len() != 0 {
+		t.Error("a password was added to the history")
+	}
+	if got := tui.AccessibleText(m); got != "> •••••••••" {
+		t.Errorf("AccessibleText = %q", got)
+	}
+}
+
+func TestScrollsToCursor(t *testing.T) {
+	m := New(WithWidth(12))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 4), tuitest.WithFocus())
+	d.Type("日本語のテキスト")
+	d.Golden("end")
+	d.Press(tui.KeyHome)
+	d.Golden("home")
+	if m.offset != 0 {
+		t.Errorf("offset = %d at home", m.offset)
+	}
+}
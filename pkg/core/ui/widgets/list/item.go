@@ -0,0 +1,31 @@
+package list
+
+// Item is a row of a List. Title is what the filter matches and highlights;
+// Detail follows it, muted.
+type Item struct {
+	// ID identifies the item in selection messages; it defaults to Title.
+	ID     string
+	Title  string
+	Detail string
+	// Value is free for the caller, e.g. the catalog entry of the row.
+	Value any
+}
+
+// NewItem creates an item with the given title.
+func NewItem(title string) Item {
+	return Item{ID: title, Title: title}
+}
+
+// WithDetail returns the item with the given detail text.
+func (i Item) WithDetail(detail string) Item {
+	i.Detail = detail
+	return i
+}
+
+// key returns the identity of the item.
+func (i Item) key() string {
+	if i.ID != "" {
+		return i.ID
+	}
+	return i.Title
+}
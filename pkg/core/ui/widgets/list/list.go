@@ -0,0 +1,682 @@
+// Package list provides a scrollable List of items with fuzzy filtering.
+//
+// A List only draws the rows in view, so it stays fast with thousands of
+// items. Typing filters the items with fuzzy matching and highlights the
+// matched characters; Backspace and Esc edit and clear the filter. With
+// multi-select, Space marks items:
+//
+//	tools := list.New(items, list.WithMultiSelect(true))
+//
+//	case list.SelectionMsg:
+//		if msg.ID == m.tools.ID() { m.preview(msg.Current) }
+//	case list.ChooseMsg:
+//		if msg.ID == m.tools.ID() { return m, m.install(msg.Items) }
+package list
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/sahilm/fuzzy"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Layout interface.
+var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+var _ tui.KeyHandler = (*Model)(nil)
+
+// keyScope is the keymap scope of the list actions.
+const keyScope = "list"
+
+// List actions, active while a list has focus.
+var (
+	actionUp       = tui.Keys.Register(keyScope, "list.up", "Previous item", "up", "ctrl+p")
+	actionDown     = tui.Keys.Register(keyScope, "list.down", "Next item", "down", "ctrl+n")
+	actionPageUp   = tui.Keys.Register(keyScope, "list.page_up", "Previous page", "pgup")
+	actionPageDown = tui.Keys.Register(keyScope, "list.page_down", "Next page", "pgdown")
+	actionHome     = tui.Keys.Register(keyScope, "list.home", "First item", "home")
+	actionEnd      = tui.Keys.Register(keyScope, "list.end", "Last item", "end")
+	actionToggle   = tui.Keys.Register(keyScope, "list.toggle", "Select item", "space")
+	actionChoose   = tui.Keys.Register(keyScope, "list.choose", "Choose", "enter")
+	actionBack     = tui.Keys.Register(keyScope, "list.filter_backspace", "Delete filter character", "backspace")
+	actionClear    = tui.Keys.Register(keyScope, "list.filter_clear", "Clear filter", "esc")
+)
+
+// Markers of the rows of multi-select lists.
+const (
+	markOn  = "[x] "
+	markOff = "[ ] "
+)
+
+// ID identifies a list in its messages.
+type ID int64
+
+var lastID atomic.Int64
+
+// SelectionMsg is sent when the item under the cursor or the set of
+// selected items changes. Current is the zero Item when nothing matches.
+type SelectionMsg struct {
+	ID       ID
+	Current  Item
+	Selected []Item
+}
+
+// ChooseMsg is sent when Enter is pressed. Items are the selected items,
+// or the one under the cursor when none is selected.
+type ChooseMsg struct {
+	ID    ID
+	Items []Item
+}
+
+// Option is a functional option for configuring the List.
+type Option func(*Model)
+
+// match is an item shown with the current filter.
+type match struct {
+	index int
+	// positions are the byte offsets of the matched characters in the
+	// item's title.
+	positions []int
+}
+
+// Model is a scrollable list of items.
+type Model struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+	tui.ClickZone
+
+	id         ID
+	items      []Item
+	matches    []match
+	filter     []rune
+	filterable bool
+	multi      bool
+	selected   map[string]bool
+
+	// cursor indexes matches; offset is the first match in view.
+	cursor int
+	offset int
+
+	style tui.Style
+}
+
+// New creates a new List of items with the given options. Filtering is on
+// and multi-select off by default.
+func New(items []Item, opts ...Option) *Model {
+	m := &Model{
+		id:         ID(lastID.Add(1)),
+		filterable: true,
+		selected:   make(map[string]bool),
+		style:      tui.NewStyle().Padding(0, designsystem.Current().Components.List.Padding),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.SetItems(items)
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithMultiSelect lets Space select several items.
+func WithMultiSelect(multi bool) Option {
+	return func(m *Model) { m.multi = multi }
+}
+
+// WithFilter turns filtering as you type on or off.
+func WithFilter(filterable bool) Option {
+	return func(m *Model) { m.filterable = filterable }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+// scrollMsg reports the wheel turned over the list.
+type scrollMsg struct {
+	id    ID
+	delta int
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		}
+		m.scroll()
+
+	case scrollMsg:
+		if msg.id == m.id {
+			return m, m.moveTo(m.cursor + msg.delta)
+		}
+
+	case tui.KeyMsg:
+		if m.Focused() {
+			return m, m.handleKey(msg)
+		}
+	}
+	return m, nil
+}
+
+// HandlesKey reports whether the focused list uses msg before its
+// container: its bindings, the filter text and, while a filter is set,
+// Esc to clear it.
+func (m *Model) HandlesKey(msg tui.KeyMsg) bool {
+	if !m.Focused() {
+		return false
+	}
+	switch {
+	case tui.Keys.Matches(msg, actionBack):
+		return m.filterable && len(m.filter) > 0
+	case tui.Keys.Matches(msg, actionClear):
+		return len(m.filter) > 0
+	case tui.Keys.Matches(msg, actionToggle) && m.multi:
+		return true
+	case isText(msg):
+		return m.filterable || tui.Keys.MatchesScope(msg, keyScope)
+	}
+	return tui.Keys.MatchesScope(msg, keyScope)
+}
+
+// handleKey applies a navigation, selection or filter key.
+func (m *Model) handleKey(msg tui.KeyMsg) tui.Cmd {
+	switch {
+	case tui.Keys.Matches(msg, actionUp):
+		return m.moveTo(m.cursor - 1)
+	case tui.Keys.Matches(msg, actionDown):
+		return m.moveTo(m.cursor + 1)
+	case tui.Keys.Matches(msg, actionPageUp):
+		return m.moveTo(m.cursor - max(m.rows(), 1))
+	case tui.Keys.Matches(msg, actionPageDown):
+		return m.moveTo(m.cursor + max(m.rows(), 1))
+	case tui.Keys.Matches(msg, actionHome):
+		return m.moveTo(0)
+	case tui.Keys.Matches(msg, actionEnd):
+		return m.moveTo(len(m.matches) - 1)
+	case tui.Keys.Matches(msg, actionToggle) && m.multi:
+		return m.toggle()
+	case tui.Keys.Matches(msg, actionChoose):
+		return m.choose()
+	case tui.Keys.Matches(msg, actionBack) && m.filterable && len(m.filter) > 0:
+		return m.setFilter(m.filter[:len(m.filter)-1])
+	case tui.Keys.Matches(msg, actionClear) && len(m.filter) > 0:
+		return m.setFilter(nil)
+	case isText(msg) && m.filterable:
+		runes := msg.Runes
+		if msg.Type == tui.KeySpace {
+			runes = []rune{' '}
+		}
+		return m.setFilter(append(m.filter[:len(m.filter):len(m.filter)], runes...))
+	}
+	return nil
+}
+
+// isText reports whether msg types text: letters, or a space that did not
+// toggle an item.
+func isText(msg tui.KeyMsg) bool {
+	return (msg.Type == tui.KeyRunes || msg.Type == tui.KeySpace) && !msg.Ctrl && !msg.Alt
+}
+
+// moveTo moves the cursor, clamped to the matches, and reports the change.
+func (m *Model) moveTo(cursor int) tui.Cmd {
+	cursor = min(max(cursor, 0), max(len(m.matches)-1, 0))
+	if cursor == m.cursor {
+		return nil
+	}
+	m.cursor = cursor
+	m.scroll()
+	m.MarkDirty()
+	return m.selectionChanged()
+}
+
+// toggle selects or unselects the item under the cursor.
+func (m *Model) toggle() tui.Cmd {
+	item, ok := m.Current()
+	if !ok {
+		return nil
+	}
+	if m.selected[item.key()] {
+		delete(m.selected, item.key())
+	} else {
+		m.selected[item.key()] = true
+	}
+	m.MarkDirty()
+	return m.selectionChanged()
+}
+
+// choose reports the chosen items.
+func (m *Model) choose() tui.Cmd {
+	items := m.Selected()
+	if len(items) == 0 {
+		current, ok := m.Current()
+		if !ok {
+			return nil
+		}
+		items = []Item{current}
+	}
+	msg := ChooseMsg{ID: m.id, Items: items}
+	return func() tui.Msg { return msg }
+}
+
+// setFilter filters the items again, keeping the cursor on the same item
+// when it still matches.
+func (m *Model) setFilter(filter []rune) tui.Cmd {
+	previous, hadCurrent := m.Current()
+	m.filter = filter
+	m.refilter()
+
+	m.cursor = 0
+	if hadCurrent {
+		for i, match := range m.matches {
+			if m.items[match.index].key() == previous.key() {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.offset = 0
+	m.scroll()
+	m.MarkDirty()
+
+	current, ok := m.Current()
+	if ok == hadCurrent && current.key() == previous.key() {
+		return nil
+	}
+	return m.selectionChanged()
+}
+
+// itemTitles lets fuzzy search the items' titles.
+type itemTitles []Item
+
+func (s itemTitles) String(i int) string { return s[i].Title }
+func (s itemTitles) Len() int            { return len(s) }
+
+// refilter computes the matches of the filter, best first.
+func (m *Model) refilter() {
+	m.matches = m.matches[:0]
+	if len(m.filter) == 0 {
+		for i := range m.items {
+			m.matches = append(m.matches, match{index: i})
+		}
+		return
+	}
+	for _, found := range fuzzy.FindFrom(string(m.filter), itemTitles(m.items)) {
+		m.matches = append(m.matches, match{index: found.Index, positions: found.MatchedIndexes})
+	}
+}
+
+// selectionChanged returns the command reporting the current selection.
+func (m *Model) selectionChanged() tui.Cmd {
+	current, _ := m.Current()
+	msg := SelectionMsg{ID: m.id, Current: current, Selected: m.Selected()}
+	return func() tui.Msg { return msg }
+}
+
+// --- Layout ---
+
+// frame returns the list's style, with the focus border when focused.
+func (m *Model) frame() tui.Style {
+	if m.Focused() {
+		return designsystem.Current().Components.List.Focus.ApplyBorder(m.style)
+	}
+	return m.style
+}
+
+// showFilter reports whether the filter line is drawn: while a filter is
+// set, and the slot leaves room for a row under it.
+func (m *Model) showFilter() bool {
+	_, vFrame := m.style.GetFrameSize()
+	return len(m.filter) > 0 && m.height-vFrame >= 2
+}
+
+// bordered reports whether the list has a border to show its focus.
+func (m *Model) bordered() bool {
+	_, top, right, bottom, left := m.style.GetBorder()
+	return top || right || bottom || left
+}
+
+// rows returns the number of item rows in view.
+func (m *Model) rows() int {
+	_, vFrame := m.style.GetFrameSize()
+	rows := m.height - vFrame
+	if m.showFilter() {
+		rows--
+	}
+	return max(rows, 0)
+}
+
+// scroll moves the view so the cursor is in it.
+func (m *Model) scroll() {
+	rows := m.rows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if rows > 0 && m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	// No empty rows at the bottom while there are items above.
+	m.offset = max(min(m.offset, len(m.matches)-rows), 0)
+}
+
+func (m *Model) View() string {
+	m.bindZone()
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// bindZone registers the scroll handler the first time the list is drawn
+// or focused, so a list that is never shown leaves nothing in the zone map.
+func (m *Model) bindZone() {
+	if tui.Zones.Registered(m.ZoneID()) {
+		return
+	}
+	tui.Zones.OnScroll(m.ZoneID(), func(delta int) tui.Cmd {
+		id := m.id
+		return func() tui.Msg { return scrollMsg{id: id, delta: delta} }
+	})
+}
+
+// render draws the rows in view; View caches the result.
+func (m *Model) render() string {
+	tokens := designsystem.Current().Components.List
+	style := m.frame()
+	if _, vFrame := style.GetFrameSize(); m.height-vFrame <= 0 {
+		return ""
+	}
+	hFrame, _ := style.GetFrameSize()
+	width := max(m.width-hFrame, 0)
+	// lipgloss sizes the block without its border.
+	_, top, right, bottom, left := style.GetBorder()
+	borderWidth, borderHeight := boolInt(left)+boolInt(right), boolInt(top)+boolInt(bottom)
+
+	var lines []string
+	if m.showFilter() {
+		prompt := fmt.Sprintf("/ %s  (%d/%d)", string(m.filter), len(m.matches), len(m.items))
+		lines = append(lines, tokens.Item.Muted.Apply(tui.NewStyle()).Render(tui.Truncate(prompt, width, "…")))
+	}
+
+	rows := m.rows()
+	scrollbar := tokens.Scrollbar.Render(rows, rows, len(m.matches), m.offset)
+	rowWidth := width
+	if scrollbar != "" {
+		rowWidth = max(width-1, 0)
+	}
+
+	var body []string
+	if len(m.matches) == 0 && rows > 0 {
+		body = append(body, tokens.Item.Muted.Apply(tui.NewStyle()).Render(tui.Truncate("No matches", rowWidth, "…")))
+	}
+	for i := m.offset; i < min(m.offset+rows, len(m.matches)); i++ {
+		body = append(body, m.renderRow(tokens.Item, i, rowWidth))
+	}
+	block := tui.NewStyle().Width(rowWidth).Height(rows).Render(strings.Join(body, "\n"))
+	if scrollbar != "" {
+		block = tui.JoinHorizontal(tui.Top, block, scrollbar)
+	}
+	lines = append(lines, block)
+
+	return m.MarkZone(style.Width(max(m.width-borderWidth, 0)).Height(max(m.height-borderHeight, 0)).Render(strings.Join(lines, "\n")))
+}
+
+// rowStyle returns the style of the row of match i. Without a border, the
+// row under the cursor shows the focus.
+func (m *Model) rowStyle(states designsystem.ListItemStates, i int) tui.Style {
+	style := states.Normal.Apply(tui.NewStyle())
+	if i != m.cursor {
+		return style
+	}
+	style = states.Selected.Apply(style)
+	if m.Focused() && !m.bordered() {
+		style = designsystem.Current().State.Focus.Apply(style)
+	}
+	return style
+}
+
+// renderRow draws the match at i in width cells, highlighting the matched
+// characters of its title.
+func (m *Model) renderRow(states designsystem.ListItemStates, i, width int) string {
+	match := m.matches[i]
+	item := m.items[match.index]
+
+	base := m.rowStyle(states, i)
+	highlight := states.Match.Apply(base)
+
+	var b strings.Builder
+	if m.multi {
+		mark := markOff
+		if m.selected[item.key()] {
+			mark = markOn
+		}
+		b.WriteString(base.Render(mark))
+	}
+
+	// Consecutive characters with the same look are rendered together.
+	var run strings.Builder
+	matched, p := false, 0
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if matched {
+			b.WriteString(highlight.Render(run.String()))
+		} else {
+			b.WriteString(base.Render(run.String()))
+		}
+		run.Reset()
+	}
+	for offset, r := range item.Title {
+		isMatch := p < len(match.positions) && match.positions[p] == offset
+		if isMatch {
+			p++
+		}
+		if isMatch != matched {
+			flush()
+			matched = isMatch
+		}
+		run.WriteRune(r)
+	}
+	flush()
+
+	if item.Detail != "" {
+		b.WriteString(states.Muted.Apply(base).Render(" " + item.Detail))
+	}
+
+	line := tui.Truncate(b.String(), width, "…")
+	if pad := width - tui.Width(line); pad > 0 {
+		line += base.Render(strings.Repeat(" ", pad))
+	}
+	return line
+}
+
+// AccessibleView returns the filter and the rows in view as plain text,
+// the cursor marked with "> ".
+func (m *Model) AccessibleView() string {
+	var lines []string
+	if m.showFilter() {
+		lines = append(lines, fmt.Sprintf("Filter: %s (%d of %d)", string(m.filter), len(m.matches), len(m.items)))
+	}
+	if len(m.matches) == 0 {
+		return strings.Join(append(lines, "No matches"), "\n")
+	}
+	for i := m.offset; i < min(m.offset+max(m.rows(), 1), len(m.matches)); i++ {
+		item := m.items[m.matches[i].index]
+		line := "  "
+		if i == m.cursor {
+			line = "> "
+		}
+		if m.multi {
+			if m.selected[item.key()] {
+				line += markOn
+			} else {
+				line += markOff
+			}
+		}
+		line += item.Title
+		if item.Detail != "" {
+			line += " " + item.Detail
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// --- State ---
+
+// ID returns the identifier carried by the list's messages.
+func (m *Model) ID() ID {
+	return m.id
+}
+
+// Items returns all the items, filtered or not.
+func (m *Model) Items() []Item {
+	return m.items
+}
+
+// SetItems replaces the items, keeping the filter and the selection of
+// the items that remain.
+func (m *Model) SetItems(items []Item) {
+	m.items = items
+	keep := make(map[string]bool, len(m.selected))
+	for _, item := range items {
+		if m.selected[item.key()] {
+			keep[item.key()] = true
+		}
+	}
+	m.selected = keep
+	m.refilter()
+	m.cursor = min(m.cursor, max(len(m.matches)-1, 0))
+	m.scroll()
+	m.MarkDirty()
+}
+
+// Current returns the item under the cursor; ok is false when no item
+// matches the filter.
+func (m *Model) Current() (item Item, ok bool) {
+	if m.cursor >= len(m.matches) {
+		return Item{}, false
+	}
+	return m.items[m.matches[m.cursor].index], true
+}
+
+// Selected returns the selected items, in list order.
+func (m *Model) Selected() []Item {
+	var selected []Item
+	for _, item := range m.items {
+		if m.selected[item.key()] {
+			selected = append(selected, item)
+		}
+	}
+	return selected
+}
+
+// Filter returns the text the items are filtered by.
+func (m *Model) Filter() string {
+	return string(m.filter)
+}
+
+// Focus focuses the list; the mouse wheel scrolls it wherever the pointer
+// is.
+func (m *Model) Focus() tui.Cmd {
+	m.bindZone()
+	tui.Zones.Focus(m.ZoneID())
+	return m.FocusState.Focus()
+}
+
+// Blur removes focus from the list.
+func (m *Model) Blur() {
+	tui.Zones.Blur(m.ZoneID())
+	m.FocusState.Blur()
+}
+
+// Close forgets the list's zone. Call it when the list is discarded.
+func (m *Model) Close() {
+	tui.Zones.Remove(m.ZoneID())
+}
+
+// --- tui.Layout Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Layout {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
+	m.style = m.style.Border(border, sides...)
+	m.scroll()
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Layout {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Layout {
+	m.style = m.style.Padding(p...)
+	m.scroll()
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Layout {
+	m.desiredWidth = width
+	m.width = width
+	return m
+}
+
+func (m *Model) Height(height int) tui.Layout {
+	m.desiredHeight = height
+	m.height = height
+	m.scroll()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Layout {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,252 @@
+package list
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	lg "github.com/charmbracelet/lipgloss"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// numbered returns n items titled "item 1" to "item n".
+func numbered(n int) []Item {
+	items := make([]Item, n)
+	for i := range items {
+		items[i] = NewItem(fmt.Sprintf("item %d", i+1))
+	}
+	return items
+}
+
+// tools returns a few items with details.
+func tools() []Item {
+	return []Item{
+		NewItem("node").WithDetail("22.1.0"),
+		NewItem("golang").WithDetail("1.25"),
+		NewItem("python").WithDetail("3.13"),
+		NewItem("golangci-lint").WithDetail("2.1"),
+		NewItem("deno").WithDetail("2.3"),
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	tests := []struct {
+		name       string
+		keys       []string
+		wantCursor int
+		wantOffset int
+	}{
+		{"down", []string{"down", "down"}, 2, 0},
+		{"up stops at the top", []string{"up"}, 0, 0},
+		{"scrolls to the cursor", []string{"down", "down", "down", "down", "down"}, 5, 1},
+		{"page down", []string{"pgdown"}, 5, 1},
+		{"page up", []string{"end", "pgup"}, 194, 194},
+		{"end", []string{"end"}, 199, 195},
+		{"home", []string{"end", "home"}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(numbered(200))
+			d := tuitest.New(t, m, tuitest.WithSize(20, 5), tuitest.WithFocus())
+			d.PressKeys(tt.keys...)
+			if m.cursor != tt.wantCursor || m.offset != tt.wantOffset {
+				t.Errorf("cursor %d offset %d, want cursor %d offset %d", m.cursor, m.offset, tt.wantCursor, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestVirtualizedRendering(t *testing.T) {
+	d := tuitest.New(t, New(numbered(200)), tuitest.WithSize(20, 5), tuitest.WithFocus())
+	d.Golden("top")
+	d.PressKeys("pgdown", "pgdown")
+	d.Golden("scrolled")
+	d.PressKeys("end")
+	d.Golden("end")
+}
+
+func TestFilter(t *testing.T) {
+	m := New(tools())
+	d := tuitest.New(t, m, tuitest.WithSize(30, 6), tuitest.WithFocus())
+	if m.HandlesKey(tuitest.Key(t, "esc")) {
+		t.Error("a list without a filter claimed Esc")
+	}
+
+	d.Type("gl")
+	if !m.HandlesKey(tuitest.Key(t, "esc")) || !m.HandlesKey(tuitest.Key(t, "?")) {
+		t.Error("a filtered list left Esc or ? to its container")
+	}
+	if got := titles(visible(m)); !slices.Equal(slices.Sorted(slices.Values(got)), []string{"golang", "golangci-lint"}) {
+		t.Errorf("matches = %q, want golang and golangci-lint", got)
+	}
+	d.Golden("filtered")
+
+	d.Type("zz")
+	d.Golden("no_matches")
+
+	d.PressKeys("backspace", "backspace", "esc")
+	if m.Filter() != "" || len(visible(m)) != len(tools()) {
+		t.Errorf("filter %q with %d matches, want all items", m.Filter(), len(visible(m)))
+	}
+}
+
+func TestFilterTypesSpaces(t *testing.T) {
+	m := New(numbered(12))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 6), tuitest.WithFocus())
+	d.Type("item").Press(tui.KeySpace).Type("2")
+	if got := m.Filter(); got != "item 2" {
+		t.Errorf("filter = %q, want %q", got, "item 2")
+	}
+	if !m.HandlesKey(tui.KeyMsg{Type: tui.KeySpace}) {
+		t.Error("a filterable list left Space to its container")
+	}
+}
+
+func TestShortSlots(t *testing.T) {
+	for height := range 4 {
+		t.Run(fmt.Sprintf("height %d", height), func(t *testing.T) {
+			m := New(tools())
+			d := tuitest.New(t, m, tuitest.WithSize(30, 6), tuitest.WithFocus())
+			d.Type("go").Resize(30, height)
+			view := m.View()
+			lines := 0
+			if view != "" {
+				lines = strings.Count(view, "\n") + 1
+			}
+			if lines != height {
+				t.Errorf("%d lines drawn in a slot of %d", lines, height)
+			}
+			if got, want := m.showFilter(), height >= 2; got != want {
+				t.Errorf("filter line shown = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestBorderlessFocusOnSelectedRow(t *testing.T) {
+	m := New(tools())
+	tuitest.New(t, m, tuitest.WithSize(30, 6), tuitest.WithFocus())
+	theme := designsystem.Current()
+	fg := func() any {
+		return m.rowStyle(theme.Components.List.Item, m.cursor).GetLipglossStyle().GetForeground()
+	}
+	if got, want := fg(), lg.Color(theme.State.Focus.Text); got != want {
+		t.Errorf("focused row color = %v, want the focus color %v", got, want)
+	}
+	m.Blur()
+	if got, want := fg(), lg.Color(theme.Components.List.Item.Selected.Text); got != want {
+		t.Errorf("blurred row color = %v, want the selected color %v", got, want)
+	}
+}
+
+func TestFilterKeepsCursorOnItem(t *testing.T) {
+	m := New(tools())
+	d := tuitest.New(t, m, tuitest.WithSize(30, 6), tuitest.WithFocus())
+	d.PressKeys("down", "down", "down") // golangci-lint
+	d.Type("go")
+	if current, _ := m.Current(); current.Title != "golangci-lint" {
+		t.Errorf("current = %q, want golangci-lint", current.Title)
+	}
+}
+
+func TestMultiSelect(t *testing.T) {
+	m := New(tools(), WithMultiSelect(true))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 6), tuitest.WithFocus())
+	d.PressKeys("space", "down", "down", "space")
+	d.Golden("selected")
+
+	if got := titles(m.Selected()); !slices.Equal(got, []string{"node", "python"}) {
+		t.Errorf("selected = %q, want node and python", got)
+	}
+
+	d.PressKeys("enter")
+	choose, ok := last[ChooseMsg](d.Messages())
+	if !ok || !slices.Equal(titles(choose.Items), []string{"node", "python"}) || choose.ID != m.ID() {
+		t.Errorf("choose = %+v, want node and python of the list", choose)
+	}
+}
+
+func TestSelectionMessages(t *testing.T) {
+	m := New(tools(), WithMultiSelect(true))
+	d := tuitest.New(t, m, tuitest.WithSize(30, 6), tuitest.WithFocus())
+
+	d.PressKeys("down")
+	sel, ok := last[SelectionMsg](d.Messages())
+	if !ok || sel.Current.Title != "golang" || sel.ID != m.ID() {
+		t.Fatalf("selection = %+v, want golang", sel)
+	}
+
+	d.PressKeys("space")
+	sel, _ = last[SelectionMsg](d.Messages())
+	if !slices.Equal(titles(sel.Selected), []string{"golang"}) {
+		t.Errorf("selected = %q, want golang", titles(sel.Selected))
+	}
+
+	// Moving past the end changes nothing and sends nothing.
+	n := count[SelectionMsg](d.Messages())
+	d.PressKeys("end", "down")
+	if got := count[SelectionMsg](d.Messages()) - n; got != 1 {
+		t.Errorf("%d selection messages, want 1", got)
+	}
+}
+
+func TestEnterChoosesCurrentItem(t *testing.T) {
+	d := tuitest.New(t, New(tools()), tuitest.WithSize(30, 6), tuitest.WithFocus())
+	d.PressKeys("down", "enter")
+	choose, ok := last[ChooseMsg](d.Messages())
+	if !ok || !slices.Equal(titles(choose.Items), []string{"golang"}) {
+		t.Errorf("choose = %+v, want golang", choose)
+	}
+}
+
+func TestUnfocusedListIgnoresKeys(t *testing.T) {
+	m := New(tools())
+	tuitest.New(t, m, tuitest.WithSize(30, 6)).Type("py").PressKeys("down")
+	if m.Filter() != "" || m.cursor != 0 {
+		t.Errorf("filter %q cursor %d, want untouched", m.Filter(), m.cursor)
+	}
+}
+
+// visible returns the items matching the filter, best first.
+func visible(m *Model) []Item {
+	var items []Item
+	for _, match := range m.matches {
+		items = append(items, m.items[match.index])
+	}
+	return items
+}
+
+func titles(items []Item) []string {
+	var titles []string
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	return titles
+}
+
+// last returns the last message of type T.
+func last[T tui.Msg](msgs []tui.Msg) (T, bool) {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msg, ok := msgs[i].(T); ok {
+			return msg, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// count returns the number of messages of type T.
+func count[T tui.Msg](msgs []tui.Msg) int {
+	n := 0
+	for _, msg := range msgs {
+		if _, ok := msg.(T); ok {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,500 @@
+// Package navbar provides the NavBar widget: a row of tabs, one per route,
+// for the Scaffold's NavBar slot.
+//
+// Selecting a tab, with the arrows, a number key or a click, publishes its
+// route on navigation.Navigated, so the active ViewBox can switch on the
+// route ID like it does for the side menu. The bar follows routes picked
+// elsewhere too. Tabs that do not fit scroll, with a mark at each edge
+// hiding some, and each tab may carry a badge, e.g. a count of updates:
+//
+//	nav := navbar.New(navbar.WithTabs(
+//		navbar.Tab{Route: navigation.Route{ID: "home", Title: "Home"}},
+//		navbar.Tab{Route: navigation.Route{ID: "tools", Title: "Tools"}, Badge: "3"},
+//	))
+package navbar
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
+)
+
+// Ensure Model implements the tui.Model and tui.Layout interfaces.
+var _ tui.Model = (*Model)(nil)
+var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+var _ tui.KeyScoper = (*Model)(nil)
+var _ tui.Disposable = (*Model)(nil)
+
+// keyScope is the scope of the bar's key bindings while it has focus;
+// jumpScope is the scope of the ones the Scaffold hands it from anywhere.
+const (
+	keyScope  = "navbar"
+	jumpScope = "navbar.jump"
+)
+
+// maxNumbered is the number of tabs reachable with a number key.
+const maxNumbered = 9
+
+var (
+	actionPrev = tui.Keys.Register(keyScope, "navbar.prev", "Previous tab", "left", "h")
+	actionNext = tui.Keys.Register(keyScope, "navbar.next", "Next tab", "right", "l")
+	// actionTabs[i] selects tab i+1 while the bar has focus, actionJumps[i]
+	// from anywhere in a Scaffold.
+	actionTabs, actionJumps = registerNumbers()
+)
+
+// registerNumbers registers the actions selecting the first tabs by number.
+func registerNumbers() (tabs, jumps []tui.Action) {
+	for i := 1; i <= maxNumbered; i++ {
+		n := strconv.Itoa(i)
+		tabs = append(tabs, tui.Keys.Register(keyScope, tui.Action("navbar.tab"+n), "Tab "+n, n))
+		jumps = append(jumps, tui.Keys.Register(jumpScope, tui.Action("navbar.jump"+n), "Go to tab "+n, "alt+"+n))
+	}
+	return tabs, jumps
+}
+
+func init() {
+	// The jumps work from whatever slot of the Scaffold has focus.
+	tui.Keys.AddOuterScopes(jumpScope)
+}
+
+// Tab is a tab of the bar.
+type Tab struct {
+	Route navigation.Route
+	// Badge is drawn after the title when set, e.g. a count.
+	Badge string
+}
+
+// Option is a functional option for configuring the NavBar.
+type Option func(*Model)
+
+type Model struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+	tui.Subscriptions
+	style tui.Style
+
+	tabs   []Tab
+	zones  []tui.ZoneID
+	active int
+	// offset is the first tab drawn when they do not all fit.
+	offset int
+
+	nav *tui.Subscription[navigation.Route]
+}
+
+// New creates a new NavBar with the given options. The first tab is
+// active.
+func New(opts ...Option) *Model {
+	m := &Model{
+		style: tui.NewStyle(),
+	}
+	m.nav = tui.Subscribe(&m.Subscriptions, navigation.Navigated)
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithTabs sets the tabs of the bar.
+func WithTabs(tabs ...Tab) Option {
+	return func(m *Model) { m.SetTabs(tabs) }
+}
+
+// WithActive makes the tab at index active, without publishing its route.
+// It follows WithTabs; an index out of range makes the first tab active.
+func WithActive(index int) Option {
+	return func(m *Model) {
+		m.active = index
+		m.clampActive()
+	}
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithBorderForeground(color string) Option {
+	return func(m *Model) { m.BorderForeground(color) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+func WithAlign(pos tui.Position) Option {
+	return func(m *Model) { m.Align(pos) }
+}
+
+// clickMsg reports a click on a tab.
+type clickMsg struct {
+	bar *Model
+	tab int
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	// Routes picked elsewhere, e.g. in the side menu, move the bar along.
+	if route, ok := m.nav.Receive(msg); ok {
+		if i := m.index(route.ID); i >= 0 && i != m.active {
+			m.active = i
+			m.MarkDirty()
+		}
+		return m, nil
+	}
+
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		// Apply desired dimensions if set, otherwise use available space
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		} else {
+			m.width = msg.Width
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		} else {
+			m.height = msg.Height
+		}
+		m.MarkDirty()
+
+	case clickMsg:
+		if msg.bar == m {
+			return m, m.Select(msg.tab)
+		}
+
+	case tui.KeyMsg:
+		if len(m.tabs) == 0 {
+			break
+		}
+		switch {
+		case tui.Keys.Matches(msg, actionPrev):
+			return m, m.Select(max(m.active-1, 0))
+		case tui.Keys.Matches(msg, actionNext):
+			return m, m.Select(min(m.active+1, len(m.tabs)-1))
+		}
+		for i, action := range actionTabs {
+			if tui.Keys.Matches(msg, action) {
+				return m, m.Select(i)
+			}
+		}
+	}
+	return m, nil
+}
+
+// Jump handles the keys selecting a tab from anywhere (alt+1 to alt+9):
+// the Scaffold hands them over before routing input to the focused slot.
+// It reports whether msg was one of them.
+func (m *Model) Jump(msg tui.KeyMsg) (tui.Cmd, bool) {
+	for i, action := range actionJumps {
+		if tui.Keys.Matches(msg, action) {
+			return m.Select(i), true
+		}
+	}
+	return nil, false
+}
+
+// KeyScopes returns the bar's scope when it has tabs to switch.
+func (m *Model) KeyScopes() []string {
+	if len(m.tabs) == 0 {
+		return nil
+	}
+	return []string{keyScope}
+}
+
+// JumpScopes returns the scope of the keys Jump handles when there are tabs
+// to jump to, so the Scaffold can list them in its help.
+func (m *Model) JumpScopes() []string {
+	if len(m.tabs) == 0 {
+		return nil
+	}
+	return []string{jumpScope}
+}
+
+func (m *Model) View() string {
+	m.bindZones()
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// bindZones registers the click handlers of the tabs the first time the bar
+// is drawn, so a bar that is never shown leaves nothing in the zone map.
+func (m *Model) bindZones() {
+	for tab, zone := range m.zones {
+		if tui.Zones.Registered(zone) {
+			continue
+		}
+		tui.Zones.OnClick(zone, func() tui.Cmd {
+			return func() tui.Msg { return clickMsg{bar: m, tab: tab} }
+		})
+	}
+}
+
+// render draws the visible tabs; View caches the result.
+func (m *Model) render() string {
+	hFrame, vFrame := m.style.GetFrameSize()
+	contentWidth := max(m.width-hFrame, 0)
+	contentHeight := max(m.height-vFrame, 0)
+
+	return m.style.Width(contentWidth).Height(contentHeight).Render(m.content(contentWidth))
+}
+
+// content draws the tabs that fit in width, scrolled so the active one
+// shows.
+func (m *Model) content(width int) string {
+	if len(m.tabs) == 0 || width <= 0 {
+		return ""
+	}
+	theme := designsystem.Current()
+	tokens := theme.Components.NavBar
+	left := tokens.ScrollLeft.For(theme.Charset)
+	right := tokens.ScrollRight.For(theme.Charset)
+
+	labels := make([]string, len(m.tabs))
+	for i := range m.tabs {
+		labels[i] = m.label(i)
+	}
+	m.offset = scrollOffset(labels, m.active, m.offset, width, tui.Width(left), tui.Width(right))
+
+	var b strings.Builder
+	used := 0
+	if m.offset > 0 {
+		b.WriteString(left)
+		used += tui.Width(left)
+	}
+	for i := m.offset; i < len(labels); i++ {
+		// The last tab needs no room for the right mark.
+		room := width - used
+		if i < len(labels)-1 {
+			room -= tui.Width(right)
+		}
+		if tui.Width(labels[i]) > room && i > m.offset {
+			b.WriteString(right)
+			break
+		}
+		b.WriteString(tui.MarkZone(m.zones[i], tui.Truncate(labels[i], max(room, 0), "…")))
+		used += tui.Width(labels[i])
+	}
+	return b.String()
+}
+
+// label draws tab i: its number, title and badge, styled by its state.
+func (m *Model) label(i int) string {
+	tokens := designsystem.Current().Components.NavBar
+	tab := m.tabs[i]
+
+	state := tokens.Tab
+	if i == m.active {
+		state = tokens.Active
+		if m.Focused() {
+			state = tokens.Focus
+		}
+	}
+	style := state.Apply(tui.NewStyle()).Padding(0, tokens.Padding)
+
+	text := tab.Route.Title
+	if i < maxNumbered {
+		text = strconv.Itoa(i+1) + " " + text
+	}
+	if tab.Badge != "" {
+		text += " " + tokens.Badge.Apply(tui.NewStyle()).Render(tab.Badge)
+	}
+	return style.Render(text)
+}
+
+// scrollOffset returns the first tab to draw so that the active one fits
+// in width, moving from offset as little as possible. leftMark and
+// rightMark are the widths of the marks drawn when tabs are hidden.
+func scrollOffset(labels []string, active, offset, width, leftMark, rightMark int) int {
+	if active < offset {
+		return active
+	}
+	for offset < active {
+		used := 0
+		if offset > 0 {
+			used += leftMark
+		}
+		for i := offset; i <= active; i++ {
+			used += tui.Width(labels[i])
+		}
+		if active < len(labels)-1 {
+			used += rightMark
+		}
+		if used <= width {
+			break
+		}
+		offset++
+	}
+	return offset
+}
+
+// AccessibleView lists the tabs on one line, marking the active one with
+// ">".
+func (m *Model) AccessibleView() string {
+	if len(m.tabs) == 0 {
+		return ""
+	}
+	parts := make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		part := tab.Route.Title
+		if tab.Badge != "" {
+			part += " (" + tab.Badge + ")"
+		}
+		if i == m.active {
+			part = "> " + part
+		}
+		parts[i] = part
+	}
+	return "Tabs: " + strings.Join(parts, " | ")
+}
+
+// --- State ---
+
+// Tabs returns the tabs of the bar.
+func (m *Model) Tabs() []Tab {
+	return m.tabs
+}
+
+// SetTabs replaces the tabs, keeping the active index when it is still in
+// range.
+func (m *Model) SetTabs(tabs []Tab) {
+	m.Close()
+	m.tabs = tabs
+	m.zones = make([]tui.ZoneID, len(tabs))
+	for i := range m.zones {
+		m.zones[i] = tui.NewZoneID()
+	}
+	m.clampActive()
+	m.offset = 0
+	m.MarkDirty()
+}
+
+// clampActive makes the first tab active when the active index is out of
+// range.
+func (m *Model) clampActive() {
+	if m.active < 0 || m.active >= len(m.tabs) {
+		m.active = 0
+	}
+	m.MarkDirty()
+}
+
+// Active returns the index and the route of the active tab; the index is
+// -1 when there are no tabs.
+func (m *Model) Active() (int, navigation.Route) {
+	if m.active < 0 || m.active >= len(m.tabs) {
+		return -1, navigation.Route{}
+	}
+	return m.active, m.tabs[m.active].Route
+}
+
+// Select makes the tab at index active and returns the command publishing
+// its route. Selecting the active tab again publishes nothing.
+func (m *Model) Select(index int) tui.Cmd {
+	if index < 0 || index >= len(m.tabs) || index == m.active {
+		return nil
+	}
+	m.active = index
+	m.MarkDirty()
+	return navigation.Navigated.Publish(m.tabs[index].Route)
+}
+
+// SetBadge sets the badge of the tab of the route with id; an empty badge
+// removes it.
+func (m *Model) SetBadge(id, badge string) {
+	if i := m.index(id); i >= 0 {
+		m.tabs[i].Badge = badge
+		m.MarkDirty()
+	}
+}
+
+// index returns the index of the tab of the route with id, or -1.
+func (m *Model) index(id string) int {
+	for i, tab := range m.tabs {
+		if tab.Route.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Close forgets the zones of the tabs.
+func (m *Model) Close() {
+	for _, zone := range m.zones {
+		tui.Zones.Remove(zone)
+	}
+}
+
+// Dispose ends the bar's subscription to navigation and forgets its zones.
+func (m *Model) Dispose() {
+	m.Subscriptions.Dispose()
+	m.Close()
+}
+
+// --- tui.Layout Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Layout {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Layout {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Layout {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Layout {
+	m.desiredWidth = width
+	return m
+}
+
+func (m *Model) Height(height int) tui.Layout {
+	m.desiredHeight = height
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Layout {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
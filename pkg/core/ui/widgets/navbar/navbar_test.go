@@ -0,0 +1,161 @@
+package navbar
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
+)
+
+// newBar returns a bar of tabs titled after titles, disposed with the test.
+func newBar(t *testing.T, titles ...string) *Model {
+	tabs := make([]Tab, len(titles))
+	for i, title := range titles {
+		tabs[i] = Tab{Route: navigation.Route{ID: title, Title: title}}
+	}
+	m := New(WithTabs(tabs...))
+	t.Cleanup(m.Dispose)
+	return m
+}
+
+// navigated returns the routes published so far.
+func navigated(d *tuitest.Driver) []string {
+	var ids []string
+	for _, msg := range d.Messages() {
+		if event, ok := msg.(tui.Event[navigation.Route]); ok {
+			ids = append(ids, event.Payload.ID)
+		}
+	}
+	return ids
+}
+
+func TestNavBar(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	m := newBar(t, "Home", "Tools", "Settings")
+	m.SetBadge("Tools", "3")
+	m.Focus()
+	d := tuitest.New(t, m, tuitest.WithSize(40, 1))
+	d.Golden("home")
+
+	d.PressKeys("3")
+	d.Golden("settings")
+	if i, route := m.Active(); i != 2 || route.ID != "Settings" {
+		t.Errorf("Active() = %d %q, want Settings", i, route.ID)
+	}
+
+	d.Press(tui.KeyLeft).PressKeys("2")
+	if got := navigated(d); len(got) != 2 || got[0] != "Settings" || got[1] != "Tools" {
+		t.Errorf("published %q, want Settings then Tools once", got)
+	}
+	if got := m.AccessibleView(); got != "Tabs: Home | > Tools (3) | Settings" {
+		t.Errorf("AccessibleView() = %q", got)
+	}
+}
+
+func TestNavBarScrolls(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	m := newBar(t, "Home", "Tools", "Stacks", "Updates", "Settings", "About")
+	m.Focus()
+	d := tuitest.New(t, m, tuitest.WithSize(30, 1))
+	d.Golden("start")
+
+	d.PressKeys("5")
+	d.Golden("settings")
+
+	d.Press(tui.KeyRight)
+	d.Golden("end")
+
+	d.PressKeys("2")
+	d.Golden("back")
+}
+
+func TestWithActiveClamps(t *testing.T) {
+	tabs := []Tab{{Route: navigation.Route{ID: "home"}}, {Route: navigation.Route{ID: "tools"}}}
+	for _, index := range []int{-1, 2, 9} {
+		m := New(WithTabs(tabs...), WithActive(index))
+		if i, route := m.Active(); i != 0 || route.ID != "home" {
+			t.Errorf("WithActive(%d): Active() = %d %q, want the first tab", index, i, route.ID)
+		}
+		m.Dispose()
+	}
+}
+
+func TestNavBarWithoutWidth(t *testing.T) {
+	m := newBar(t, "Home", "Tools")
+	if got := m.content(0); got != "" {
+		t.Errorf("content(0) = %q, want nothing", got)
+	}
+}
+
+func TestNavBarFollowsNavigation(t *testing.T) {
+	m := newBar(t, "Home", "Tools")
+	d := tuitest.New(t, m, tuitest.WithSize(30, 1))
+
+	d.Send(tui.Event[navigation.Route]{Topic: navigation.Navigated, Payload: navigation.Route{ID: "Tools"}})
+	if i, _ := m.Active(); i != 1 {
+		t.Errorf("active tab = %d after navigating to Tools, want 1", i)
+	}
+	if got := navigated(d); len(got) != 1 {
+		t.Errorf("the bar published %q again", got[1:])
+	}
+}
+
+func TestNavBarClick(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	m := newBar(t, "Home", "Tools")
+	d := tuitest.New(t, m, tuitest.WithSize(30, 1))
+
+	zone, ok := tui.Zones.Get(m.zones[1])
+	if !ok {
+		t.Fatal("the tab was not drawn")
+	}
+	d.Send(
+		tui.MouseMsg{X: zone.X, Y: zone.Y, Action: tui.MouseActionPress, Button: tui.MouseButtonLeft},
+		tui.MouseMsg{X: zone.X, Y: zone.Y, Action: tui.MouseActionRelease, Button: tui.MouseButtonLeft},
+	)
+	if got := navigated(d); len(got) != 1 || got[0] != "Tools" {
+		t.Errorf("published %q, want Tools", got)
+	}
+}
+
+func TestJump(t *testing.T) {
+	m := newBar(t, "Home", "Tools")
+	if _, ok := m.Jump(tuitest.Key(t, "2")); ok {
+		t.Error("Jump handled a plain number key")
+	}
+	cmd, ok := m.Jump(tuitest.Key(t, "alt+2"))
+	if !ok || cmd == nil {
+		t.Fatal("Jump(alt+2) did not select the second tab")
+	}
+	if _, ok := m.Jump(tuitest.Key(t, "alt+9")); !ok {
+		t.Error("Jump(alt+9) was not handled without a ninth tab")
+	}
+	if i, _ := m.Active(); i != 1 {
+		t.Errorf("active tab = %d, want 1", i)
+	}
+}
+
+func TestScrollOffset(t *testing.T) {
+	labels := []string{"aaaa", "bbbb", "cccc", "dddd"}
+	tests := []struct {
+		name                  string
+		active, offset, width int
+		want                  int
+	}{
+		{"fits", 1, 0, 20, 0},
+		{"scrolls right", 3, 0, 10, 2},
+		{"keeps offset", 2, 1, 10, 1},
+		{"scrolls left", 0, 2, 10, 0},
+		{"too narrow", 2, 0, 3, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scrollOffset(labels, tt.active, tt.offset, tt.width, 1, 1); got != tt.want {
+				t.Errorf("scrollOffset = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
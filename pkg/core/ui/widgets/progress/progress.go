@@ -0,0 +1,330 @@
+// Package progress provides the progress bar widget, determinate (a
+// fraction of the work done) or indeterminate (a block sweeping the bar
+// while the amount of work is unknown).
+//
+//	bar := progress.New()
+//	bar.SetProgress(written, size)
+//
+//	busy := progress.New(progress.WithIndeterminate(true))
+//	return busy.Init() // starts the sweep
+//
+// Bar and Pulse draw bars without a model, for widgets that show many of
+// them, such as the TaskPanel.
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Component interface.
+var _ tui.Component = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// ID identifies a progress bar in its messages.
+type ID int64
+
+var lastID atomic.Int64
+
+// TickMsg advances the sweep of an indeterminate bar.
+type TickMsg struct {
+	ID ID
+	// tag drops the ticks of an earlier sweep, so a bar never runs two
+	// clocks.
+	tag int
+}
+
+// Option is a functional option for configuring the progress bar.
+type Option func(*Model)
+
+// Model is a progress bar.
+type Model struct {
+	width, height int // width 0 fills the slot
+	slot          int
+	tui.ViewCache
+
+	id            ID
+	percent       float64
+	indeterminate bool
+	showPercent   bool
+	frame         int
+	sweeping      bool
+	tag           int
+
+	style tui.Style
+}
+
+// New creates a new, empty, determinate progress bar.
+func New(opts ...Option) *Model {
+	m := &Model{
+		id:          ID(lastID.Add(1)),
+		showPercent: designsystem.Current().Components.Progress.ShowPercent,
+		style:       tui.NewStyle(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithPercent sets the fraction done, from 0 to 1.
+func WithPercent(percent float64) Option {
+	return func(m *Model) { m.SetPercent(percent) }
+}
+
+// WithIndeterminate makes the bar sweep instead of filling up.
+func WithIndeterminate(indeterminate bool) Option {
+	return func(m *Model) { m.indeterminate = indeterminate }
+}
+
+// WithShowPercent shows or hides the percentage after a determinate bar.
+func WithShowPercent(show bool) Option {
+	return func(m *Model) { m.showPercent = show }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+// --- tui.Model Implementation ---
+
+// Init starts the sweep of an indeterminate bar.
+func (m *Model) Init() tui.Cmd {
+	if m.indeterminate {
+		return m.sweep()
+	}
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.slot = msg.Width
+		m.MarkDirty()
+
+	case TickMsg:
+		if msg.ID != m.id || msg.tag != m.tag || !m.indeterminate {
+			return m, nil
+		}
+		m.frame++
+		m.MarkDirty()
+		return m, m.tick()
+	}
+	return m, nil
+}
+
+// sweep returns the command that starts the sweep, unless it runs already.
+func (m *Model) sweep() tui.Cmd {
+	if m.sweeping {
+		return nil
+	}
+	m.sweeping = true
+	m.tag++
+	return m.tick()
+}
+
+// tick schedules the next step of the sweep.
+func (m *Model) tick() tui.Cmd {
+	id, tag := m.id, m.tag
+	interval := designsystem.Current().Components.Spinner.Interval
+	return tui.Tick(interval, func(time.Time) tui.Msg { return TickMsg{ID: id, tag: tag} })
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+}
+
+// render draws the bar and its percentage; View caches the result.
+func (m *Model) render() string {
+	width := m.width
+	if width <= 0 {
+		width = m.slot
+	}
+	hFrame, _ := m.style.GetFrameSize()
+	width = max(width-hFrame, 0)
+
+	if m.indeterminate {
+		return m.style.Render(Pulse(width, m.frame))
+	}
+	if !m.showPercent {
+		return m.style.Render(Bar(width, m.percent))
+	}
+	label := formatPercent(m.percent)
+	if width <= len(label) {
+		return m.style.Render(tui.Truncate(label, width, ""))
+	}
+	return m.style.Render(Bar(width-len(label)-1, m.percent) + " " + label)
+}
+
+// AccessibleView returns the percentage done, or "working" while the bar
+// is indeterminate.
+func (m *Model) AccessibleView() string {
+	if m.indeterminate {
+		return "working"
+	}
+	return strings.TrimSpace(formatPercent(m.percent))
+}
+
+// Bar draws a determinate bar width cells wide, filled to percent (from 0
+// to 1), with the theme's glyphs.
+func Bar(width int, percent float64) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := int(clamp(percent) * float64(width))
+	return fill(0, filled, width)
+}
+
+// Pulse draws an indeterminate bar width cells wide at step frame of its
+// sweep: a block going back and forth across the bar.
+func Pulse(width, frame int) string {
+	if width <= 0 {
+		return ""
+	}
+	block := min(max(designsystem.Current().Components.Progress.Pulse, 1), width)
+	span := width - block
+	pos := 0
+	if span > 0 {
+		pos = frame % (2 * span)
+		if pos > span {
+			pos = 2*span - pos
+		}
+	}
+	return fill(pos, pos+block, width)
+}
+
+// fill draws width cells, filled from start to end.
+func fill(start, end, width int) string {
+	theme := designsystem.Current()
+	tokens := theme.Components.Progress
+	filled := tui.NewStyle().Foreground(tokens.FilledColor)
+	empty := tui.NewStyle().Foreground(tokens.EmptyColor)
+	emptyGlyph, filledGlyph := tokens.Empty.For(theme.Charset), tokens.Filled.For(theme.Charset)
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString(empty.Render(strings.Repeat(emptyGlyph, start)))
+	}
+	if end > start {
+		b.WriteString(filled.Render(strings.Repeat(filledGlyph, end-start)))
+	}
+	if width > end {
+		b.WriteString(empty.Render(strings.Repeat(emptyGlyph, width-end)))
+	}
+	return b.String()
+}
+
+// formatPercent returns percent as a four-cell label, e.g. " 42%".
+func formatPercent(percent float64) string {
+	return fmt.Sprintf("%3d%%", int(clamp(percent)*100))
+}
+
+func clamp(percent float64) float64 {
+	return min(max(percent, 0), 1)
+}
+
+// --- State ---
+
+// ID returns the identifier carried by the bar's messages.
+func (m *Model) ID() ID {
+	return m.id
+}
+
+// Percent returns the fraction done, from 0 to 1.
+func (m *Model) Percent() float64 {
+	return m.percent
+}
+
+// SetPercent sets the fraction done, clamped to 0..1.
+func (m *Model) SetPercent(percent float64) {
+	m.percent = clamp(percent)
+	m.MarkDirty()
+}
+
+// SetProgress sets the fraction done to done out of total. A total of 0 or
+// less empties the bar.
+func (m *Model) SetProgress(done, total int64) {
+	if total <= 0 {
+		m.SetPercent(0)
+		return
+	}
+	m.SetPercent(float64(done) / float64(total))
+}
+
+// Indeterminate reports whether the bar sweeps instead of filling up.
+func (m *Model) Indeterminate() bool {
+	return m.indeterminate
+}
+
+// SetIndeterminate switches between sweeping and filling up. It returns
+// the command that starts the sweep, if needed.
+func (m *Model) SetIndeterminate(indeterminate bool) tui.Cmd {
+	m.indeterminate = indeterminate
+	m.MarkDirty()
+	if !indeterminate {
+		m.sweeping = false
+		return nil
+	}
+	return m.sweep()
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.height = height
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
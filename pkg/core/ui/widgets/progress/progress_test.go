@@ -0,0 +1,121 @@
+package progress
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestBar(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	tests := []struct {
+		name    string
+		width   int
+		percent float64
+		want    string
+	}{
+		{"empty", 5, 0, "░░░░░"},
+		{"half", 6, 0.5, "███░░░"},
+		{"full", 4, 1, "████"},
+		{"over", 4, 1.5, "████"},
+		{"under", 4, -1, "░░░░"},
+		{"no room", 0, 0.5, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi.Strip(Bar(tt.width, tt.percent)); got != tt.want {
+				t.Errorf("Bar(%d, %v) = %q, want %q", tt.width, tt.percent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPulse(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	tests := []struct {
+		frame int
+		want  string
+	}{
+		{0, "####--"},
+		{1, "-####-"},
+		{2, "--####"},
+		{3, "-####-"},
+		{4, "####--"},
+	}
+
+	for _, tt := range tests {
+		if got := ansi.Strip(Pulse(6, tt.frame)); got != tt.want {
+			t.Errorf("Pulse(6, %d) = %q, want %q", tt.frame, got, tt.want)
+		}
+	}
+	if got := ansi.Strip(Pulse(2, 3)); got != "##" {
+		t.Errorf("Pulse(2, 3) = %q, want the block to fill a narrow bar", got)
+	}
+}
+
+func TestProgressView(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	tests := []struct {
+		name string
+		m    *Model
+		want string
+	}{
+		{"percent", New(WithPercent(0.42), WithWidth(15)), "####------  42%"},
+		{"no percent", New(WithPercent(0.5), WithShowPercent(false), WithWidth(8)), "####----"},
+		{"label only", New(WithPercent(1), WithWidth(4)), "100%"},
+		{"padded", New(WithPercent(0), WithShowPercent(false), WithWidth(6), WithPadding(0, 1)), " ---- "},
+		{"indeterminate", New(WithIndeterminate(true), WithWidth(6)), "####--"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ansi.Strip(tt.m.View()); got != tt.want {
+				t.Errorf("View() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressFillsSlot(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	m := New(WithShowPercent(false))
+	m.SetProgress(3, 4)
+	m.Update(tui.WindowSizeMsg{Width: 8, Height: 1})
+	if got := ansi.Strip(m.View()); got != "######--" {
+		t.Errorf("View() = %q, want the slot's width three quarters full", got)
+	}
+	if got := m.AccessibleView(); got != "75%" {
+		t.Errorf("AccessibleView() = %q, want %q", got, "75%")
+	}
+}
+
+func TestProgressSweep(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	m := New(WithWidth(6))
+	if m.Init() != nil {
+		t.Error("Init() of a determinate bar ticks")
+	}
+	if m.SetIndeterminate(true) == nil {
+		t.Fatal("SetIndeterminate(true) = nil, want the sweep to start")
+	}
+	if m.SetIndeterminate(true) != nil {
+		t.Error("SetIndeterminate(true) twice started a second clock")
+	}
+
+	m.Update(TickMsg{ID: m.ID(), tag: m.tag})
+	if got := ansi.Strip(m.View()); got != "-####-" {
+		t.Errorf("View() = %q after a tick, want the block moved", got)
+	}
+	if got := m.AccessibleView(); got != "working" {
+		t.Errorf("AccessibleView() = %q, want %q", got, "working")
+	}
+
+	m.SetIndeterminate(false)
+	if _, cmd := m.Update(TickMsg{ID: m.ID(), tag: m.tag}); cmd != nil {
+		t.Error("a determinate bar kept sweeping")
+	}
+}
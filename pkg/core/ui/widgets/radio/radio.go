@@ -0,0 +1,399 @@
+// Package radio provides the RadioGroup widget: a set of choices of which
+// at most one is selected.
+//
+// Arrows move between the choices and Space or Enter selects one; a click
+// selects it directly. Either way the group sends a ChangeMsg:
+//
+//	shell := radio.New([]string{"bash", "zsh", "fish"}, radio.WithSelected(1))
+//
+//	case radio.ChangeMsg:
+//		if msg.ID == m.shell.ID() { m.cfg.Shell = msg.Value }
+package radio
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Component interface.
+var _ tui.Component = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// keyScope is the keymap scope of the radio group actions.
+const keyScope = "radio"
+
+// Radio group actions, active while a group has focus.
+var (
+	actionPrev   = tui.Keys.Register(keyScope, "radio.prev", "Previous choice", "up", "left", "k")
+	actionNext   = tui.Keys.Register(keyScope, "radio.next", "Next choice", "down", "right", "j")
+	actionSelect = tui.Keys.Register(keyScope, "radio.select", "Select choice", "space", "enter")
+)
+
+// ID identifies a radio group in its messages.
+type ID int64
+
+var lastID atomic.Int64
+
+// ChangeMsg is sent when the user selects a choice.
+type ChangeMsg struct {
+	ID    ID
+	Index int
+	Value string
+}
+
+// Option is a functional option for configuring the RadioGroup.
+type Option func(*Model)
+
+// Model is a group of radio buttons.
+type Model struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+
+	id         ID
+	choices    []string
+	zones      []tui.ZoneID
+	selected   int // -1 when nothing is selected
+	cursor     int
+	offset     int // the first choice in view, when they are stacked
+	horizontal bool
+	disabled   bool
+
+	// style holds the widget's own styling, which wins over the theme's.
+	style tui.Style
+}
+
+// New creates a new RadioGroup of choices, with nothing selected.
+func New(choices []string, opts ...Option) *Model {
+	m := &Model{
+		id:       ID(lastID.Add(1)),
+		selected: -1,
+		style:    tui.NewStyle(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.SetChoices(choices)
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithSelected selects the choice at index.
+func WithSelected(index int) Option {
+	return func(m *Model) { m.selected, m.cursor = index, max(index, 0) }
+}
+
+// WithHorizontal lays the choices out on one line.
+func WithHorizontal(horizontal bool) Option {
+	return func(m *Model) { m.horizontal = horizontal }
+}
+
+// WithDisabled disables the group: it is drawn muted and ignores input.
+func WithDisabled(disabled bool) Option {
+	return func(m *Model) { m.disabled = disabled }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+// clickMsg reports a click on a choice.
+type clickMsg struct {
+	id     ID
+	choice int
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		}
+		m.scroll()
+
+	case tui.KeyMsg:
+		if m.disabled || !m.Focused() {
+			return m, nil
+		}
+		switch {
+		case tui.Keys.Matches(msg, actionPrev):
+			m.moveTo(m.cursor - 1)
+		case tui.Keys.Matches(msg, actionNext):
+			m.moveTo(m.cursor + 1)
+		case tui.Keys.Matches(msg, actionSelect):
+			return m, m.selectChoice(m.cursor)
+		}
+
+	case clickMsg:
+		if msg.id == m.id && !m.disabled {
+			m.moveTo(msg.choice)
+			return m, m.selectChoice(msg.choice)
+		}
+	}
+	return m, nil
+}
+
+// moveTo moves the cursor, clamped to the choices, and scrolls to it.
+func (m *Model) moveTo(cursor int) {
+	cursor = min(max(cursor, 0), max(len(m.choices)-1, 0))
+	if cursor != m.cursor {
+		m.cursor = cursor
+		m.scroll()
+		m.MarkDirty()
+	}
+}
+
+// visibleRows returns the number of stacked choices in view; 0 means all of
+// them. Side by side, the choices take one line.
+func (m *Model) visibleRows() int {
+	if m.height <= 0 || m.horizontal {
+		return 0
+	}
+	_, vFrame := m.style.GetFrameSize()
+	return max(m.height-vFrame, 1)
+}
+
+// scroll moves the view so the cursor is in it.
+func (m *Model) scroll() {
+	rows := m.visibleRows()
+	if rows == 0 {
+		m.offset = 0
+		return
+	}
+	m.offset = min(m.offset, m.cursor)
+	m.offset = max(m.offset, m.cursor-rows+1)
+	// No empty rows at the bottom while there are choices above.
+	m.offset = max(min(m.offset, len(m.choices)-rows), 0)
+	m.MarkDirty()
+}
+
+// selectChoice selects the choice at i and reports it, unless it already
+// was selected.
+func (m *Model) selectChoice(i int) tui.Cmd {
+	if i < 0 || i >= len(m.choices) || i == m.selected {
+		return nil
+	}
+	m.selected = i
+	m.MarkDirty()
+	msg := ChangeMsg{ID: m.id, Index: i, Value: m.choices[i]}
+	return func() tui.Msg { return msg }
+}
+
+func (m *Model) View() string {
+	m.bindZones()
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// bindZones registers the click handlers of the choices the first time the
+// group is drawn, so a group that is never shown leaves nothing in the zone
+// map.
+func (m *Model) bindZones() {
+	for choice, zone := range m.zones {
+		if tui.Zones.Registered(zone) {
+			continue
+		}
+		tui.Zones.OnClick(zone, func() tui.Cmd {
+			id := m.id
+			return func() tui.Msg { return clickMsg{id: id, choice: choice} }
+		})
+	}
+}
+
+// render draws the choices, each with its glyph; View caches the result.
+func (m *Model) render() string {
+	theme := designsystem.Current()
+	tokens := theme.Components.Radio
+
+	end := len(m.choices)
+	if rows := m.visibleRows(); rows > 0 {
+		end = min(m.offset+rows, end)
+	}
+	items := make([]string, 0, end-m.offset)
+	for i := m.offset; i < end; i++ {
+		choice := m.choices[i]
+		glyph, color := tokens.Off, tokens.OffColor
+		if i == m.selected {
+			glyph, color = tokens.On, tokens.OnColor
+		}
+		dot := tui.NewStyle().Foreground(color)
+		text := tui.NewStyle()
+		switch {
+		case m.disabled:
+			dot = theme.State.Disabled.Apply(dot)
+			text = theme.State.Disabled.Apply(text)
+		case m.Focused() && i == m.cursor:
+			text = theme.State.Focus.Apply(text)
+		}
+		items = append(items, tui.MarkZone(m.zones[i], dot.Render(glyph.For(theme.Charset))+" "+text.Render(choice)))
+	}
+
+	sep := "\n"
+	if m.horizontal {
+		sep = "  "
+	}
+	view := strings.Join(items, sep)
+	if m.width > 0 {
+		hFrame, _ := m.style.GetFrameSize()
+		lines := strings.Split(view, "\n")
+		for i, line := range lines {
+			lines[i] = tui.Truncate(line, max(m.width-hFrame, 0), "…")
+		}
+		view = strings.Join(lines, "\n")
+	}
+	return m.style.Render(view)
+}
+
+// AccessibleView returns the choices with the selected one marked, the
+// cursor marked with "> ".
+func (m *Model) AccessibleView() string {
+	lines := make([]string, len(m.choices))
+	for i, choice := range m.choices {
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "> "
+		}
+		lines[i] = prefix + choice
+		if i == m.selected {
+			lines[i] += " (selected)"
+		}
+	}
+	if m.disabled {
+		lines = append(lines, "(disabled)")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// --- State ---
+
+// ID returns the identifier carried by the group's messages.
+func (m *Model) ID() ID {
+	return m.id
+}
+
+// Selected returns the index and the value of the selected choice; index
+// is -1 when nothing is selected.
+func (m *Model) Selected() (index int, value string) {
+	if m.selected < 0 || m.selected >= len(m.choices) {
+		return -1, ""
+	}
+	return m.selected, m.choices[m.selected]
+}
+
+// SetSelected selects the choice at index (-1 for none) without sending a
+// ChangeMsg.
+func (m *Model) SetSelected(index int) {
+	m.selected = index
+	m.moveTo(index)
+	m.MarkDirty()
+}
+
+// SetChoices replaces the choices, keeping the selection when it is still
+// in range.
+func (m *Model) SetChoices(choices []string) {
+	m.Close()
+	m.choices = choices
+	m.zones = make([]tui.ZoneID, len(choices))
+	for i := range m.zones {
+		m.zones[i] = tui.NewZoneID()
+	}
+	if m.selected >= len(choices) {
+		m.selected = -1
+	}
+	m.moveTo(m.cursor)
+	m.scroll()
+	m.MarkDirty()
+}
+
+// SetDisabled enables or disables the group.
+func (m *Model) SetDisabled(disabled bool) {
+	m.disabled = disabled
+	m.MarkDirty()
+}
+
+// Close forgets the zones of the choices. Call it when the group is
+// discarded.
+func (m *Model) Close() {
+	for _, zone := range m.zones {
+		tui.Zones.Remove(zone)
+	}
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.desiredWidth = width
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.desiredHeight = height
+	m.height = height
+	m.scroll()
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
@@ -0,0 +1,105 @@
+package radio
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// changes returns the ChangeMsgs sent so far.
+func changes(d *tuitest.Driver) []ChangeMsg {
+	var changes []ChangeMsg
+	for _, msg := range d.Messages() {
+		if msg, ok := msg.(ChangeMsg); ok {
+			changes = append(changes, msg)
+		}
+	}
+	return changes
+}
+
+func TestRadioGroup(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	r := New([]string{"bash", "zsh", "fish"}, WithSelected(0))
+	t.Cleanup(r.Close)
+	r.Focus()
+	d := tuitest.New(t, r, tuitest.WithSize(20, 3))
+	d.Golden("first")
+
+	// Moving does not select; Space does.
+	d.Press(tui.KeyDown, tui.KeyDown)
+	if i, _ := r.Selected(); i != 0 || len(changes(d)) != 0 {
+		t.Fatalf("selected %d after moving, want 0 and no change", i)
+	}
+	d.Press(tui.KeySpace)
+	d.Golden("fish")
+
+	got := changes(d)
+	if len(got) != 1 || got[0] != (ChangeMsg{ID: r.ID(), Index: 2, Value: "fish"}) {
+		t.Errorf("changes = %+v, want fish", got)
+	}
+
+	// Selecting the selected choice again changes nothing.
+	d.Press(tui.KeyEnter)
+	if len(changes(d)) != 1 {
+		t.Errorf("%d changes, want 1", len(changes(d)))
+	}
+}
+
+func TestRadioClick(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	r := New([]string{"bash", "zsh", "fish"}, WithHorizontal(true))
+	t.Cleanup(r.Close)
+	d := tuitest.New(t, r, tuitest.WithSize(30, 1))
+
+	zone, ok := tui.Zones.Get(r.zones[1])
+	if !ok {
+		t.Fatal("the choice was not drawn")
+	}
+	d.Send(
+		tui.MouseMsg{X: zone.X, Y: zone.Y, Action: tui.MouseActionPress, Button: tui.MouseButtonLeft},
+		tui.MouseMsg{X: zone.X, Y: zone.Y, Action: tui.MouseActionRelease, Button: tui.MouseButtonLeft},
+	)
+	if i, value := r.Selected(); i != 1 || value != "zsh" {
+		t.Errorf("selected %d %q, want zsh", i, value)
+	}
+	d.Golden("horizontal")
+}
+
+func TestRadioDisabled(t *testing.T) {
+	r := New([]string{"bash", "zsh"}, WithDisabled(true))
+	t.Cleanup(r.Close)
+	r.Focus()
+	tuitest.New(t, r).Press(tui.KeySpace)
+	if i, _ := r.Selected(); i != -1 {
+		t.Errorf("selected %d, want none", i)
+	}
+	if got := tui.AccessibleText(r); got != "> bash\n  zsh\n(disabled)" {
+		t.Errorf("AccessibleText = %q", got)
+	}
+}
+
+func TestRadioASCIIFallback(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+
+	r := New([]string{"on", "off"}, WithSelected(0))
+	t.Cleanup(r.Close)
+	if got := tui.StripZones(r.View()); got != "(*) on\n( ) off" {
+		t.Errorf("view = %q, want ASCII glyphs", got)
+	}
+}
+
+func TestRadioScrollsInSlot(t *testing.T) {
+	r := New([]string{"bash", "zsh", "fish", "nu", "pwsh"})
+	t.Cleanup(r.Close)
+	r.Focus()
+	d := tuitest.New(t, r, tuitest.WithSize(20, 2))
+	d.Press(tui.KeyDown, tui.KeyDown, tui.KeyDown)
+
+	lines := strings.Split(tui.StripZones(r.View()), "\n")
+	if len(lines) != 2 || !strings.HasSuffix(lines[1], "nu") {
+		t.Errorf("view = %q, want two lines ending at the cursor on nu", lines)
+	}
+}
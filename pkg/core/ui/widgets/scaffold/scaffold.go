@@ -1,16 +1,23 @@
 package scaffold
 
 import (
+	"strings"
+
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/appbar"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/bottombar"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/containerbox"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/navbar"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/sidemenu"
 )
 
 // Ensure Model implements the tui.Model and tui.Layout interfaces.
 var _ tui.Model = (*Model)(nil)
 var _ tui.Layout = (*Model)(nil)
+var _ tui.KeyScoper = (*Model)(nil)
+var _ tui.KeyHandler = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
 
 // Option is a functional option for configuring the Scaffold.
 type Option func(*Model)
@@ -20,17 +27,28 @@ type Model struct {
 	desiredWidth, desiredHeight int // 0 means not explicitly set
 
 	AppBar       *appbar.Model
+	NavBar       *navbar.Model
 	sidemenu       *sidemenu.Model
 	BottomBar    *bottombar.Model
 	ContainerBox *containerbox.Model
 
+	// focus tracks which slot receives key input, in tab order.
+	focus *tui.FocusManager
+
+	// The frame is cached as a whole and per slot, so a message that
+	// changes one slot only re-frames that slot.
+	tui.ViewCache
+	slotFrames [slotCount]tui.ViewCache
+
 	marginStyle       tui.Style
 	containerStyle    tui.Style
 	appBarStyle       tui.Style
+	navBarStyle       tui.Style
 	sidemenuStyle       tui.Style
 	bottomBarStyle    tui.Style
 	containerboxStyle tui.Style
 	appBarHeight      int
+	navBarHeight      int
 	sidemenuWidth       int
 	bottomBarHeight   int
 }
@@ -41,6 +59,7 @@ func New(opts ...Option) *Model {
 		marginStyle:       tui.NewStyle(),
 		containerStyle:    tui.NewStyle(),
 		appBarStyle:       tui.NewStyle(),
+		navBarStyle:       tui.NewStyle(),
 		sidemenuStyle:       tui.NewStyle(),
 		bottomBarStyle:    tui.NewStyle(),
 		containerboxStyle: tui.NewStyle(),
@@ -50,6 +69,24 @@ func New(opts ...Option) *Model {
 		opt(m)
 	}
 
+	// Tab order follows the reading order of the slots.
+	m.focus = tui.NewFocusManager()
+	if m.AppBar != nil {
+		m.focus.Add(m.AppBar)
+	}
+	if m.NavBar != nil {
+		m.focus.Add(m.NavBar)
+	}
+	if m.sidemenu != nil {
+		m.focus.Add(m.sidemenu)
+	}
+	if m.ContainerBox != nil {
+		m.focus.Add(m.ContainerBox)
+	}
+	if m.BottomBar != nil {
+		m.focus.Add(m.BottomBar)
+	}
+
 	return m
 }
 
@@ -59,6 +96,11 @@ func WithAppBar(appBar *appbar.Model) Option {
 	return func(m *Model) { m.AppBar = appBar }
 }
 
+// WithNavBar sets the tabs shown under the AppBar.
+func WithNavBar(navBar *navbar.Model) Option {
+	return func(m *Model) { m.NavBar = navBar }
+}
+
 func Withsidemenu(sidemenu *sidemenu.Model) Option {
 	return func(m *Model) { m.sidemenu = sidemenu }
 }
@@ -72,37 +114,45 @@ func WithContainerBox(containerbox *containerbox.Model) Option {
 }
 
 func WithMargin(m ...int) Option {
-	return func(model *Model) { model.marginStyle.Margin(m...) }
+	return func(model *Model) { model.marginStyle = model.marginStyle.Margin(m...) }
 }
 
 func WithPadding(p ...int) Option {
-	return func(model *Model) { model.containerStyle.Padding(p...) }
+	return func(model *Model) { model.containerStyle = model.containerStyle.Padding(p...) }
 }
 
 func WithBackgroundColor(c string) Option {
-	return func(m *Model) { m.containerStyle.Background(c) }
+	return func(m *Model) { m.containerStyle = m.containerStyle.Background(c) }
 }
 
 func WithAppBarBackgroundColor(c string) Option {
-	return func(m *Model) { m.appBarStyle.Background(c) }
+	return func(m *Model) { m.appBarStyle = m.appBarStyle.Background(c) }
+}
+
+func WithNavBarBackgroundColor(c string) Option {
+	return func(m *Model) { m.navBarStyle = m.navBarStyle.Background(c) }
 }
 
 func WithsidemenuBackgroundColor(c string) Option {
-	return func(m *Model) { m.sidemenuStyle.Background(c) }
+	return func(m *Model) { m.sidemenuStyle = m.sidemenuStyle.Background(c) }
 }
 
 func WithBottomBarBackgroundColor(c string) Option {
-	return func(m *Model) { m.bottomBarStyle.Background(c) }
+	return func(m *Model) { m.bottomBarStyle = m.bottomBarStyle.Background(c) }
 }
 
 func WithContainerBoxBackgroundColor(c string) Option {
-	return func(m *Model) { m.containerboxStyle.Background(c) }
+	return func(m *Model) { m.containerboxStyle = m.containerboxStyle.Background(c) }
 }
 
 func WithAppBarHeight(h int) Option {
 	return func(m *Model) { m.appBarHeight = h }
 }
 
+func WithNavBarHeight(h int) Option {
+	return func(m *Model) { m.navBarHeight = h }
+}
+
 func WithsidemenuWidth(w int) Option {
 	return func(m *Model) { m.sidemenuWidth = w }
 }
@@ -131,6 +181,9 @@ func (m *Model) Init() tui.Cmd {
 	if m.AppBar != nil {
 		cmds = append(cmds, m.AppBar.Init())
 	}
+	if m.NavBar != nil {
+		cmds = append(cmds, m.NavBar.Init())
+	}
 	if m.sidemenu != nil {
 		cmds = append(cmds, m.sidemenu.Init())
 	}
@@ -140,6 +193,13 @@ func (m *Model) Init() tui.Cmd {
 	if m.ContainerBox != nil {
 		cmds = append(cmds, m.ContainerBox.Init())
 	}
+
+	// The main content starts with focus; otherwise the first slot does.
+	if m.ContainerBox != nil {
+		cmds = append(cmds, m.focus.Focus(m.ContainerBox))
+	} else {
+		cmds = append(cmds, m.focus.Next())
+	}
 	return tui.Batch(cmds...)
 }
 
@@ -166,44 +226,27 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 		}
 
 		// Now, calculate slot dimensions for children based on Scaffold's new size
-		appBarHeight := 0
-		if m.AppBar != nil {
-			appBarHeight = m.appBarHeight
-			if appBarHeight == 0 {
-				appBarHeight = 3
-			}
-		}
-
-		bottomBarHeight := 0
-		if m.BottomBar != nil {
-			bottomBarHeight = m.bottomBarHeight
-			if bottomBarHeight == 0 {
-				bottomBarHeight = 3
-			}
-		}
-
-		sidemenuWidth := 0
-		if m.sidemenu != nil {
-			sidemenuWidth = m.sidemenuWidth
-			if sidemenuWidth == 0 {
-				sidemenuWidth = 20
-			}
-		}
-
-		mainContentHeight := m.height - appBarHeight - bottomBarHeight
-		mainContentWidth := m.width
+		slots := m.layout()
 
 		// Delegate updates to children, passing their specific slot dimensions
 		if m.AppBar != nil {
-			appBarSlotMsg := tui.WindowSizeMsg{Width: m.width, Height: appBarHeight}
+			appBarSlotMsg := tui.WindowSizeMsg{Width: slots.appBar.Width, Height: slots.appBar.Height}
 			newAppBar, cmd := m.AppBar.Update(appBarSlotMsg)
 			newAppBarModel := newAppBar.(*appbar.Model)
 			*m.AppBar = *newAppBarModel
 			cmds = append(cmds, cmd)
 		}
 
+		if m.NavBar != nil {
+			navBarSlotMsg := tui.WindowSizeMsg{Width: slots.navBar.Width, Height: slots.navBar.Height}
+			newNavBar, cmd := m.NavBar.Update(navBarSlotMsg)
+			newNavBarModel := newNavBar.(*navbar.Model)
+			*m.NavBar = *newNavBarModel
+			cmds = append(cmds, cmd)
+		}
+
 		if m.sidemenu != nil {
-			sidemenuSlotMsg := tui.WindowSizeMsg{Width: sidemenuWidth, Height: mainContentHeight}
+			sidemenuSlotMsg := tui.WindowSizeMsg{Width: slots.sidemenu.Width, Height: slots.sidemenu.Height}
 			newsidemenu, cmd := m.sidemenu.Update(sidemenuSlotMsg)
 			newsidemenuModel := newsidemenu.(*sidemenu.Model)
 			*m.sidemenu = *newsidemenuModel
@@ -211,7 +254,7 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 		}
 
 		if m.BottomBar != nil {
-			bottomBarSlotMsg := tui.WindowSizeMsg{Width: m.width, Height: bottomBarHeight}
+			bottomBarSlotMsg := tui.WindowSizeMsg{Width: slots.bottomBar.Width, Height: slots.bottomBar.Height}
 			newBottomBar, cmd := m.BottomBar.Update(bottomBarSlotMsg)
 			newBottomBarModel := newBottomBar.(*bottombar.Model)
 			*m.BottomBar = *newBottomBarModel
@@ -219,34 +262,53 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 		}
 
 		if m.ContainerBox != nil {
-			containerBoxSlotWidth := mainContentWidth - sidemenuWidth
-			containerBoxSlotMsg := tui.WindowSizeMsg{Width: containerBoxSlotWidth, Height: mainContentHeight}
+			containerBoxSlotMsg := tui.WindowSizeMsg{Width: slots.containerBox.Width, Height: slots.containerBox.Height}
 			newContainerBox, cmd := m.ContainerBox.Update(containerBoxSlotMsg)
 			newContainerBoxModel := newContainerBox.(*containerbox.Model)
 			*m.ContainerBox = *newContainerBoxModel
 			cmds = append(cmds, cmd)
 		}
 	} else {
-		// If it's not a WindowSizeMsg, propagate the original message to all children
-		if m.AppBar != nil {
+		// Tab, Shift+Tab and focus requests move focus between slots.
+		if handled, cmd := m.focus.Update(msg); handled {
+			return m, cmd
+		}
+
+		// The NavBar's jump keys switch tabs whichever slot has focus.
+		if key, ok := msg.(tui.KeyMsg); ok && m.NavBar != nil {
+			if cmd, handled := m.NavBar.Jump(key); handled {
+				return m, cmd
+			}
+		}
+
+		// Input only reaches the focused slot; any other message is
+		// propagated to all children.
+		input := tui.IsInputMsg(msg)
+		if m.AppBar != nil && (!input || m.AppBar.Focused()) {
 			newAppBar, cmd := m.AppBar.Update(msg)
 			newAppBarModel := newAppBar.(*appbar.Model)
 			*m.AppBar = *newAppBarModel
 			cmds = append(cmds, cmd)
 		}
-		if m.sidemenu != nil {
+		if m.NavBar != nil && (!input || m.NavBar.Focused()) {
+			newNavBar, cmd := m.NavBar.Update(msg)
+			newNavBarModel := newNavBar.(*navbar.Model)
+			*m.NavBar = *newNavBarModel
+			cmds = append(cmds, cmd)
+		}
+		if m.sidemenu != nil && (!input || m.sidemenu.Focused()) {
 			newsidemenu, cmd := m.sidemenu.Update(msg)
 			newsidemenuModel := newsidemenu.(*sidemenu.Model)
 			*m.sidemenu = *newsidemenuModel
 			cmds = append(cmds, cmd)
 		}
-		if m.BottomBar != nil {
+		if m.BottomBar != nil && (!input || m.BottomBar.Focused()) {
 			newBottomBar, cmd := m.BottomBar.Update(msg)
 			newBottomBarModel := newBottomBar.(*bottombar.Model)
 			*m.BottomBar = *newBottomBarModel
 			cmds = append(cmds, cmd)
 		}
-		if m.ContainerBox != nil {
+		if m.ContainerBox != nil && (!input || m.ContainerBox.Focused()) {
 			newContainerBox, cmd := m.ContainerBox.Update(msg)
 			newContainerBoxModel := newContainerBox.(*containerbox.Model)
 			*m.ContainerBox = *newContainerBoxModel
@@ -262,99 +324,232 @@ func (m *Model) View() string {
 		return "Initializing scaffold..."
 	}
 
-	appBarHeight := 0
+	slots := m.layout()
+
+	var appBarView, navBarView, sidemenuView, bottomBarView, containerBoxView string
+
 	if m.AppBar != nil {
-		appBarHeight = m.appBarHeight
-		if appBarHeight == 0 {
-			appBarHeight = 3
-		}
+		appBarView = m.frameSlot(slotAppBar, m.appBarStyle, slots.appBar, m.AppBar.View())
 	}
 
-	bottomBarHeight := 0
-	if m.BottomBar != nil {
-		bottomBarHeight = m.bottomBarHeight
-		if bottomBarHeight == 0 {
-			bottomBarHeight = 3
-		}
+	if m.NavBar != nil {
+		navBarView = m.frameSlot(slotNavBar, m.navBarStyle, slots.navBar, m.NavBar.View())
 	}
 
-	sidemenuWidth := 0
 	if m.sidemenu != nil {
-		sidemenuWidth = m.sidemenuWidth
-		if sidemenuWidth == 0 {
-			sidemenuWidth = 20
-		}
+		sidemenuView = m.frameSlot(slotSidemenu, m.sidemenuStyle, slots.sidemenu, m.sidemenu.View())
 	}
 
-	containerBoxHeight := m.height - appBarHeight - bottomBarHeight
-	containerBoxWidth := m.width - sidemenuWidth
+	if m.BottomBar != nil {
+		bottomBarView = m.frameSlot(slotBottomBar, m.bottomBarStyle, slots.bottomBar, m.BottomBar.View())
+	}
 
-	var appBarView, sidemenuView, bottomBarView, containerBoxView string
+	if m.ContainerBox != nil {
+		containerBoxView = m.frameSlot(slotContainerBox, m.containerboxStyle, slots.containerBox, m.ContainerBox.View())
+	}
 
-	if m.AppBar != nil {
-		viewStyle := m.appBarStyle.Width(m.width).Height(appBarHeight).Align(tui.Center)
-		appBarView = viewStyle.Render(m.AppBar.View())
+	key := tui.ViewKey{
+		Width:  m.width,
+		Height: m.height,
+		Parts:  []string{appBarView, navBarView, sidemenuView, containerBoxView, bottomBarView},
 	}
+	return m.CachedView(key, func() string {
+		return m.render(appBarView, navBarView, sidemenuView, containerBoxView, bottomBarView)
+	})
+}
 
-	if m.sidemenu != nil {
-		viewStyle := m.sidemenuStyle.Width(sidemenuWidth).Height(containerBoxHeight).Align(tui.Center)
-		sidemenuView = viewStyle.Render(m.sidemenu.View())
+// render joins the framed slots into the Scaffold's frame.
+func (m *Model) render(appBarView, navBarView, sidemenuView, containerBoxView, bottomBarView string) string {
+	maincontainerbox := tui.JoinHorizontal(tui.Top, sidemenuView, containerBoxView)
+
+	rows := []string{appBarView, maincontainerbox, bottomBarView}
+	if m.NavBar != nil {
+		rows = []string{appBarView, navBarView, maincontainerbox, bottomBarView}
+	}
+	finalView := tui.JoinVertical(tui.Left, rows...)
+
+	container := m.containerStyle.Width(m.width).Height(m.height).Render(finalView)
+
+	return m.marginStyle.Render(container)
+}
+
+// HandlesKey reports whether the focused slot uses msg itself.
+func (m *Model) HandlesKey(msg tui.KeyMsg) bool {
+	focused := m.focus.Focused()
+	return focused != nil && tui.HandlesKey(focused, msg)
+}
+
+// KeyScopes returns the focus traversal scope, the NavBar's jump keys and
+// the scopes of the focused slot, if it has bindings of its own.
+func (m *Model) KeyScopes() []string {
+	scopes := []string{tui.FocusScope}
+	if m.NavBar != nil {
+		scopes = append(m.NavBar.JumpScopes(), scopes...)
+	}
+	if scoper, ok := m.focus.Focused().(tui.KeyScoper); ok {
+		scopes = append(scoper.KeyScopes(), scopes...)
 	}
+	return scopes
+}
 
+// AccessibleView returns the text of each slot, top to bottom, separated
+// by blank lines.
+func (m *Model) AccessibleView() string {
+	var sections []string
+	add := func(text string) {
+		if text != "" {
+			sections = append(sections, text)
+		}
+	}
+	if m.AppBar != nil {
+		add(m.AppBar.AccessibleView())
+	}
+	if m.NavBar != nil {
+		add(m.NavBar.AccessibleView())
+	}
+	if m.sidemenu != nil {
+		add(m.sidemenu.AccessibleView())
+	}
+	if m.ContainerBox != nil {
+		add(m.ContainerBox.AccessibleView())
+	}
 	if m.BottomBar != nil {
-		viewStyle := m.bottomBarStyle.Width(m.width).Height(bottomBarHeight).Align(tui.Center)
-		bottomBarView = viewStyle.Render(m.BottomBar.View())
+		add(m.BottomBar.AccessibleView())
 	}
+	return strings.Join(sections, "\n\n")
+}
 
+// Dispose disposes the slots that hold resources, such as bus
+// subscriptions.
+func (m *Model) Dispose() {
+	if m.AppBar != nil {
+		m.AppBar.Dispose()
+	}
+	if m.NavBar != nil {
+		m.NavBar.Dispose()
+	}
+	if m.sidemenu != nil {
+		m.sidemenu.Dispose()
+	}
 	if m.ContainerBox != nil {
-		viewStyle := m.containerboxStyle.Width(containerBoxWidth).Height(containerBoxHeight).Align(tui.Center)
-		containerBoxView = viewStyle.Render(m.ContainerBox.View())
+		m.ContainerBox.Dispose()
+	}
+	if m.BottomBar != nil {
+		m.BottomBar.Dispose()
 	}
+}
 
-	maincontainerbox := tui.JoinHorizontal(tui.Top, sidemenuView, containerBoxView)
+// --- Slot Layout ---
 
-	finalView := tui.JoinVertical(tui.Left, appBarView, maincontainerbox, bottomBarView)
+// Slot indexes into slotFrames.
+const (
+	slotAppBar = iota
+	slotNavBar
+	slotSidemenu
+	slotContainerBox
+	slotBottomBar
+	slotCount
+)
 
-	container := m.containerStyle.Width(m.width).Height(m.height).Render(finalView)
+// frameSlot sizes a slot's view to its area with the slot style, reusing
+// the last result while the view and the area are unchanged.
+func (m *Model) frameSlot(slot int, style tui.Style, area tui.Rect, view string) string {
+	key := tui.ViewKey{Width: area.Width, Height: area.Height, Parts: []string{view}}
+	return m.slotFrames[slot].CachedView(key, func() string {
+		return style.Width(area.Width).Height(area.Height).Align(tui.Center).Render(view)
+	})
+}
 
-	return m.marginStyle.Render(container)
+// slotRects holds the area of each slot. Absent slots get an empty Rect.
+type slotRects struct {
+	appBar, navBar, sidemenu, containerBox, bottomBar tui.Rect
+}
+
+// layout arranges the slots in the Scaffold's area: a column with the
+// AppBar and NavBar above and the BottomBar below, at their fixed heights,
+// around a row holding the sidemenu and the ContainerBox, which takes the
+// remaining space.
+func (m *Model) layout() slotRects {
+	area := tui.Rect{Width: m.width, Height: m.height}
+	// Slots without a With...Height/Width option take the theme's size.
+	sizes := designsystem.Current().Components.Scaffold
+
+	column := tui.NewFlex(tui.Column).Add(
+		tui.Fixed(slotSize(m.AppBar != nil, m.appBarHeight, sizes.AppBarHeight)),
+		tui.Fixed(slotSize(m.NavBar != nil, m.navBarHeight, sizes.NavBarHeight)),
+		tui.Grow(1),
+		tui.Fixed(slotSize(m.BottomBar != nil, m.bottomBarHeight, sizes.BottomBarHeight)),
+	).Arrange(area)
+
+	row := tui.NewFlex(tui.Row).Add(
+		tui.Fixed(slotSize(m.sidemenu != nil, m.sidemenuWidth, sizes.SidemenuWidth)),
+		tui.Grow(1),
+	).Arrange(column[2])
+
+	return slotRects{
+		appBar:       column[0],
+		navBar:       column[1],
+		sidemenu:     row[0],
+		containerBox: row[1],
+		bottomBar:    column[3],
+	}
+}
+
+// slotSize returns the size of a slot: 0 when it is absent, otherwise the
+// configured size or the default.
+func slotSize(present bool, size, fallback int) int {
+	switch {
+	case !present:
+		return 0
+	case size > 0:
+		return size
+	default:
+		return fallback
+	}
 }
 
 // --- layout.Layout Implementation ---
 
 func (m *Model) BackgroundColor(color string) tui.Layout {
-	m.containerStyle.Background(color)
+	m.containerStyle = m.containerStyle.Background(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
-	m.containerStyle.Border(border, sides...)
+	m.containerStyle = m.containerStyle.Border(border, sides...)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) BorderForeground(color string) tui.Layout {
-	m.containerStyle.BorderForeground(color)
+	m.containerStyle = m.containerStyle.BorderForeground(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Padding(p ...int) tui.Layout {
-	m.containerStyle.Padding(p...)
+	m.containerStyle = m.containerStyle.Padding(p...)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Width(width int) tui.Layout {
 	m.desiredWidth = width
-	m.containerStyle.Width(width) // Apply to style immediately for GetFrameSize
+	m.containerStyle = m.containerStyle.Width(width) // Apply to style immediately for GetFrameSize
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Height(height int) tui.Layout {
 	m.desiredHeight = height
-	m.containerStyle.Height(height) // Apply to style immediately for GetFrameSize
+	m.containerStyle = m.containerStyle.Height(height) // Apply to style immediately for GetFrameSize
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Align(pos tui.Position) tui.Layout {
-	m.containerStyle.Align(pos)
+	m.containerStyle = m.containerStyle.Align(pos)
+	m.MarkDirty()
 	return m
 }
\ No newline at end of file
@@ -0,0 +1,139 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/appbar"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/bottombar"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/containerbox"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/sidemenu"
+)
+
+// benchList is a long list that draws only the rows in view, styling each
+// one, like the lists screens use.
+type benchList struct {
+	tui.ViewCache
+	rows                          []string
+	cursor, offset, width, height int
+}
+
+func newBenchList(n int) *benchList {
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = fmt.Sprintf("tool-%04d   1.%d.%d   installed   ~/.easyenv/tools/tool-%04d", i, i%17, i%5, i)
+	}
+	return &benchList{rows: rows}
+}
+
+func (l *benchList) Init() tui.Cmd { return nil }
+
+func (l *benchList) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		l.width, l.height = msg.Width, msg.Height
+	case tui.KeyMsg:
+		if msg.Type == tui.KeyDown {
+			l.cursor = (l.cursor + 1) % len(l.rows)
+			if l.cursor < l.offset {
+				l.offset = l.cursor
+			} else if l.cursor >= l.offset+l.height {
+				l.offset = l.cursor - l.height + 1
+			}
+			l.MarkDirty()
+		}
+	}
+	return l, nil
+}
+
+func (l *benchList) View() string {
+	return l.CachedView(tui.ViewKey{Width: l.width, Height: l.height}, func() string {
+		row := tui.NewStyle().Width(l.width).Foreground("#CCCCCC")
+		selected := row.Reverse(true).Bold(true)
+		end := min(l.offset+l.height, len(l.rows))
+		lines := make([]string, 0, end-l.offset)
+		for i := l.offset; i < end; i++ {
+			style := row
+			if i == l.cursor {
+				style = selected
+			}
+			lines = append(lines, style.Render(l.rows[i]))
+		}
+		return strings.Join(lines, "\n")
+	})
+}
+
+// newBenchScaffold builds a 200x60 scaffold holding a 5,000-row list.
+func newBenchScaffold() *Model {
+	m := New(
+		WithAppBar(appbar.New(appbar.WithBorder(tui.RoundedBorder))),
+		Withsidemenu(sidemenu.New(
+			sidemenu.WithBorder(tui.RoundedBorder),
+			sidemenu.WithItems(navigation.Route{ID: "home", Title: "Home"}, navigation.Route{ID: "tools", Title: "Tools"}),
+		)),
+		WithContainerBox(containerbox.New(
+			containerbox.WithBorder(tui.RoundedBorder),
+			containerbox.WithContent(newBenchList(5000)),
+		)),
+		WithBottomBar(bottombar.New(bottombar.WithBorder(tui.RoundedBorder))),
+	)
+	m.Init()
+	m.Update(tui.WindowSizeMsg{Width: 200, Height: 60})
+	return m
+}
+
+// noopMsg reaches every slot without changing any of them, like the ticks
+// and task messages that arrive while the screen is idle.
+type noopMsg struct{}
+
+// BenchmarkScaffoldView measures a message plus a frame render, with and
+// without the view cache. "idle" changes nothing on screen; "scroll" moves
+// the list cursor, so only the list and the frame around it are redrawn.
+//
+//	go test ./pkg/core/ui/widgets/scaffold -bench ScaffoldView -benchmem
+func BenchmarkScaffoldView(b *testing.B) {
+	messages := map[string]tui.Msg{
+		"idle":   noopMsg{},
+		"scroll": tui.KeyMsg{Type: tui.KeyDown},
+	}
+	for _, scenario := range []string{"idle", "scroll"} {
+		for _, cached := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/cache=%v", scenario, cached), func(b *testing.B) {
+				tui.SetViewCaching(cached)
+				b.Cleanup(func() { tui.SetViewCaching(true) })
+
+				m := newBenchScaffold()
+				m.View()
+				msg := messages[scenario]
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					m.Update(msg)
+					_ = m.View()
+				}
+			})
+		}
+	}
+}
+
+func TestScaffoldCachedViewMatchesRender(t *testing.T) {
+	cached := newBenchScaffold()
+	fresh := newBenchScaffold()
+	for range 3 {
+		cached.Update(tui.KeyMsg{Type: tui.KeyDown})
+		fresh.Update(tui.KeyMsg{Type: tui.KeyDown})
+	}
+	cached.View()
+	cached.Update(noopMsg{})
+	got := cached.View()
+
+	tui.SetViewCaching(false)
+	t.Cleanup(func() { tui.SetViewCaching(true) })
+	if want := fresh.View(); got != want {
+		t.Errorf("cached frame differs from a fresh render:\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,239 @@
+package scaffold
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/appbar"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/bottombar"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/containerbox"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/navbar"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/sidemenu"
+)
+
+func newTestScaffold() (*Model, []tui.Focusable) {
+	appBar := appbar.New(appbar.WithBorder(tui.NormalBorder))
+	menu := sidemenu.New(sidemenu.WithBorder(tui.NormalBorder))
+	content := containerbox.New(containerbox.WithBorder(tui.NormalBorder))
+	bottomBar := bottombar.New(bottombar.WithBorder(tui.NormalBorder))
+
+	m := New(
+		WithAppBar(appBar),
+		Withsidemenu(menu),
+		WithContainerBox(content),
+		WithBottomBar(bottomBar),
+	)
+	return m, []tui.Focusable{appBar, menu, content, bottomBar}
+}
+
+func TestScaffoldLayout(t *testing.T) {
+	m, _ := newTestScaffold()
+	d := tuitest.New(t, m, tuitest.WithSize(50, 14))
+	d.Golden("50x14")
+
+	d.Resize(70, 18)
+	d.Golden("70x18")
+}
+
+func TestScaffoldSlotSizesFromTheme(t *testing.T) {
+	theme := designsystem.Default()
+	theme.Components.Scaffold.AppBarHeight = 2
+	theme.Components.Scaffold.SidemenuWidth = 12
+	designsystem.SetCurrent(theme)
+	t.Cleanup(func() { designsystem.SetCurrent(nil) })
+
+	m, _ := newTestScaffold()
+	tuitest.New(t, m, tuitest.WithSize(50, 14))
+	rects := m.layout()
+	if rects.appBar.Height != 2 || rects.sidemenu.Width != 12 || rects.bottomBar.Height != 3 {
+		t.Errorf("AppBar height %d, sidemenu width %d, BottomBar height %d; want 2, 12 and 3",
+			rects.appBar.Height, rects.sidemenu.Width, rects.bottomBar.Height)
+	}
+}
+
+func TestScaffoldViewHasNoSideEffects(t *testing.T) {
+	m, _ := newTestScaffold()
+	d := tuitest.New(t, m, tuitest.WithSize(50, 14))
+	first := d.Frame()
+
+	// Rendering again, or resizing away and back, must give the same frame:
+	// per-frame sizes may not leak into the slots' stored styles.
+	if got := m.View(); got != m.View() {
+		t.Fatal("consecutive renders differ")
+	}
+	d.Resize(30, 10).Resize(90, 30).Resize(50, 14)
+	if got := d.Frame(); got != first {
+		t.Errorf("frame after resize round trip:\n%s\nwant\n%s", got, first)
+	}
+}
+
+func TestScaffoldFocusTraversal(t *testing.T) {
+	m, slots := newTestScaffold()
+	d := tuitest.New(t, m, tuitest.WithSize(50, 14))
+
+	focused := func() int {
+		t.Helper()
+		index := -1
+		for i, slot := range slots {
+			if slot.Focused() {
+				if index >= 0 {
+					t.Fatalf("slots %d and %d are focused at the same time", index, i)
+				}
+				index = i
+			}
+		}
+		return index
+	}
+
+	// The ContainerBox starts focused, Tab follows reading order and wraps.
+	steps := []struct {
+		key  tui.KeyMsg
+		want int
+	}{
+		{tui.KeyMsg{Type: tui.KeyTab}, 3},
+		{tui.KeyMsg{Type: tui.KeyTab}, 0},
+		{tui.KeyMsg{Type: tui.KeyTab, Shift: true}, 3},
+		{tui.KeyMsg{Type: tui.KeyTab, Shift: true}, 2},
+	}
+	if got := focused(); got != 2 {
+		t.Fatalf("initial focus = %d, want 2 (ContainerBox)", got)
+	}
+	for _, step := range steps {
+		d.Send(step.key)
+		if got := focused(); got != step.want {
+			t.Fatalf("after %s focus = %d, want %d", step.key, got, step.want)
+		}
+	}
+
+	d.Send(tui.FocusRequestMsg{Target: slots[1]})
+	if got := focused(); got != 1 {
+		t.Fatalf("after focus request focus = %d, want 1", got)
+	}
+
+	var changed *tui.FocusChangedMsg
+	for _, msg := range d.Messages() {
+		if msg, ok := msg.(tui.FocusChangedMsg); ok {
+			changed = &msg
+		}
+	}
+	if changed == nil || changed.Previous != slots[2] || changed.Current != slots[1] {
+		t.Errorf("last FocusChangedMsg = %+v, want ContainerBox -> sidemenu", changed)
+	}
+}
+
+func TestScaffoldDisposeEndsSubscriptions(t *testing.T) {
+	before := navigation.Navigated.Subscribers()
+	m := New(
+		WithAppBar(appbar.New()),
+		Withsidemenu(sidemenu.New(sidemenu.WithItems(navigation.Route{ID: "home", Title: "Home"}))),
+		WithContainerBox(containerbox.New(containerbox.WithContent(newRouteView()))),
+		WithBottomBar(bottombar.New()),
+	)
+	if navigation.Navigated.Subscribers() == before {
+		t.Fatal("no slot subscribed to navigation")
+	}
+
+	m.Dispose()
+	if got := navigation.Navigated.Subscribers(); got != before {
+		t.Errorf("%d subscriptions left after Dispose", got-before)
+	}
+}
+
+// tabView claims Tab while completing, as a text input showing a suggestion does.
+type tabView struct {
+	completing bool
+	tabs       int
+}
+
+func (v *tabView) Init() tui.Cmd { return nil }
+
+func (v *tabView) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if key, ok := msg.(tui.KeyMsg); ok && key.Type == tui.KeyTab {
+		v.tabs++
+	}
+	return v, nil
+}
+
+func (v *tabView) View() string { return "" }
+
+func (v *tabView) HandlesKey(msg tui.KeyMsg) bool {
+	return v.completing && msg.Type == tui.KeyTab
+}
+
+func TestScaffoldOffersKeysToFocused(t *testing.T) {
+	view := &tabView{completing: true}
+	content := containerbox.New(containerbox.WithContent(view))
+	m := New(WithAppBar(appbar.New()), WithContainerBox(content))
+	d := tuitest.New(t, m, tuitest.WithSize(40, 10))
+
+	d.Press(tui.KeyTab)
+	if !content.Focused() || view.tabs != 1 {
+		t.Fatalf("content focused %v, saw %d Tabs; want it to keep focus and get the Tab", content.Focused(), view.tabs)
+	}
+
+	view.completing = false
+	d.Press(tui.KeyTab)
+	if content.Focused() || view.tabs != 1 {
+		t.Errorf("content focused %v, saw %d Tabs; want Tab to move focus on", content.Focused(), view.tabs)
+	}
+}
+
+// routeView is a ViewBox showing the current route, as screens switch on it.
+type routeView struct {
+	tui.Subscriptions
+	nav   *tui.Subscription[navigation.Route]
+	route string
+}
+
+func newRouteView() *routeView {
+	v := &routeView{route: "home"}
+	v.nav = tui.Subscribe(&v.Subscriptions, navigation.Navigated)
+	return v
+}
+
+func (v *routeView) Init() tui.Cmd { return nil }
+
+func (v *routeView) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if route, ok := v.nav.Receive(msg); ok {
+		v.route = route.ID
+	}
+	return v, nil
+}
+
+func (v *routeView) View() string { return "route: " + v.route }
+
+func TestScaffoldNavBar(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+
+	nav := navbar.New(navbar.WithTabs(
+		navbar.Tab{Route: navigation.Route{ID: "home", Title: "Home"}},
+		navbar.Tab{Route: navigation.Route{ID: "tools", Title: "Tools"}, Badge: "2"},
+		navbar.Tab{Route: navigation.Route{ID: "settings", Title: "Settings"}},
+	))
+	view := newRouteView()
+	t.Cleanup(view.Dispose)
+	m := New(
+		WithAppBar(appbar.New(appbar.WithBorder(tui.NormalBorder))),
+		WithNavBar(nav),
+		WithContainerBox(containerbox.New(containerbox.WithBorder(tui.NormalBorder), containerbox.WithContent(view))),
+	)
+	t.Cleanup(m.Dispose)
+	d := tuitest.New(t, m, tuitest.WithSize(40, 10))
+	d.Golden("home")
+
+	// The jump keys work while the content has focus.
+	d.Send(tui.KeyMsg{Type: tui.KeyRunes, Runes: []rune("2"), Alt: true})
+	if view.route != "tools" {
+		t.Errorf("content shows route %q, want tools", view.route)
+	}
+	d.Golden("tools")
+
+	// Tab wraps to the AppBar, then reaches the bar, whose own keys apply.
+	d.Press(tui.KeyTab, tui.KeyTab, tui.KeyRight)
+	if !nav.Focused() || view.route != "settings" {
+		t.Errorf("bar focused %v, content shows %q, want the bar to select settings", nav.Focused(), view.route)
+	}
+}
@@ -1,12 +1,29 @@
 package sidemenu
 
 import (
+	"strings"
+
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
 )
 
 // Ensure Model implements the tui.Model and tui.Layout interfaces.
 var _ tui.Model = (*Model)(nil)
 var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+var _ tui.KeyScoper = (*Model)(nil)
+var _ tui.Disposable = (*Model)(nil)
+
+// keyScope is the scope of the side menu's key bindings.
+const keyScope = "sidemenu"
+
+var (
+	actionUp   = tui.Keys.Register(keyScope, "sidemenu.up", "Previous item", "up", "k")
+	actionDown = tui.Keys.Register(keyScope, "sidemenu.down", "Next item", "down", "j")
+	actionOpen = tui.Keys.Register(keyScope, "sidemenu.open", "Open", "enter")
+)
 
 // Option is a functional option for configuring the sidemenu.
 type Option func(*Model)
@@ -14,7 +31,15 @@ type Option func(*Model)
 type Model struct {
 	width, height int
 	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+	tui.Subscriptions
 	style tui.Style
+
+	// Navigation entries; opening one publishes it on navigation.Navigated.
+	items  []navigation.Route
+	cursor int
+	nav    *tui.Subscription[navigation.Route]
 }
 
 // New creates a new sidemenu with the given options.
@@ -22,6 +47,7 @@ func New(opts ...Option) *Model {
 	m := &Model{
 		style: tui.NewStyle(),
 	}
+	m.nav = tui.Subscribe(&m.Subscriptions, navigation.Navigated)
 
 	for _, opt := range opts {
 		opt(m)
@@ -60,6 +86,11 @@ func WithAlign(pos tui.Position) Option {
 	return func(m *Model) { m.Align(pos) }
 }
 
+// WithItems sets the routes listed in the menu.
+func WithItems(routes ...navigation.Route) Option {
+	return func(m *Model) { m.items = routes }
+}
+
 // --- tui.Model Implementation ---
 
 func (m *Model) Init() tui.Cmd {
@@ -67,6 +98,17 @@ func (m *Model) Init() tui.Cmd {
 }
 
 func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	// Routes picked elsewhere, e.g. in the NavBar, move the cursor along.
+	if route, ok := m.nav.Receive(msg); ok {
+		for i, item := range m.items {
+			if item.ID == route.ID && i != m.cursor {
+				m.cursor = i
+				m.MarkDirty()
+			}
+		}
+		return m, nil
+	}
+
 	switch msg := msg.(type) {
 	case tui.WindowSizeMsg:
 		// Calculate available space from parent
@@ -87,39 +129,110 @@ func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 		}
 
 		// No longer subtracting GetFrameSize here. The style's Width/Height will handle it.
+
+	case tui.KeyMsg:
+		if len(m.items) == 0 {
+			break
+		}
+		switch {
+		case tui.Keys.Matches(msg, actionUp):
+			m.cursor = max(m.cursor-1, 0)
+			m.MarkDirty()
+		case tui.Keys.Matches(msg, actionDown):
+			m.cursor = min(m.cursor+1, len(m.items)-1)
+			m.MarkDirty()
+		case tui.Keys.Matches(msg, actionOpen):
+			return m, navigation.Navigated.Publish(m.items[m.cursor])
+		}
 	}
 	return m, nil
 }
 
+// KeyScopes returns the menu's scope when it has items to navigate.
+func (m *Model) KeyScopes() []string {
+	if len(m.items) == 0 {
+		return nil
+	}
+	return []string{keyScope}
+}
+
+// Dispose ends the menu's subscription to navigation.
+func (m *Model) Dispose() {
+	m.Subscriptions.Dispose()
+}
+
 func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// render draws the slot; View caches the result.
+func (m *Model) render() string {
 	// Calculate content dimensions based on total width/height and frame size
 	hFrame, vFrame := m.style.GetFrameSize()
 	contentWidth := m.width - hFrame
 	contentHeight := m.height - vFrame
 
+	style := designsystem.FocusBorder(m.style, m.Focused())
+
 	// Render the content with the calculated content dimensions
-	return m.style.Width(contentWidth).Height(contentHeight).Render("sidemenu")
+	return style.Width(contentWidth).Height(contentHeight).Render(m.content())
+}
+
+// AccessibleView lists the items, marking the selected one with ">".
+func (m *Model) AccessibleView() string {
+	if len(m.items) == 0 {
+		return ""
+	}
+	lines := []string{"Menu:"}
+	for i, item := range m.items {
+		marker := "  "
+		if i == m.cursor {
+			marker = "> "
+		}
+		lines = append(lines, marker+item.Title)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// content lists the items, marking the one under the cursor.
+func (m *Model) content() string {
+	if len(m.items) == 0 {
+		return "sidemenu"
+	}
+	lines := make([]string, len(m.items))
+	for i, item := range m.items {
+		if i == m.cursor {
+			lines[i] = tui.NewStyle().Bold(true).Render("› " + item.Title)
+		} else {
+			lines[i] = "  " + item.Title
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 // --- tui.Layout Implementation ---
 
 func (m *Model) BackgroundColor(color string) tui.Layout {
-	m.style.Background(color)
+	m.style = m.style.Background(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
-	m.style.Border(border, sides...)
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) BorderForeground(color string) tui.Layout {
-	m.style.BorderForeground(color)
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
 	return m
 }
 
 func (m *Model) Padding(p ...int) tui.Layout {
-	m.style.Padding(p...)
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
 	return m
 }
 
@@ -136,6 +249,7 @@ func (m *Model) Height(height int) tui.Layout {
 }
 
 func (m *Model) Align(pos tui.Position) tui.Layout {
-	m.style.Align(pos)
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
 	return m
 }
\ No newline at end of file
@@ -0,0 +1,165 @@
+// Package spacer provides the Spacer widget: blank space between other
+// widgets, sized by the theme (components.Spacer) or by the caller.
+//
+// In a column a spacer adds blank lines, in a row blank columns; a growing
+// spacer fills its whole slot and pushes its neighbors apart:
+//
+//	tui.JoinHorizontal(tui.Top, left.View(), spacer.New().View(), right.View())
+package spacer
+
+import (
+	"strings"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Component interface.
+var _ tui.Component = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// Option is a functional option for configuring the Spacer.
+type Option func(*Model)
+
+// Model is a block of blank cells.
+type Model struct {
+	width, height int // 0 takes the theme's size
+	slot          tui.Rect
+	tui.ViewCache
+
+	// grow fills the slot instead of keeping a fixed size.
+	grow  bool
+	style tui.Style
+}
+
+// New creates a new Spacer of the theme's size in both directions.
+func New(opts ...Option) *Model {
+	m := &Model{
+		style: tui.NewStyle(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithSize sets the number of blank columns and lines.
+func WithSize(size int) Option {
+	return func(m *Model) { m.width, m.height = size, size }
+}
+
+// WithGrow makes the spacer fill its slot.
+func WithGrow(grow bool) Option {
+	return func(m *Model) { m.grow = grow }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+// Update keeps the size of the slot, which a growing spacer fills.
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if msg, ok := msg.(tui.WindowSizeMsg); ok {
+		m.slot = tui.Rect{Width: msg.Width, Height: msg.Height}
+		m.MarkDirty()
+	}
+	return m, nil
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+}
+
+// render draws the blank cells; View caches the result.
+func (m *Model) render() string {
+	width, height := m.Size()
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	line := strings.Repeat(" ", width)
+	return m.style.Render(strings.Repeat(line+"\n", height-1) + line)
+}
+
+// AccessibleView returns nothing: a spacer holds no content.
+func (m *Model) AccessibleView() string {
+	return ""
+}
+
+// --- State ---
+
+// Size returns the blank columns and lines the spacer takes.
+func (m *Model) Size() (width, height int) {
+	if m.grow {
+		return m.slot.Width, m.slot.Height
+	}
+	size := designsystem.Current().Components.Spacer.Size
+	width, height = m.width, m.height
+	if width <= 0 {
+		width = size
+	}
+	if height <= 0 {
+		height = size
+	}
+	return width, height
+}
+
+// Grows reports whether the spacer fills its slot.
+func (m *Model) Grows() bool {
+	return m.grow
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+// Border is ignored: a spacer has no frame.
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	return m
+}
+
+// BorderForeground is ignored: a spacer has no frame.
+func (m *Model) BorderForeground(color string) tui.Component {
+	return m
+}
+
+// Padding is ignored: a spacer is all padding already.
+func (m *Model) Padding(p ...int) tui.Component {
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.width = width
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.height = height
+	return m
+}
+
+// Align is ignored: a spacer has no content to align.
+func (m *Model) Align(pos tui.Position) tui.Component {
+	return m
+}
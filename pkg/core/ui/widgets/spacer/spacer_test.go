@@ -0,0 +1,38 @@
+package spacer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+)
+
+func TestSpacer(t *testing.T) {
+	tests := []struct {
+		name          string
+		m             *Model
+		width, height int
+	}{
+		{"theme size", New(), 1, 1},
+		{"size", New(WithSize(3)), 3, 3},
+		{"blank lines", New(WithWidth(1), WithHeight(2)), 1, 2},
+		{"grows to the slot", New(WithGrow(true)), 7, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.m.Update(tui.WindowSizeMsg{Width: 7, Height: 4})
+			view := tt.m.View()
+			if w, h := tui.Width(view), strings.Count(view, "\n")+1; w != tt.width || h != tt.height {
+				t.Errorf("view is %dx%d, want %dx%d", w, h, tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestSpacerPushesViewsApart(t *testing.T) {
+	got := tui.JoinHorizontal(tui.Top, "a", New(WithWidth(3)).View(), "b")
+	if got != "a   b" {
+		t.Errorf("joined = %q, want three blank columns", got)
+	}
+}
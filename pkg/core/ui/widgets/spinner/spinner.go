@@ -0,0 +1,242 @@
+// Package spinner provides the Spinner widget: a glyph animated with the
+// theme's frames (components.Spinner) to show that work is under way.
+//
+// A spinner moves only while it is spinning. Init starts it; Stop freezes
+// it, e.g. when the work it stands for is done:
+//
+//	busy := spinner.New(spinner.WithLabel("Resolving versions"))
+//	return busy.Init()
+package spinner
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Component interface.
+var _ tui.Component = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// ID identifies a spinner in its messages.
+type ID int64
+
+var lastID atomic.Int64
+
+// TickMsg advances a spinner to its next frame.
+type TickMsg struct {
+	ID ID
+	// tag drops the ticks of a spinner that was stopped and started again,
+	// so it never runs two clocks.
+	tag int
+}
+
+// Option is a functional option for configuring the Spinner.
+type Option func(*Model)
+
+// Model is an animated glyph, optionally followed by a label.
+type Model struct {
+	width, height int
+	tui.ViewCache
+
+	id       ID
+	label    string
+	frame    int
+	spinning bool
+	tag      int
+	// frames and interval override the theme's when set.
+	frames   []string
+	interval time.Duration
+
+	style tui.Style
+}
+
+// New creates a new Spinner with the theme's frames. It starts spinning
+// when its Init command runs.
+func New(opts ...Option) *Model {
+	m := &Model{
+		id:    ID(lastID.Add(1)),
+		style: tui.NewStyle(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithLabel sets the text drawn after the glyph.
+func WithLabel(label string) Option {
+	return func(m *Model) { m.label = label }
+}
+
+// WithFrames replaces the theme's frames, for every charset.
+func WithFrames(frames ...string) Option {
+	return func(m *Model) { m.frames = frames }
+}
+
+// WithInterval sets the time between frames.
+func WithInterval(interval time.Duration) Option {
+	return func(m *Model) { m.interval = interval }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+// --- tui.Model Implementation ---
+
+// Init starts the spinner.
+func (m *Model) Init() tui.Cmd {
+	return m.Start()
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	if msg, ok := msg.(TickMsg); ok && msg.ID == m.id && msg.tag == m.tag && m.spinning {
+		m.frame++
+		m.MarkDirty()
+		return m, m.tick()
+	}
+	return m, nil
+}
+
+// tick schedules the next frame.
+func (m *Model) tick() tui.Cmd {
+	id, tag := m.id, m.tag
+	return tui.Tick(m.Interval(), func(time.Time) tui.Msg { return TickMsg{ID: id, tag: tag} })
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+}
+
+// render draws the frame and the label; View caches the result.
+func (m *Model) render() string {
+	tokens := designsystem.Current().Components.Spinner
+	view := tui.NewStyle().Foreground(tokens.Color).Render(m.Frame())
+	if m.label != "" {
+		view += " " + m.label
+	}
+	if m.width > 0 {
+		hFrame, _ := m.style.GetFrameSize()
+		view = tui.Truncate(view, max(m.width-hFrame, 0), "…")
+	}
+	return m.style.Render(view)
+}
+
+// AccessibleView returns the label alone: the frames carry no meaning.
+func (m *Model) AccessibleView() string {
+	return m.label
+}
+
+// --- State ---
+
+// ID returns the identifier carried by the spinner's messages.
+func (m *Model) ID() ID {
+	return m.id
+}
+
+// Frame returns the glyph of the current frame.
+func (m *Model) Frame() string {
+	frames := m.frames
+	if len(frames) == 0 {
+		theme := designsystem.Current()
+		frames = theme.Components.Spinner.FramesFor(theme.Charset)
+	}
+	if len(frames) == 0 {
+		return ""
+	}
+	return frames[m.frame%len(frames)]
+}
+
+// Interval returns the time between frames.
+func (m *Model) Interval() time.Duration {
+	if m.interval > 0 {
+		return m.interval
+	}
+	return designsystem.Current().Components.Spinner.Interval
+}
+
+// Spinning reports whether the spinner is moving.
+func (m *Model) Spinning() bool {
+	return m.spinning
+}
+
+// Start returns the command that sets the spinner moving; it is nil when
+// the spinner already is.
+func (m *Model) Start() tui.Cmd {
+	if m.spinning {
+		return nil
+	}
+	m.spinning = true
+	m.tag++
+	return m.tick()
+}
+
+// Stop freezes the spinner on its current frame.
+func (m *Model) Stop() {
+	m.spinning = false
+}
+
+// SetLabel replaces the text drawn after the glyph.
+func (m *Model) SetLabel(label string) {
+	m.label = label
+	m.MarkDirty()
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.height = height
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
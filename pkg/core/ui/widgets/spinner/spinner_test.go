@@ -0,0 +1,78 @@
+package spinner
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSpinnerAdvances(t *testing.T) {
+	m := New(WithFrames("a", "b", "c"), WithLabel("Resolving"))
+	if cmd := m.Init(); cmd == nil {
+		t.Fatal("Init() = nil, want the first tick")
+	}
+	if got := ansi.Strip(m.View()); got != "a Resolving" {
+		t.Errorf("View() = %q, want the first frame", got)
+	}
+
+	m.Update(TickMsg{ID: m.ID(), tag: m.tag})
+	m.Update(TickMsg{ID: m.ID(), tag: m.tag})
+	if got := m.Frame(); got != "c" {
+		t.Errorf("Frame() = %q after two ticks, want %q", got, "c")
+	}
+
+	m.Update(TickMsg{ID: m.ID() + 1, tag: m.tag})
+	if got := m.Frame(); got != "c" {
+		t.Errorf("Frame() = %q, want ticks of other spinners ignored", got)
+	}
+}
+
+func TestSpinnerStop(t *testing.T) {
+	m := New(WithFrames("a", "b"))
+	m.Init()
+	stale := TickMsg{ID: m.ID(), tag: m.tag}
+
+	m.Stop()
+	if _, cmd := m.Update(stale); cmd != nil || m.Frame() != "a" {
+		t.Errorf("a stopped spinner moved to %q", m.Frame())
+	}
+	if m.Start() == nil {
+		t.Fatal("Start() = nil, want a tick")
+	}
+	if m.Start() != nil {
+		t.Error("Start() twice started a second clock")
+	}
+	if _, cmd := m.Update(stale); cmd != nil || m.Frame() != "a" {
+		t.Error("the tick of the earlier run moved the spinner")
+	}
+}
+
+func TestSpinnerThemeFrames(t *testing.T) {
+	tests := []struct {
+		charset designsystem.Charset
+		want    string
+	}{
+		{designsystem.CharsetUnicode, "⠋"},
+		{designsystem.CharsetASCII, "|"},
+	}
+
+	for _, tt := range tests {
+		tuitest.UseCharset(t, tt.charset)
+		if got := New().Frame(); got != tt.want {
+			t.Errorf("Frame() = %q with charset %v, want %q", got, tt.charset, tt.want)
+		}
+	}
+}
+
+func TestSpinnerTruncatesLabel(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	m := New(WithLabel("Installing node"), WithWidth(8))
+	if got := ansi.Strip(m.View()); got != "| Insta…" {
+		t.Errorf("View() = %q, want the label cut to the width", got)
+	}
+	if got := m.AccessibleView(); got != "Installing node" {
+		t.Errorf("AccessibleView() = %q, want the label", got)
+	}
+}
@@ -0,0 +1,103 @@
+package table
+
+import "github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+
+// Column describes a column of a Table. Cells are looked up in each row by
+// the column's Key.
+type Column struct {
+	Key   string
+	Title string
+	// Width is the width of the column's content; 0 makes it a flex column
+	// sharing the width left by the others.
+	Width int
+	// Flex is the share of the left width a flex column takes.
+	Flex  int
+	Align tui.Position
+	// Filterable columns are searched by the filter. When no column is
+	// filterable, all of them are.
+	Filterable bool
+	// CellStyle styles a cell by its value, e.g. red for "Missing". Styles
+	// of Styled cells take precedence.
+	CellStyle func(value any) tui.Style
+}
+
+// NewColumn creates a column of the given content width.
+func NewColumn(key, title string, width int) Column {
+	return Column{Key: key, Title: title, Width: width}
+}
+
+// NewFlexColumn creates a column taking flex shares of the width left by
+// the fixed columns.
+func NewFlexColumn(key, title string, flex int) Column {
+	return Column{Key: key, Title: title, Flex: max(flex, 1)}
+}
+
+// WithAlign returns the column with its cells aligned to pos.
+func (c Column) WithAlign(pos tui.Position) Column {
+	c.Align = pos
+	return c
+}
+
+// WithFilterable returns the column searched by the filter or not.
+func (c Column) WithFilterable(filterable bool) Column {
+	c.Filterable = filterable
+	return c
+}
+
+// WithCellStyle returns the column with its cells styled by style.
+func (c Column) WithCellStyle(style func(value any) tui.Style) Column {
+	c.CellStyle = style
+	return c
+}
+
+// Cells maps column keys to the values of a row.
+type Cells map[string]any
+
+// Row is a row of a Table.
+type Row struct {
+	// ID identifies the row in messages; it is free for the caller.
+	ID    string
+	Cells Cells
+	// Muted rows are drawn with the theme's muted style, e.g. tools that
+	// are not installed.
+	Muted bool
+	// Value is free for the caller, e.g. the catalog entry of the row.
+	Value any
+}
+
+// NewRow creates a row with the given cells.
+func NewRow(cells Cells) Row {
+	return Row{Cells: cells}
+}
+
+// WithID returns the row with the given ID.
+func (r Row) WithID(id string) Row {
+	r.ID = id
+	return r
+}
+
+// WithMuted returns the row muted or not.
+func (r Row) WithMuted(muted bool) Row {
+	r.Muted = muted
+	return r
+}
+
+// Cell is a cell value with its own style, which takes precedence over
+// the column's CellStyle. Sorting and filtering use Value.
+type Cell struct {
+	Value any
+	Style tui.Style
+}
+
+// Styled returns a cell showing value with style.
+func Styled(value any, style tui.Style) Cell {
+	return Cell{Value: value, Style: style}
+}
+
+// value returns the value of a cell, unwrapping styled cells.
+func value(cell any) any {
+	if c, ok := cell.(Cell); ok {
+		return c.Value
+	}
+	return cell
+}
@@ -0,0 +1,803 @@
+// Package table provides a data Table with sorting, filtering and
+// pagination, built on bubble-table.
+//
+// The header stays in place while the rows page under it; the page size
+// follows the height the table is given. Press "/" to filter the rows,
+// optionally on one column ("status:missing"), "s" to sort on the next
+// column and "S" to reverse the order:
+//
+//	tools := table.New([]table.Column{
+//		table.NewFlexColumn("name", "Tool", 2).WithFilterable(true),
+//		table.NewColumn("version", "Version", 10),
+//		table.NewColumn("status", "Status", 10).WithCellStyle(statusStyle),
+//	}, table.WithRows(rows))
+package table
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	bt "github.com/evertras/bubble-table/table"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.Layout interface.
+var _ tui.Layout = (*Model)(nil)
+var _ tui.Focusable = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+var _ tui.KeyHandler = (*Model)(nil)
+
+// keyScope is the keymap scope of the table actions.
+const keyScope = "table"
+
+// Table actions, active while a table has focus.
+var (
+	actionUp          = tui.Keys.Register(keyScope, "table.up", "Previous row", "up", "k")
+	actionDown        = tui.Keys.Register(keyScope, "table.down", "Next row", "down", "j")
+	actionPageUp      = tui.Keys.Register(keyScope, "table.page_up", "Previous page", "pgup", "left")
+	actionPageDown    = tui.Keys.Register(keyScope, "table.page_down", "Next page", "pgdown", "right")
+	actionHome        = tui.Keys.Register(keyScope, "table.home", "First row", "home", "g")
+	actionEnd         = tui.Keys.Register(keyScope, "table.end", "Last row", "end", "G")
+	actionScrollLeft  = tui.Keys.Register(keyScope, "table.scroll_left", "Scroll columns left", "shift+left")
+	actionScrollRight = tui.Keys.Register(keyScope, "table.scroll_right", "Scroll columns right", "shift+right")
+	actionSort        = tui.Keys.Register(keyScope, "table.sort", "Sort on next column", "s")
+	actionReverse     = tui.Keys.Register(keyScope, "table.sort_reverse", "Reverse sort order", "S")
+	actionFilter      = tui.Keys.Register(keyScope, "table.filter", "Filter rows", "/")
+	actionChoose      = tui.Keys.Register(keyScope, "table.choose", "Choose row", "enter")
+	actionClear       = tui.Keys.Register(keyScope, "table.filter_clear", "Clear filter", "esc")
+	actionBack        = tui.Keys.Register(keyScope, "table.filter_backspace", "Delete filter character", "backspace")
+)
+
+// rowKey holds, in the bubble-table rows, the index of their Row. No
+// column can have this key.
+const rowKey = "\x00row"
+
+// Lines around the rows: the header with its borders, the bottom border
+// and the footer.
+const (
+	headerLines = 3
+	bottomLines = 1
+	footerLines = 1
+)
+
+// minFlexWidth is the narrowest a flex column gets before all the columns
+// shrink together.
+const minFlexWidth = 10
+
+// ID identifies a table in its messages.
+type ID int64
+
+var lastID atomic.Int64
+
+// HighlightMsg is sent when the row under the cursor changes. Row is the
+// zero Row when no row matches the filter.
+type HighlightMsg struct {
+	ID  ID
+	Row Row
+}
+
+// ChooseMsg is sent when Enter is pressed on a row.
+type ChooseMsg struct {
+	ID  ID
+	Row Row
+}
+
+// Option is a functional option for configuring the Table.
+type Option func(*Model)
+
+// Model is a data table.
+type Model struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.FocusState
+	tui.ViewCache
+	tui.ClickZone
+
+	id      ID
+	columns []Column
+	rows    []Row
+	table   bt.Model
+	// rowsTheme is the theme the bubble-table rows were built with.
+	rowsTheme *designsystem.Theme
+
+	sortKey   string
+	sortDesc  bool
+	filter    []rune
+	filtering bool
+
+	style tui.Style
+}
+
+// New creates a new Table with the given columns and options.
+func New(columns []Column, opts ...Option) *Model {
+	m := &Model{
+		id:      ID(lastID.Add(1)),
+		columns: columns,
+		style:   tui.NewStyle(),
+	}
+	m.table = bt.New(nil).
+		WithFooterVisibility(false).
+		WithPaginationWrapping(false).
+		Filtered(true).
+		WithFilterFunc(m.matches)
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.applyRows()
+	m.applySize()
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithRows sets the rows of the table.
+func WithRows(rows []Row) Option {
+	return func(m *Model) { m.rows = rows }
+}
+
+// WithSort sorts the rows on the column with key.
+func WithSort(key string, desc bool) Option {
+	return func(m *Model) { m.sortKey, m.sortDesc = key, desc }
+}
+
+// WithFrozenColumns keeps the first n columns in place when the columns
+// scroll sideways.
+func WithFrozenColumns(n int) Option {
+	return func(m *Model) { m.table = m.table.WithHorizontalFreezeColumnCount(n) }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+// scrollMsg reports the wheel turned over the table.
+type scrollMsg struct {
+	id    ID
+	delta int
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		}
+		m.applySize()
+
+	case scrollMsg:
+		if msg.id == m.id {
+			return m, m.moveTo(m.table.GetHighlightedRowIndex() + msg.delta)
+		}
+
+	case tui.KeyMsg:
+		if m.Focused() {
+			if m.filtering {
+				return m, m.handleFilterKey(msg)
+			}
+			return m, m.handleKey(msg)
+		}
+	}
+	return m, nil
+}
+
+// HandlesKey reports whether the focused table uses msg before its
+// container: its bindings, every key typed into the filter and, while a
+// filter is set, Esc to clear it.
+func (m *Model) HandlesKey(msg tui.KeyMsg) bool {
+	if !m.Focused() {
+		return false
+	}
+	switch {
+	case m.filtering:
+		typing := (msg.Type == tui.KeyRunes || msg.Type == tui.KeySpace) && !msg.Ctrl && !msg.Alt
+		return typing || tui.Keys.Matches(msg, actionChoose) || tui.Keys.Matches(msg, actionClear) || tui.Keys.Matches(msg, actionBack)
+	case tui.Keys.Matches(msg, actionClear):
+		return len(m.filter) > 0
+	case tui.Keys.Matches(msg, actionBack):
+		return false
+	}
+	return tui.Keys.MatchesScope(msg, keyScope)
+}
+
+// handleKey applies a navigation or sorting key.
+func (m *Model) handleKey(msg tui.KeyMsg) tui.Cmd {
+	switch {
+	case tui.Keys.Matches(msg, actionUp):
+		return m.moveTo(m.table.GetHighlightedRowIndex() - 1)
+	case tui.Keys.Matches(msg, actionDown):
+		return m.moveTo(m.table.GetHighlightedRowIndex() + 1)
+	case tui.Keys.Matches(msg, actionPageUp):
+		return m.change(func() { m.table = m.table.PageUp() })
+	case tui.Keys.Matches(msg, actionPageDown):
+		return m.change(func() { m.table = m.table.PageDown() })
+	case tui.Keys.Matches(msg, actionHome):
+		return m.moveTo(0)
+	case tui.Keys.Matches(msg, actionEnd):
+		return m.moveTo(m.table.TotalRows() - 1)
+	case tui.Keys.Matches(msg, actionScrollLeft):
+		m.table = m.table.ScrollLeft()
+		m.MarkDirty()
+	case tui.Keys.Matches(msg, actionScrollRight):
+		m.table = m.table.ScrollRight()
+		m.MarkDirty()
+	case tui.Keys.Matches(msg, actionSort):
+		return m.SortBy(m.nextSortKey(), false)
+	case tui.Keys.Matches(msg, actionReverse):
+		key := m.sortKey
+		if key == "" {
+			key = m.nextSortKey()
+		}
+		return m.SortBy(key, !m.sortDesc)
+	case tui.Keys.Matches(msg, actionFilter):
+		m.filtering = true
+		m.MarkDirty()
+	case tui.Keys.Matches(msg, actionClear) && len(m.filter) > 0:
+		return m.SetFilter("")
+	case tui.Keys.Matches(msg, actionChoose):
+		row, ok := m.Current()
+		if !ok {
+			return nil
+		}
+		msg := ChooseMsg{ID: m.id, Row: row}
+		return func() tui.Msg { return msg }
+	}
+	return nil
+}
+
+// handleFilterKey edits the filter while it is being typed. Enter keeps
+// the filter; Esc clears it.
+func (m *Model) handleFilterKey(msg tui.KeyMsg) tui.Cmd {
+	switch {
+	case tui.Keys.Matches(msg, actionChoose):
+		m.filtering = false
+		m.MarkDirty()
+	case tui.Keys.Matches(msg, actionClear):
+		m.filtering = false
+		m.MarkDirty()
+		return m.SetFilter("")
+	case tui.Keys.Matches(msg, actionBack):
+		if len(m.filter) > 0 {
+			return m.SetFilter(string(m.filter[:len(m.filter)-1]))
+		}
+	case msg.Type == tui.KeyRunes && !msg.Ctrl && !msg.Alt:
+		return m.SetFilter(string(m.filter) + string(msg.Runes))
+	case msg.Type == tui.KeySpace:
+		return m.SetFilter(string(m.filter) + " ")
+	}
+	return nil
+}
+
+// moveTo moves the cursor to the visible row i, clamped to the rows.
+func (m *Model) moveTo(i int) tui.Cmd {
+	i = min(max(i, 0), max(m.table.TotalRows()-1, 0))
+	return m.change(func() { m.table = m.table.WithHighlightedRow(i) })
+}
+
+// change applies a change to the table and reports when it moved the
+// cursor to another row.
+func (m *Model) change(apply func()) tui.Cmd {
+	before := m.current()
+	apply()
+	m.MarkDirty()
+
+	after := m.current()
+	if after == before {
+		return nil
+	}
+	var row Row
+	if after >= 0 {
+		row = m.rows[after]
+	}
+	msg := HighlightMsg{ID: m.id, Row: row}
+	return func() tui.Msg { return msg }
+}
+
+// current returns the index in m.rows of the row under the cursor, or -1.
+func (m *Model) current() int {
+	rows := m.table.GetVisibleRows()
+	i := m.table.GetHighlightedRowIndex()
+	if i < 0 || i >= len(rows) {
+		return -1
+	}
+	return rows[i].Data[rowKey].(int)
+}
+
+// nextSortKey returns the key of the column after the sorted one.
+func (m *Model) nextSortKey() string {
+	if len(m.columns) == 0 {
+		return ""
+	}
+	for i, c := range m.columns {
+		if c.Key == m.sortKey {
+			return m.columns[(i+1)%len(m.columns)].Key
+		}
+	}
+	return m.columns[0].Key
+}
+
+// matches reports whether row matches filter. A filter such as
+// "status:missing" only searches the column with that key or title, and
+// matches no row when there is no such column.
+func (m *Model) matches(row bt.Row, filter string) bool {
+	cells := m.rows[row.Data[rowKey].(int)].Cells
+	columns := m.columns
+	if name, text, ok := strings.Cut(filter, ":"); ok {
+		c, found := m.column(name)
+		if !found {
+			return false
+		}
+		columns, filter = []Column{c}, text
+	} else if filterable := filterableColumns(m.columns); len(filterable) > 0 {
+		columns = filterable
+	}
+
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	for _, c := range columns {
+		if strings.Contains(strings.ToLower(fmt.Sprint(value(cells[c.Key]))), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// column returns the column whose key or title is name, ignoring case.
+func (m *Model) column(name string) (Column, bool) {
+	for _, c := range m.columns {
+		if strings.EqualFold(c.Key, name) || strings.EqualFold(c.Title, name) {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+func filterableColumns(columns []Column) []Column {
+	var filterable []Column
+	for _, c := range columns {
+		if c.Filterable {
+			filterable = append(filterable, c)
+		}
+	}
+	return filterable
+}
+
+// --- bubble-table ---
+
+// applyColumns hands the columns to the table, marking the sorted one.
+func (m *Model) applyColumns() {
+	tokens := designsystem.Current().Components.Table
+	hFrame, _ := m.style.GetFrameSize()
+	width := max(m.width-hFrame, 0)
+	widths, fits := columnWidths(m.columns, width)
+
+	columns := make([]bt.Column, len(m.columns))
+	for i, c := range m.columns {
+		title := c.Title
+		if c.Key == m.sortKey && m.sortKey != "" {
+			mark := tokens.SortAsc
+			if m.sortDesc {
+				mark = tokens.SortDesc
+			}
+			title += " " + mark
+		}
+		columns[i] = tui.TableColumn(c.Key, title, widths[i], c.Flex, c.Align)
+	}
+
+	// A target width stretches flex columns, and would hide the overflow.
+	target := width
+	if !fits {
+		target = 0
+	}
+	m.table = m.table.WithColumns(columns).WithTargetWidth(target).WithMaxTotalWidth(width)
+
+	if m.sortKey != "" {
+		if m.sortDesc {
+			m.table = m.table.SortByDesc(m.sortKey)
+		} else {
+			m.table = m.table.SortByAsc(m.sortKey)
+		}
+	}
+}
+
+// columnWidths returns the content width of each column in width cells;
+// 0 leaves a flex column to share what the others leave. When flex columns
+// would be narrower than minFlexWidth, every column shrinks in proportion
+// to its width, flex columns counting minFlexWidth, down to one cell. Only
+// when even that does not fit do fits report false: the columns keep their
+// widths and scroll sideways.
+func columnWidths(columns []Column, width int) (widths []int, fits bool) {
+	// Borders take one cell between and around the columns.
+	room := width - len(columns) - 1
+	natural := make([]int, len(columns))
+	total := 0
+	for i, c := range columns {
+		natural[i] = c.Width
+		if c.Width <= 0 {
+			natural[i] = minFlexWidth
+		}
+		total += natural[i]
+	}
+
+	widths = make([]int, len(columns))
+	switch {
+	case room >= total:
+		for i, c := range columns {
+			widths[i] = max(c.Width, 0)
+		}
+		return widths, true
+	case room < len(columns):
+		return natural, false
+	}
+
+	used := 0
+	for i, n := range natural {
+		widths[i] = max(n*room/total, 1)
+		used += widths[i]
+	}
+	// Hand out the cells lost to rounding to the columns shrunk the most,
+	// or take back those the one-cell minimum added from the widest.
+	for used < room {
+		i := widest(natural, widths, func(n, w int) int { return n - w })
+		widths[i]++
+		used++
+	}
+	for used > room {
+		i := widest(natural, widths, func(_, w int) int { return w })
+		widths[i]--
+		used--
+	}
+	return widths, true
+}
+
+// widest returns the index of the column scoring the most by score, given
+// its natural and its shrunk width; the first one wins a tie.
+func widest(natural, widths []int, score func(n, w int) int) int {
+	best := 0
+	for i := range widths {
+		if score(natural[i], widths[i]) > score(natural[best], widths[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// applyRows hands the rows to the table. Their cells are styled with the
+// current theme; render builds them again if it changes.
+func (m *Model) applyRows() {
+	m.table = m.table.WithRows(m.tableRows())
+	m.rowsTheme = designsystem.Current()
+}
+
+// tableRows converts the rows, styling their cells with the current theme.
+func (m *Model) tableRows() []bt.Row {
+	rows := make([]bt.Row, len(m.rows))
+	for i, row := range m.rows {
+		data := bt.RowData{rowKey: i}
+		for _, c := range m.columns {
+			cell, ok := row.Cells[c.Key]
+			if !ok {
+				continue
+			}
+			data[c.Key] = styleCell(c, cell)
+		}
+		rows[i] = bt.NewRow(data)
+	}
+	return rows
+}
+
+// styleCell returns cell with the styles of the column and of the cell.
+func styleCell(column Column, cell any) any {
+	var style tui.Style
+	if column.CellStyle != nil {
+		style = column.CellStyle(value(cell))
+	}
+	if c, ok := cell.(Cell); ok && c.Style != nil {
+		if style != nil {
+			style = c.Style.Inherit(style)
+		} else {
+			style = c.Style
+		}
+	}
+	return tui.TableCell(value(cell), style)
+}
+
+// applySize fits the table, with its footer, in the space it is given.
+func (m *Model) applySize() {
+	_, vFrame := m.style.GetFrameSize()
+	height := max(m.height-vFrame, 0)
+	if height > 0 {
+		// bubble-table pages the rows; the header stays above them.
+		m.table = m.table.
+			WithPageSize(max(height-headerLines-bottomLines-footerLines, 1)).
+			WithMinimumHeight(height - footerLines)
+	} else {
+		m.table = m.table.WithNoPagination().WithMinimumHeight(0)
+	}
+	m.applyColumns()
+	m.MarkDirty()
+}
+
+// rowStyle styles a row by its position and state.
+func (m *Model) rowStyle(in bt.RowStyleFuncInput) tui.Style {
+	states := designsystem.Current().Components.Table.Row
+	style := states.Normal.Apply(tui.NewStyle())
+	if in.Index%2 == 1 {
+		style = states.Alternate.Apply(style)
+	}
+	if m.rows[in.Row.Data[rowKey].(int)].Muted {
+		style = states.Muted.Apply(style)
+	}
+	if in.IsHighlighted {
+		style = states.Highlight.Apply(style)
+	}
+	return style
+}
+
+// --- Layout ---
+
+func (m *Model) View() string {
+	m.bindZone()
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height, Focused: m.Focused()}, m.render)
+}
+
+// bindZone registers the scroll handler the first time the table is drawn
+// or focused, so a table that is never shown leaves nothing in the zone map.
+func (m *Model) bindZone() {
+	if tui.Zones.Registered(m.ZoneID()) {
+		return
+	}
+	tui.Zones.OnScroll(m.ZoneID(), func(delta int) tui.Cmd {
+		id := m.id
+		return func() tui.Msg { return scrollMsg{id: id, delta: delta} }
+	})
+}
+
+// render draws the table and its footer; View caches the result.
+func (m *Model) render() string {
+	hFrame, vFrame := m.style.GetFrameSize()
+	width, height := m.width-hFrame, m.height-vFrame
+	if width <= 0 || height <= 0 || len(m.columns) == 0 {
+		return ""
+	}
+
+	if m.rowsTheme != designsystem.Current() {
+		m.applyRows()
+	}
+	tokens := designsystem.Current().Components.Table
+	base := tui.NewStyle().Align(tui.Left).BorderForeground(tokens.BorderColor)
+	t := tui.StyleTable(m.table, base, tokens.Header.Apply(tui.NewStyle()), tokens.Rounded).
+		WithRowStyleFunc(tui.TableRowStyle(m.rowStyle)).
+		Focused(m.Focused())
+
+	body := tui.JoinVertical(tui.Left, t.View(), m.footer(width))
+	_, top, right, bottom, left := m.style.GetBorder()
+	return m.MarkZone(m.style.
+		Width(m.width - boolInt(left) - boolInt(right)).
+		Height(m.height - boolInt(top) - boolInt(bottom)).
+		MaxWidth(m.width).
+		MaxHeight(m.height).
+		Render(body))
+}
+
+// footer draws the filter on the left and the page on the right.
+func (m *Model) footer(width int) string {
+	style := designsystem.Current().Components.Table.Footer.Apply(tui.NewStyle())
+
+	var left string
+	if m.filtering || len(m.filter) > 0 {
+		left = "/" + string(m.filter)
+		if m.filtering {
+			left += "▏"
+		}
+		left += fmt.Sprintf("  (%d/%d)", m.table.TotalRows(), len(m.rows))
+	}
+	var right string
+	if pages := m.table.MaxPages(); pages > 1 {
+		right = fmt.Sprintf("%d/%d", m.table.CurrentPage(), pages)
+	}
+
+	gap := width - tui.Width(left) - tui.Width(right)
+	if gap < 1 {
+		return style.Render(tui.Truncate(left, width, "…"))
+	}
+	return style.Render(left + strings.Repeat(" ", gap) + right)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// AccessibleView returns the columns and the rows of the page as plain
+// text, the cursor row marked with "> ".
+func (m *Model) AccessibleView() string {
+	titles := make([]string, len(m.columns))
+	for i, c := range m.columns {
+		titles[i] = c.Title
+	}
+	lines := []string{"  " + strings.Join(titles, " | ")}
+
+	rows := m.table.GetVisibleRows()
+	start, end := m.table.VisibleIndices()
+	for i := start; i <= end && i < len(rows); i++ {
+		row := m.rows[rows[i].Data[rowKey].(int)]
+		cells := make([]string, len(m.columns))
+		for j, c := range m.columns {
+			if cell, ok := row.Cells[c.Key]; ok {
+				cells[j] = fmt.Sprint(value(cell))
+			}
+		}
+		prefix := "  "
+		if i == m.table.GetHighlightedRowIndex() {
+			prefix = "> "
+		}
+		lines = append(lines, prefix+strings.Join(cells, " | "))
+	}
+
+	if len(m.filter) > 0 {
+		lines = append(lines, fmt.Sprintf("Filter: %s (%d of %d)", string(m.filter), len(rows), len(m.rows)))
+	}
+	if pages := m.table.MaxPages(); pages > 1 {
+		lines = append(lines, fmt.Sprintf("Page %d of %d", m.table.CurrentPage(), pages))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// --- State ---
+
+// ID returns the identifier carried by the table's messages.
+func (m *Model) ID() ID {
+	return m.id
+}
+
+// Rows returns all the rows, filtered or not.
+func (m *Model) Rows() []Row {
+	return m.rows
+}
+
+// SetRows replaces the rows, keeping the sort order and the filter.
+func (m *Model) SetRows(rows []Row) tui.Cmd {
+	return m.change(func() {
+		m.rows = rows
+		m.applyRows()
+	})
+}
+
+// SetColumns replaces the columns.
+func (m *Model) SetColumns(columns []Column) {
+	m.columns = columns
+	m.applyRows()
+	m.applySize()
+}
+
+// Current returns the row under the cursor; ok is false when no row
+// matches the filter.
+func (m *Model) Current() (row Row, ok bool) {
+	i := m.current()
+	if i < 0 {
+		return Row{}, false
+	}
+	return m.rows[i], true
+}
+
+// SortBy sorts the rows on the column with key.
+func (m *Model) SortBy(key string, desc bool) tui.Cmd {
+	return m.change(func() {
+		m.sortKey, m.sortDesc = key, desc
+		m.applyColumns()
+	})
+}
+
+// Sort returns the key of the sorted column, "" when unsorted.
+func (m *Model) Sort() (key string, desc bool) {
+	return m.sortKey, m.sortDesc
+}
+
+// SetFilter filters the rows, going back to the first page.
+func (m *Model) SetFilter(filter string) tui.Cmd {
+	return m.change(func() {
+		m.filter = []rune(filter)
+		m.table = m.table.WithFilterInputValue(filter)
+	})
+}
+
+// Filter returns the text the rows are filtered by.
+func (m *Model) Filter() string {
+	return string(m.filter)
+}
+
+// Focus focuses the table; the mouse wheel scrolls it wherever the pointer
+// is.
+func (m *Model) Focus() tui.Cmd {
+	m.bindZone()
+	tui.Zones.Focus(m.ZoneID())
+	return m.FocusState.Focus()
+}
+
+// Blur removes focus from the table.
+func (m *Model) Blur() {
+	tui.Zones.Blur(m.ZoneID())
+	m.FocusState.Blur()
+}
+
+// Close forgets the table's zone. Call it when the table is discarded.
+func (m *Model) Close() {
+	tui.Zones.Remove(m.ZoneID())
+}
+
+// --- tui.Layout Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Layout {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Layout {
+	m.style = m.style.Border(border, sides...)
+	m.applySize()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Layout {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Layout {
+	m.style = m.style.Padding(p...)
+	m.applySize()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Layout {
+	m.desiredWidth = width
+	m.width = width
+	m.applySize()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Layout {
+	m.desiredHeight = height
+	m.height = height
+	m.applySize()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Layout {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
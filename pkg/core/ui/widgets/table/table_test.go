@@ -0,0 +1,286 @@
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	bt "github.com/evertras/bubble-table/table"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+)
+
+// statusStyle colors missing tools red.
+func statusStyle(value any) tui.Style {
+	if value == "Missing" {
+		return tui.NewStyle().Foreground("1")
+	}
+	return nil
+}
+
+func columns() []Column {
+	return []Column{
+		NewFlexColumn("name", "Tool", 1).WithFilterable(true),
+		NewColumn("version", "Version", 8),
+		NewColumn("status", "Status", 9).WithCellStyle(statusStyle),
+	}
+}
+
+// tools returns the rows of a tools status screen.
+func tools() []Row {
+	tools := []struct{ name, version, status string }{
+		{"node", "22.1.0", "Installed"},
+		{"golang", "1.25", "Installed"},
+		{"python", "", "Missing"},
+		{"deno", "2.3", "Outdated"},
+		{"rust", "", "Missing"},
+		{"java", "21", "Installed"},
+		{"ruby", "3.3", "Outdated"},
+	}
+	rows := make([]Row, len(tools))
+	for i, tool := range tools {
+		rows[i] = NewRow(Cells{"name": tool.name, "version": tool.version, "status": tool.status}).
+			WithID(tool.name).
+			WithMuted(tool.status == "Missing")
+	}
+	return rows
+}
+
+func TestTableRendering(t *testing.T) {
+	d := tuitest.New(t, New(columns(), WithRows(tools())), tuitest.WithSize(40, 9), tuitest.WithFocus())
+	d.Golden("first_page")
+	d.PressKeys("pgdown")
+	d.Golden("second_page")
+	d.Resize(40, 14)
+	d.Golden("single_page")
+}
+
+func TestSorting(t *testing.T) {
+	m := New(columns(), WithRows(tools()))
+	d := tuitest.New(t, m, tuitest.WithSize(40, 14), tuitest.WithFocus())
+
+	d.PressKeys("s")
+	if got := names(m); !slices.Equal(got, []string{"deno", "golang", "java", "node", "python", "ruby", "rust"}) {
+		t.Errorf("sorted on name = %q", got)
+	}
+	d.Golden("by_name")
+
+	d.PressKeys("s", "S")
+	if k, desc := m.Sort(); k != "version" || !desc {
+		t.Errorf("sort = %q desc %v, want version desc", k, desc)
+	}
+	// Versions are not numbers, so they sort as text.
+	if got := names(m); got[0] != "ruby" {
+		t.Errorf("sorted on version desc = %q, want ruby first", got)
+	}
+}
+
+func TestFiltering(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   []string
+	}{
+		{"filterable columns", "ru", []string{"rust", "ruby"}},
+		{"other columns are not searched", "missing", nil},
+		{"one column", "status:missing", []string{"python", "rust"}},
+		{"column by title", "Version:2", []string{"node", "golang", "deno", "java"}},
+		{"case insensitive", "GO", []string{"golang"}},
+		{"unknown column", "size:big", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(columns(), WithRows(tools()))
+			tuitest.New(t, m, tuitest.WithSize(40, 14), tuitest.WithFocus())
+			m.SetFilter(tt.filter)
+			if got := names(m); !slices.Equal(got, tt.want) {
+				t.Errorf("rows = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterTyping(t *testing.T) {
+	m := New(columns(), WithRows(tools()))
+	d := tuitest.New(t, m, tuitest.WithSize(40, 9), tuitest.WithFocus())
+	d.Type("/ja")
+	d.Golden("typing")
+	if !m.HandlesKey(tuitest.Key(t, "?")) || !m.HandlesKey(tuitest.Key(t, "esc")) {
+		t.Error("the filter being typed left ? or Esc to the container")
+	}
+
+	d.PressKeys("enter")
+	if m.Filter() != "ja" || m.filtering {
+		t.Errorf("filter %q typing %v, want ja kept", m.Filter(), m.filtering)
+	}
+
+	// Letters navigate again once the filter is applied.
+	d.Type("/").PressKeys("esc")
+	if m.Filter() != "" || len(names(m)) != len(tools()) {
+		t.Errorf("filter %q, want cleared", m.Filter())
+	}
+}
+
+func TestHighlightMessages(t *testing.T) {
+	m := New(columns(), WithRows(tools()))
+	d := tuitest.New(t, m, tuitest.WithSize(40, 9), tuitest.WithFocus())
+
+	d.PressKeys("down")
+	msg, ok := last[HighlightMsg](d.Messages())
+	if !ok || msg.Row.ID != "golang" || msg.ID != m.ID() {
+		t.Fatalf("highlight = %+v, want golang", msg)
+	}
+
+	d.PressKeys("end")
+	if msg, _ := last[HighlightMsg](d.Messages()); msg.Row.ID != "ruby" {
+		t.Errorf("highlight = %q, want ruby", msg.Row.ID)
+	}
+
+	d.PressKeys("enter")
+	if choose, ok := last[ChooseMsg](d.Messages()); !ok || choose.Row.ID != "ruby" {
+		t.Errorf("choose = %+v, want ruby", choose)
+	}
+
+	// Sorting keeps the message flowing for the new row under the cursor.
+	d.PressKeys("home", "s")
+	if msg, _ := last[HighlightMsg](d.Messages()); msg.Row.ID != "deno" {
+		t.Errorf("highlight after sort = %q, want deno", msg.Row.ID)
+	}
+}
+
+func TestCellStyles(t *testing.T) {
+	column := NewColumn("status", "Status", 9).WithCellStyle(statusStyle)
+
+	if got := styleCell(column, "Installed"); got != "Installed" {
+		t.Errorf("plain cell = %v, want the bare value", got)
+	}
+
+	// A Styled cell keeps the column's color unless it sets its own.
+	cell, ok := styleCell(column, Styled("Missing", tui.NewStyle().Bold(true))).(bt.StyledCell)
+	if !ok || cell.Data != "Missing" {
+		t.Fatalf("cell = %+v, want a styled Missing", cell)
+	}
+	if cell.Style.GetForeground() != lipgloss.Color("1") || !cell.Style.GetBold() {
+		t.Errorf("cell style is not bold red")
+	}
+}
+
+// TestAnySize renders the table at every size up to 50x15: it must never
+// panic nor draw outside the slot.
+func TestAnySize(t *testing.T) {
+	m := New(columns(), WithRows(tools()), WithFrozenColumns(1))
+	t.Cleanup(m.Close)
+	for width := 0; width <= 50; width++ {
+		for height := 0; height <= 15; height++ {
+			m.Update(tui.WindowSizeMsg{Width: width, Height: height})
+			view := m.View()
+			if w, h := tui.Width(view), strings.Count(view, "\n")+1; w > width || (view != "" && h > height) {
+				t.Fatalf("%dx%d: view is %dx%d", width, height, w, h)
+			}
+		}
+	}
+}
+
+func TestColumnWidths(t *testing.T) {
+	tests := []struct {
+		name  string
+		width int
+		want  []int
+		fits  bool
+	}{
+		{"flex takes the rest", 40, []int{0, 8, 9}, true},
+		{"flex at its narrowest", 31, []int{0, 8, 9}, true},
+		{"all shrink together", 26, []int{9, 6, 7}, true},
+		{"one cell each", 7, []int{1, 1, 1}, true},
+		{"too narrow to shrink", 6, []int{minFlexWidth, 8, 9}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, fits := columnWidths(columns(), tt.width)
+			if !slices.Equal(got, tt.want) || fits != tt.fits {
+				t.Errorf("columnWidths(%d) = %v, %v, want %v, %v", tt.width, got, fits, tt.want, tt.fits)
+			}
+		})
+	}
+}
+
+// TestNarrowSlots renders the table at every width the columns can shrink
+// to: every column stays in view and the table fills the slot.
+func TestNarrowSlots(t *testing.T) {
+	m := New(columns(), WithRows(tools()))
+	t.Cleanup(m.Close)
+	for width := len(columns())*2 + 1; width <= 50; width++ {
+		m.Update(tui.WindowSizeMsg{Width: width, Height: 9})
+		header := strings.Split(m.View(), "\n")[0]
+		if got := strings.Count(header, "┬"); got != len(columns())-1 {
+			t.Fatalf("width %d: %d column separators in %q, want every column", width, got, header)
+		}
+		if got := tui.Width(header); got != width {
+			t.Fatalf("width %d: header %q is %d cells wide", width, header, got)
+		}
+	}
+}
+
+func TestRowsBuiltOnChange(t *testing.T) {
+	calls := 0
+	counted := columns()
+	counted[2] = counted[2].WithCellStyle(func(value any) tui.Style {
+		calls++
+		return statusStyle(value)
+	})
+	m := New(counted, WithRows(tools()))
+	m.Focus()
+	d := tuitest.New(t, m, tuitest.WithSize(40, 9))
+
+	built := calls
+	d.PressKeys("down", "pgdown")
+	m.Blur()
+	m.View()
+	if calls != built {
+		t.Errorf("moving the cursor styled %d cells again", calls-built)
+	}
+
+	m.SetRows(tools()[:2])
+	if calls != built+2 {
+		t.Errorf("SetRows styled %d cells, want 2", calls-built)
+	}
+}
+
+func TestFrozenColumnsScroll(t *testing.T) {
+	// Too many columns to shrink to one cell each: they scroll instead.
+	columns := []Column{NewFlexColumn("name", "Tool", 1)}
+	for i := range 12 {
+		columns = append(columns, NewColumn("version", fmt.Sprintf("V%d", i+1), 6))
+	}
+	m := New(columns, WithRows(tools()), WithFrozenColumns(1))
+	m.Focus()
+	d := tuitest.New(t, m, tuitest.WithSize(24, 9))
+	d.Golden("narrow")
+	d.PressKeys("shift+right")
+	d.Golden("scrolled")
+}
+
+// names returns the names of the rows left by the filter, in order.
+func names(m *Model) []string {
+	var names []string
+	for _, row := range m.table.GetVisibleRows() {
+		names = append(names, m.rows[row.Data[rowKey].(int)].ID)
+	}
+	return names
+}
+
+// last returns the last message of type T.
+func last[T tui.Msg](msgs []tui.Msg) (T, bool) {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msg, ok := msgs[i].(T); ok {
+			return msg, true
+		}
+	}
+	var zero T
+	return zero, false
+}
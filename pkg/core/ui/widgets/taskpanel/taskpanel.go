@@ -0,0 +1,725 @@
+// Package taskpanel provides the TaskPanel widget: the live state of many
+// concurrent tasks, e.g. the steps of installing a stack. Each task is a
+// line with a state glyph, its title, a progress bar and its elapsed time,
+// followed by the last line it printed while it runs or once it failed.
+//
+// Tasks are updated by messages. A task created with a tui.TaskFunc is run
+// by the panel: the function reports through its progress callback with
+// the panel's messages, leaving their Task key empty, and its result
+// finishes the task:
+//
+//	panel := taskpanel.New(
+//		taskpanel.NewTask("go", "Install go", func(ctx context.Context, progress func(tui.Msg)) (tui.Msg, error) {
+//			progress(taskpanel.OutputMsg{Output: "downloading go1.25.1"})
+//			progress(taskpanel.ProgressMsg{Fraction: 0.5})
+//			return nil, install(ctx)
+//		}),
+//		taskpanel.NewTask("node", "Install node", nil), // driven by the screen
+//	)
+//
+// The panel fits a full-screen ViewBox, and RunInline draws it in the
+// scrollback of a one-shot command, or as plain lines without a terminal.
+// A panel given to tui.NewInline draws the tasks the command reports with
+// tui.InlineTask.
+package taskpanel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/progress"
+)
+
+// Ensure Model implements the tui.Component interface.
+var _ tui.Component = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// State is the state of a task.
+type State int
+
+const (
+	Pending State = iota
+	Running
+	Done
+	Failed
+	Skipped
+)
+
+// String returns the state as the accessible view writes it.
+func (s State) String() string {
+	switch s {
+	case Running:
+		return "running"
+	case Done:
+		return "done"
+	case Failed:
+		return "failed"
+	case Skipped:
+		return "skipped"
+	default:
+		return "pending"
+	}
+}
+
+// Finished reports whether a task in the state is over.
+func (s State) Finished() bool {
+	return s >= Done
+}
+
+// Task is a line of a TaskPanel.
+type Task struct {
+	// Key identifies the task in messages; it is unique in its panel.
+	Key   string
+	Title string
+	// Run, when set, is run by the panel. It fails the task by returning an
+	// error, and skips it by returning a StateMsg with State Skipped.
+	Run tui.TaskFunc
+
+	State State
+	// Fraction is the part of the work done, from 0 to 1. Below 0 the
+	// amount of work is unknown and the bar sweeps while the task runs.
+	Fraction float64
+	// Output is the last line printed by the task.
+	Output            string
+	Err               error
+	Started, Finished time.Time
+}
+
+// NewTask creates a pending task whose amount of work is unknown. run may
+// be nil for a task driven by messages.
+func NewTask(key, title string, run tui.TaskFunc) Task {
+	return Task{Key: key, Title: title, Run: run, Fraction: -1}
+}
+
+// Elapsed returns the time the task ran, so far while it runs.
+func (t Task) Elapsed(now time.Time) time.Duration {
+	switch {
+	case t.Started.IsZero():
+		return 0
+	case t.State.Finished():
+		return t.Finished.Sub(t.Started)
+	default:
+		return now.Sub(t.Started)
+	}
+}
+
+// ID identifies a panel in its messages.
+type ID int64
+
+var lastID atomic.Int64
+
+// StateMsg moves the task Task to State. Err explains a failure.
+type StateMsg struct {
+	Task  string
+	State State
+	Err   error
+}
+
+// ProgressMsg sets the part of the work of Task done, from 0 to 1; below 0
+// the amount is unknown. A pending task starts running.
+type ProgressMsg struct {
+	Task     string
+	Fraction float64
+}
+
+// OutputMsg reports what Task printed; the panel keeps its last non-blank
+// line.
+type OutputMsg struct {
+	Task   string
+	Output string
+}
+
+// DoneMsg is sent once every task of the panel has finished.
+type DoneMsg struct {
+	ID     ID
+	Failed int
+}
+
+// tickMsg advances the spinners and the elapsed times.
+type tickMsg struct {
+	id ID
+}
+
+// Option is a functional option for configuring the TaskPanel.
+type Option func(*Model)
+
+// Model is a panel of tasks.
+type Model struct {
+	width, height int
+	tui.ViewCache
+
+	id    ID
+	tasks []Task
+	// bound maps the tui.Tasks running Run functions to their task keys.
+	bound map[tui.TaskID]string
+	// log is the accessible view: a line per change, so plain output only
+	// ever grows.
+	log []string
+
+	frame   int
+	ticking bool
+	// done is set once DoneMsg was sent.
+	done   bool
+	inline bool
+	now    func() time.Time
+
+	style tui.Style
+}
+
+// New creates a new TaskPanel of tasks. Its Init starts the tasks that
+// have a Run function.
+func New(tasks []Task, opts ...Option) *Model {
+	m := &Model{
+		id:    ID(lastID.Add(1)),
+		bound: make(map[tui.TaskID]string),
+		now:   time.Now,
+		style: tui.NewStyle(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	for _, task := range tasks {
+		m.add(task)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithClock sets the clock the elapsed times are measured with.
+func WithClock(now func() time.Time) Option {
+	return func(m *Model) { m.now = now }
+}
+
+// WithInline draws the panel for the scrollback instead of a screen: it
+// takes the height of its tasks and quits the program once they are all
+// finished. RunInline sets it.
+func WithInline(inline bool) Option {
+	return func(m *Model) { m.inline = inline }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+// --- Tasks ---
+
+// Add appends task to the panel, and returns the command running it when
+// it has a Run function.
+func (m *Model) Add(task Task) tui.Cmd {
+	m.add(task)
+	return tui.Batch(m.run(len(m.tasks)-1), m.changed())
+}
+
+// add appends task without starting it.
+func (m *Model) add(task Task) {
+	m.tasks = append(m.tasks, task)
+	// The new task has to finish before the next DoneMsg.
+	m.done = false
+	m.logf(task, "%s", task.State)
+	m.MarkDirty()
+}
+
+// run starts the Run function of the task at i.
+func (m *Model) run(i int) tui.Cmd {
+	task := &m.tasks[i]
+	if task.Run == nil || task.State != Pending {
+		return nil
+	}
+	t := tui.NewTask(task.Run)
+	m.bound[t.ID()] = task.Key
+	m.setState(i, Running, nil)
+	return t.Start()
+}
+
+// Tasks returns the tasks with their current state.
+func (m *Model) Tasks() []Task {
+	return m.tasks
+}
+
+// Task returns the task with key.
+func (m *Model) Task(key string) (Task, bool) {
+	if i := m.index(key); i >= 0 {
+		return m.tasks[i], true
+	}
+	return Task{}, false
+}
+
+// index returns the index of the task with key, or -1.
+func (m *Model) index(key string) int {
+	for i, task := range m.tasks {
+		if task.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// setState moves the task at i to state, timing it.
+func (m *Model) setState(i int, state State, err error) {
+	task := &m.tasks[i]
+	if task.State == state || task.State.Finished() {
+		return
+	}
+	now := m.now()
+	// A task skipped before it started has no time.
+	if task.Started.IsZero() && state != Pending && state != Skipped {
+		task.Started = now
+	}
+	if state.Finished() {
+		task.Finished = now
+	}
+	task.State, task.Err = state, err
+
+	line := state.String()
+	if err != nil {
+		line += ": " + err.Error()
+	}
+	if state.Finished() && !task.Started.IsZero() {
+		line += " (" + formatElapsed(task.Elapsed(now)) + ")"
+	}
+	m.logf(*task, "%s", line)
+	m.MarkDirty()
+}
+
+// setFraction sets the part of the work done by the task at i.
+func (m *Model) setFraction(i int, fraction float64) {
+	if m.tasks[i].State == Pending {
+		m.setState(i, Running, nil)
+	}
+	task := &m.tasks[i]
+	if task.State.Finished() {
+		return
+	}
+	before := task.Fraction
+	task.Fraction = min(fraction, 1)
+	// The log gets a line every 10%, like the plain Inline tasks.
+	if fraction >= 0 && (before < 0 || int(before*10) != int(task.Fraction*10)) {
+		m.logf(*task, "%d%%", int(task.Fraction*100))
+	}
+	m.MarkDirty()
+}
+
+// setOutput keeps the last non-blank line of output for the task at i.
+func (m *Model) setOutput(i int, output string) {
+	lines := strings.Split(output, "\n")
+	for j := len(lines) - 1; j >= 0; j-- {
+		if line := strings.TrimSpace(lines[j]); line != "" {
+			m.tasks[i].Output = line
+			m.logf(m.tasks[i], "%s", line)
+			m.MarkDirty()
+			return
+		}
+	}
+}
+
+// logf appends a line about task to the accessible log.
+func (m *Model) logf(task Task, format string, args ...any) {
+	m.log = append(m.log, task.Title+": "+fmt.Sprintf(format, args...))
+}
+
+// --- tui.Model Implementation ---
+
+// Init runs the tasks that have a Run function.
+func (m *Model) Init() tui.Cmd {
+	cmds := make([]tui.Cmd, 0, len(m.tasks)+1)
+	for i := range m.tasks {
+		cmds = append(cmds, m.run(i))
+	}
+	return tui.Batch(append(cmds, m.changed())...)
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.MarkDirty()
+		return m, nil
+
+	case tickMsg:
+		if msg.id != m.id {
+			return m, nil
+		}
+		m.frame++
+		m.MarkDirty()
+		m.ticking = false
+		return m, m.changed()
+
+	case tui.TaskProgressMsg:
+		key, ok := m.bound[msg.ID]
+		if !ok {
+			return m, nil
+		}
+		switch progress := msg.Progress.(type) {
+		case StateMsg:
+			progress.Task = key
+			m.apply(progress)
+		case ProgressMsg:
+			progress.Task = key
+			m.apply(progress)
+		case OutputMsg:
+			progress.Task = key
+			m.apply(progress)
+		}
+		return m, m.changed()
+
+	case tui.TaskDoneMsg:
+		key, ok := m.bound[msg.ID]
+		if !ok {
+			return m, nil
+		}
+		delete(m.bound, msg.ID)
+		state := Done
+		switch {
+		case errors.Is(msg.Err, context.Canceled):
+			state = Skipped
+		case msg.Err != nil:
+			state = Failed
+		default:
+			if result, ok := msg.Result.(StateMsg); ok && result.State.Finished() {
+				state = result.State
+			}
+		}
+		m.apply(StateMsg{Task: key, State: state, Err: msg.Err})
+		return m, m.changed()
+
+	case StateMsg, ProgressMsg, OutputMsg:
+		m.apply(msg)
+		return m, m.changed()
+
+	case tui.InlineTaskMsg:
+		return m, m.inlineTask(msg)
+
+	case tui.InlineCloseMsg:
+		// The tasks still running will not report again.
+		for i := range m.tasks {
+			m.setState(i, Skipped, nil)
+		}
+		return m, m.changed()
+	}
+	return m, nil
+}
+
+// inlineTask applies a change to a task reported by a tui.Inline.
+func (m *Model) inlineTask(msg tui.InlineTaskMsg) tui.Cmd {
+	key := fmt.Sprintf("inline-%d", msg.ID)
+	if msg.Event == tui.InlineStarted {
+		task := NewTask(key, msg.Title, nil)
+		task.State, task.Started = Running, m.now()
+		return m.Add(task)
+	}
+
+	i := m.index(key)
+	if i < 0 {
+		return nil
+	}
+	switch msg.Event {
+	case tui.InlineStatus:
+		m.setOutput(i, msg.Text)
+	case tui.InlineProgress:
+		m.setFraction(i, float64(msg.Done)/float64(msg.Total))
+	case tui.InlineDone:
+		if msg.Text != "" {
+			m.setOutput(i, msg.Text)
+		}
+		m.setState(i, Done, nil)
+	case tui.InlineFailed:
+		m.setState(i, Failed, msg.Err)
+	}
+	return m.changed()
+}
+
+// apply updates the task a StateMsg, ProgressMsg or OutputMsg is about.
+func (m *Model) apply(msg tui.Msg) {
+	switch msg := msg.(type) {
+	case StateMsg:
+		if i := m.index(msg.Task); i >= 0 {
+			m.setState(i, msg.State, msg.Err)
+		}
+	case ProgressMsg:
+		if i := m.index(msg.Task); i >= 0 {
+			m.setFraction(i, msg.Fraction)
+		}
+	case OutputMsg:
+		if i := m.index(msg.Task); i >= 0 {
+			m.setOutput(i, msg.Output)
+		}
+	}
+}
+
+// changed returns what follows a change: the next tick while tasks run,
+// and DoneMsg once they have all finished, quitting inline panels.
+func (m *Model) changed() tui.Cmd {
+	running, finished, failed := 0, 0, 0
+	for _, task := range m.tasks {
+		switch {
+		case task.State == Running:
+			running++
+		case task.State.Finished():
+			finished++
+			if task.State == Failed {
+				failed++
+			}
+		}
+	}
+
+	if running > 0 && !m.ticking {
+		m.ticking = true
+		id := m.id
+		interval := designsystem.Current().Components.Spinner.Interval
+		return tui.Tick(interval, func(time.Time) tui.Msg { return tickMsg{id: id} })
+	}
+	if finished < len(m.tasks) || m.done {
+		return nil
+	}
+	m.done = true
+	m.MarkDirty()
+	msg := DoneMsg{ID: m.id, Failed: failed}
+	done := func() tui.Msg { return msg }
+	if m.inline {
+		return tui.Sequence(done, tui.Quit)
+	}
+	return done
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+}
+
+// defaultWidth is the width of a panel that was never given one.
+const defaultWidth = 60
+
+// render draws a line per task, and the output tails; View caches the
+// result.
+func (m *Model) render() string {
+	theme := designsystem.Current()
+	tokens := theme.Components.TaskPanel
+	hFrame, vFrame := m.style.GetFrameSize()
+	width := m.width
+	if width <= 0 {
+		width = defaultWidth
+	}
+	width = max(width-hFrame, 0)
+
+	titleWidth := 0
+	for _, task := range m.tasks {
+		titleWidth = max(titleWidth, tui.Width(task.Title))
+	}
+	titleWidth = min(titleWidth, tokens.TitleWidth)
+
+	now := m.now()
+	output := tokens.Output.Apply(tui.NewStyle())
+	// blocks holds the lines of each task: its row, then its output.
+	blocks := make([][]string, len(m.tasks))
+	finished := make([]bool, len(m.tasks))
+	for i, task := range m.tasks {
+		finished[i] = task.State.Finished()
+		row := m.glyph(task) + " " + padRight(tui.Truncate(task.Title, titleWidth, "…"), titleWidth)
+
+		elapsed := ""
+		if !task.Started.IsZero() {
+			elapsed = formatElapsed(task.Elapsed(now))
+		}
+		// The bar takes what is left between the title and the time.
+		if barWidth := width - tui.Width(row) - 2 - elapsedWidth; barWidth >= minBarWidth {
+			row += " " + m.bar(task, barWidth) + " " + padLeft(elapsed, elapsedWidth)
+		} else if elapsed != "" {
+			row += " " + elapsed
+		}
+		blocks[i] = []string{tui.Truncate(row, width, "")}
+
+		// A failure shows what the task printed last, or else its error.
+		tail := task.Output
+		if tail == "" && task.Err != nil {
+			tail = task.Err.Error()
+		}
+		if tail != "" && (task.State == Running || task.State == Failed) {
+			blocks[i] = append(blocks[i], output.Render(tui.Truncate(outputIndent+tail, width, "…")))
+		}
+	}
+
+	lines := visibleLines(blocks, finished, m.height-vFrame, m.inline || m.height <= 0)
+	view := m.style.Render(strings.Join(lines, "\n"))
+	if m.inline && m.done && view != "" {
+		// The program clears the line the cursor ends on when it stops.
+		view += "\n"
+	}
+	return view
+}
+
+// Widths of the parts of a task line, in cells.
+const (
+	elapsedWidth = 6
+	minBarWidth  = 6
+)
+
+// outputIndent aligns the output tails with the titles.
+const outputIndent = "  "
+
+// glyph returns the mark of the task's state: the spinner while it runs.
+func (m *Model) glyph(task Task) string {
+	theme := designsystem.Current()
+	tokens := theme.Components.TaskPanel
+	var state designsystem.TaskStateTokens
+	switch task.State {
+	case Running:
+		spinner := theme.Components.Spinner
+		frames := spinner.FramesFor(theme.Charset)
+		if len(frames) == 0 {
+			return " "
+		}
+		return tui.NewStyle().Foreground(spinner.Color).Render(frames[m.frame%len(frames)])
+	case Done:
+		state = tokens.Done
+	case Failed:
+		state = tokens.Failed
+	case Skipped:
+		state = tokens.Skipped
+	default:
+		state = tokens.Pending
+	}
+	return tui.NewStyle().Foreground(state.Color).Render(state.Glyph.For(theme.Charset))
+}
+
+// bar draws the progress of the task in width cells: a percentage bar, a
+// sweep while the amount of work is unknown, or blanks.
+func (m *Model) bar(task Task, width int) string {
+	switch {
+	case task.State == Done:
+		task.Fraction = 1
+	case task.State == Running && task.Fraction < 0:
+		return progress.Pulse(width, m.frame)
+	case task.State == Pending || task.State == Skipped || task.Fraction < 0:
+		return strings.Repeat(" ", width)
+	}
+	label := fmt.Sprintf("%3d%%", int(min(max(task.Fraction, 0), 1)*100))
+	return progress.Bar(width-len(label)-1, task.Fraction) + " " + label
+}
+
+// visibleLines returns the lines of blocks that fit in height. The blocks
+// of finished tasks scroll away first, so the ones still going stay in
+// view; all returns every line.
+func visibleLines(blocks [][]string, finished []bool, height int, all bool) []string {
+	count := 0
+	for _, block := range blocks {
+		count += len(block)
+	}
+	var lines []string
+	for i, block := range blocks {
+		if !all && count > height && finished[i] {
+			count -= len(block)
+			continue
+		}
+		lines = append(lines, block...)
+	}
+	if !all && len(lines) > height {
+		lines = lines[:max(height, 0)]
+	}
+	return lines
+}
+
+// formatElapsed returns d in at most six cells: "4.2s", "3m07s", "1h05m".
+func formatElapsed(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%.1fs", d.Seconds())
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+func padRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-tui.Width(s), 0))
+}
+
+func padLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-tui.Width(s), 0)) + s
+}
+
+// AccessibleView returns a line per change to the tasks, oldest first, so
+// plain output reads as a log.
+func (m *Model) AccessibleView() string {
+	return strings.Join(m.log, "\n")
+}
+
+// --- Inline ---
+
+// RunInline runs the panel's tasks and draws it below the command's output
+// on out until they have all finished. Without a terminal, or with plain
+// set (e.g. --no-tui), it writes a line per change instead. It returns the
+// error of the program, not of the tasks: see Tasks.
+func (m *Model) RunInline(ctx context.Context, out io.Writer, plain bool) error {
+	m.inline = true
+	_, err := tui.NewInline(ctx, out, m, tui.WithPlainLines(plain)).Wait()
+	return err
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.height = height
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
@@ -0,0 +1,261 @@
+package taskpanel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// clock is a fake clock the test moves by hand.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time          { return c.now }
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newClock() *clock {
+	return &clock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// stack returns a panel installing a stack, driven by messages.
+func stack(c *clock, opts ...Option) *Model {
+	return New([]Task{
+		NewTask("go", "Install go", nil),
+		NewTask("node", "Install node", nil),
+		NewTask("python", "Install python", nil),
+		NewTask("rust", "Install rust", nil),
+		NewTask("docker", "Install docker", nil),
+	}, append([]Option{WithClock(c.Now)}, opts...)...)
+}
+
+// send updates m with msgs, dropping their commands: the tests move the
+// clock and the spinner by hand.
+func send(m *Model, msgs ...tui.Msg) {
+	for _, msg := range msgs {
+		m.Update(msg)
+	}
+}
+
+func TestTaskPanel(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetUnicode)
+	c := newClock()
+	m := stack(c)
+	send(m, tui.WindowSizeMsg{Width: 60, Height: 10},
+		StateMsg{Task: "go", State: Running},
+		StateMsg{Task: "node", State: Running},
+		StateMsg{Task: "python", State: Running},
+		StateMsg{Task: "docker", State: Skipped})
+	c.Advance(1200 * time.Millisecond)
+	send(m,
+		ProgressMsg{Task: "go", Fraction: 1},
+		StateMsg{Task: "go", State: Done},
+		ProgressMsg{Task: "node", Fraction: 0.4},
+		OutputMsg{Task: "node", Output: "fetching node-v22.tar.gz\n"},
+		OutputMsg{Task: "python", Output: "configure: error: no C compiler\n\n"},
+		StateMsg{Task: "python", State: Failed, Err: errors.New("exit status 1")})
+	c.Advance(65 * time.Second)
+
+	tuitest.AssertGolden(t, "mixed", ansi.Strip(m.View()))
+}
+
+func TestTaskPanelScrollsFinishedTasks(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	c := newClock()
+	m := stack(c)
+	send(m, tui.WindowSizeMsg{Width: 40, Height: 3},
+		StateMsg{Task: "go", State: Done},
+		StateMsg{Task: "node", State: Done},
+		StateMsg{Task: "python", State: Running},
+		OutputMsg{Task: "python", Output: "building"})
+
+	tuitest.AssertGolden(t, "overflow", ansi.Strip(m.View()))
+}
+
+func TestTaskPanelNarrow(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	c := newClock()
+	m := stack(c)
+	send(m, tui.WindowSizeMsg{Width: 24, Height: 5}, StateMsg{Task: "go", State: Running})
+	c.Advance(3 * time.Second)
+
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	if got, want := lines[0], "| Install go     3.0s"; got != want {
+		t.Errorf("first line = %q, want %q without a bar", got, want)
+	}
+	for _, line := range lines {
+		if tui.Width(line) > 24 {
+			t.Errorf("line %q is wider than the panel", line)
+		}
+	}
+}
+
+func TestTaskStates(t *testing.T) {
+	c := newClock()
+	m := stack(c)
+
+	send(m, ProgressMsg{Task: "go", Fraction: 0.5})
+	if task, _ := m.Task("go"); task.State != Running || task.Started != c.now {
+		t.Errorf("progress left go %v, want it running since now", task.State)
+	}
+
+	c.Advance(2 * time.Second)
+	send(m, StateMsg{Task: "go", State: Done}, StateMsg{Task: "go", State: Failed})
+	task, _ := m.Task("go")
+	if task.State != Done {
+		t.Errorf("go is %v, want a finished task to stay done", task.State)
+	}
+	c.Advance(time.Minute)
+	if got := task.Elapsed(c.now); got != 2*time.Second {
+		t.Errorf("Elapsed() = %v, want the time it ran", got)
+	}
+
+	send(m, StateMsg{Task: "missing", State: Running})
+	if _, ok := m.Task("missing"); ok {
+		t.Error("a message about an unknown task added it")
+	}
+}
+
+func TestDoneMsg(t *testing.T) {
+	m := New([]Task{NewTask("a", "A", nil), NewTask("b", "B", nil)}, WithInline(true))
+	m.Update(StateMsg{Task: "a", State: Done})
+	_, cmd := m.Update(StateMsg{Task: "b", State: Failed, Err: errors.New("boom")})
+	if cmd == nil {
+		t.Fatal("finishing the last task sent nothing")
+	}
+
+	seq, ok := cmd().(tui.SequenceMsg)
+	if !ok || len(seq) != 2 {
+		t.Fatalf("command = %#v, want DoneMsg then quit", seq)
+	}
+	if done, ok := seq[0]().(DoneMsg); !ok || done.ID != m.id || done.Failed != 1 {
+		t.Errorf("first message = %#v, want DoneMsg with a failure", done)
+	}
+	if !tui.IsQuit(seq[1]()) {
+		t.Error("an inline panel did not quit once done")
+	}
+	if _, cmd := m.Update(StateMsg{Task: "b", State: Done}); cmd != nil {
+		t.Error("DoneMsg was sent twice")
+	}
+}
+
+func TestRunInlinePlain(t *testing.T) {
+	c := newClock()
+	m := New([]Task{
+		NewTask("go", "Install go", func(ctx context.Context, progress func(tui.Msg)) (tui.Msg, error) {
+			progress(OutputMsg{Output: "downloading go1.25.1"})
+			progress(ProgressMsg{Fraction: 0.5})
+			return nil, nil
+		}),
+		NewTask("rust", "Install rust", func(ctx context.Context, progress func(tui.Msg)) (tui.Msg, error) {
+			return nil, errors.New("no network")
+		}),
+		NewTask("docker", "Install docker", func(ctx context.Context, progress func(tui.Msg)) (tui.Msg, error) {
+			return StateMsg{State: Skipped}, nil
+		}),
+	}, WithClock(c.Now))
+
+	var out bytes.Buffer
+	if err := m.RunInline(context.Background(), &out, true); err != nil {
+		t.Fatalf("RunInline() = %v", err)
+	}
+
+	// The tasks run concurrently: only the lines of each task are ordered.
+	want := map[string][]string{
+		"Install go":     {"pending", "running", "downloading go1.25.1", "50%", "done (0.0s)"},
+		"Install rust":   {"pending", "running", "failed: no network (0.0s)"},
+		"Install docker": {"pending", "running", "skipped (0.0s)"},
+	}
+	got := make(map[string][]string)
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		title, event, _ := strings.Cut(line, ": ")
+		got[title] = append(got[title], event)
+	}
+	for title, events := range want {
+		if strings.Join(got[title], "|") != strings.Join(events, "|") {
+			t.Errorf("%s logged %q, want %q", title, got[title], events)
+		}
+	}
+}
+
+func TestRunInlineWithoutTasks(t *testing.T) {
+	var out bytes.Buffer
+	if err := New(nil).RunInline(context.Background(), &out, true); err != nil {
+		t.Fatalf("RunInline() = %v", err)
+	}
+}
+
+func TestFormatElapsed(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0.0s"},
+		{4200 * time.Millisecond, "4.2s"},
+		{187 * time.Second, "3m07s"},
+		{65 * time.Minute, "1h05m"},
+	}
+
+	for _, tt := range tests {
+		if got := formatElapsed(tt.d); got != tt.want {
+			t.Errorf("formatElapsed(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestInlineTasks(t *testing.T) {
+	c := newClock()
+	var out bytes.Buffer
+	ui := tui.NewInline(context.Background(), &out, New(nil, WithClock(c.Now)), tui.WithPlainLines(true))
+
+	fetch := ui.Task("Fetching index")
+	fetch.Status("mirror 1")
+	for i := 0; i <= 4; i++ {
+		fetch.Progress(i, 4)
+	}
+	fetch.Done("312 tools")
+	ui.Task("Building").Fail(errors.New("exit status 2"))
+	ui.Task("Cleaning up")
+	if err := ui.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := []string{
+		"Fetching index: running",
+		"Fetching index: mirror 1",
+		"Fetching index: 0%",
+		"Fetching index: 25%",
+		"Fetching index: 50%",
+		"Fetching index: 75%",
+		"Fetching index: 100%",
+		"Fetching index: 312 tools",
+		"Fetching index: done (0.0s)",
+		"Building: running",
+		"Building: failed: exit status 2 (0.0s)",
+		"Cleaning up: running",
+		"Cleaning up: skipped (0.0s)",
+		"",
+	}
+	if got := out.String(); got != strings.Join(want, "\n") {
+		t.Errorf("output:\n%s\nwant:\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestInlineTaskView(t *testing.T) {
+	tuitest.UseCharset(t, designsystem.CharsetASCII)
+	m := New(nil, WithClock(newClock().Now))
+	send(m, tui.WindowSizeMsg{Width: 40, Height: 5},
+		tui.InlineTaskMsg{ID: 1, Title: "Reading", Event: tui.InlineStarted},
+		tui.InlineTaskMsg{ID: 1, Title: "Reading", Event: tui.InlineDone, Text: "v1.2.0"})
+
+	done := designsystem.Current().Components.TaskPanel.Done.Glyph.ASCII
+	if got := ansi.Strip(m.View()); !strings.HasPrefix(got, done+" Reading") {
+		t.Errorf("View() = %q, want the task drawn with the theme's done glyph", got)
+	}
+}
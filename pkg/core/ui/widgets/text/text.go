@@ -0,0 +1,190 @@
+package text
+
+import (
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.TextWidget interface.
+var _ tui.TextWidget = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// Option is a functional option for configuring the Text.
+type Option func(*Model)
+
+// Model is a single run of text styled by a theme variant (h1…h6, title,
+// body, label, code). It takes the width of its text, so it fits inline
+// slots such as the AppBar title; use a TextBox to wrap or truncate.
+type Model struct {
+	width, height int // 0 means sized to the text
+	tui.ViewCache
+
+	text    string
+	variant designsystem.TextVariant
+	// style holds the widget's own styling, which wins over the variant's.
+	style tui.Style
+}
+
+// New creates a new Text showing text with the given options. The variant
+// defaults to body.
+func New(text string, opts ...Option) *Model {
+	m := &Model{
+		text:    text,
+		variant: designsystem.TextBody,
+		style:   tui.NewStyle(),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithVariant sets the typographic variant, e.g. designsystem.TextH1.
+func WithVariant(variant designsystem.TextVariant) Option {
+	return func(m *Model) { m.SetVariant(variant) }
+}
+
+// WithForeground overrides the variant's color.
+func WithForeground(color string) Option {
+	return func(m *Model) {
+		m.style = m.style.Foreground(color)
+	}
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithAlign(pos tui.Position) Option {
+	return func(m *Model) { m.Align(pos) }
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+// Update ignores window sizes: a Text is as wide as its text unless a width
+// is set.
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	return m, nil
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+}
+
+// render draws the text; View caches the result. A set width cuts the text
+// with the TextBox ellipsis rather than wrapping it, so a Text stays one run.
+func (m *Model) render() string {
+	style := m.TextStyle()
+	text := m.text
+	if m.width > 0 {
+		hFrame, _ := style.GetFrameSize()
+		inner := max(m.width-hFrame, 0)
+		text = tui.Truncate(text, inner, designsystem.Current().Components.TextBox.Ellipsis)
+		style = style.Width(inner)
+	}
+	if m.height > 0 {
+		_, vFrame := style.GetFrameSize()
+		style = style.Height(max(m.height-vFrame, 0))
+	}
+	return style.Render(text)
+}
+
+// AccessibleView returns the text without styling.
+func (m *Model) AccessibleView() string {
+	return m.text
+}
+
+// --- tui.TextWidget Implementation ---
+
+// Text returns the text shown.
+func (m *Model) Text() string {
+	return m.text
+}
+
+// SetText replaces the text shown.
+func (m *Model) SetText(text string) {
+	m.text = text
+	m.MarkDirty()
+}
+
+// Variant returns the typographic variant.
+func (m *Model) Variant() designsystem.TextVariant {
+	return m.variant
+}
+
+// SetVariant changes the typographic variant.
+func (m *Model) SetVariant(variant designsystem.TextVariant) {
+	m.variant = variant
+	m.MarkDirty()
+}
+
+// TextStyle returns the style the text is drawn with: the widget's own
+// styling over the current theme's variant.
+func (m *Model) TextStyle() tui.Style {
+	variant := designsystem.Current().Components.Text.Variant(m.variant)
+	return m.style.Inherit(variant.Apply(tui.NewStyle()))
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.width = width
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.height = height
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
@@ -0,0 +1,90 @@
+package text
+
+import (
+	"testing"
+
+	lg "github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+func TestVariants(t *testing.T) {
+	tests := []struct {
+		variant                 designsystem.TextVariant
+		bold, italic, underline bool
+		faint                   bool
+	}{
+		{variant: designsystem.TextH1, bold: true},
+		{variant: designsystem.TextH4, bold: true},
+		{variant: designsystem.TextH5},
+		{variant: designsystem.TextH6, faint: true},
+		{variant: designsystem.TextTitle, bold: true},
+		{variant: designsystem.TextBody},
+		{variant: designsystem.TextLabel, italic: true},
+		{variant: designsystem.TextCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.variant), func(t *testing.T) {
+			style := New("easyenv", WithVariant(tt.variant)).TextStyle().GetLipglossStyle()
+			if got := style.GetBold(); got != tt.bold {
+				t.Errorf("bold = %v, want %v", got, tt.bold)
+			}
+			if got := style.GetItalic(); got != tt.italic {
+				t.Errorf("italic = %v, want %v", got, tt.italic)
+			}
+			if got := style.GetUnderline(); got != tt.underline {
+				t.Errorf("underline = %v, want %v", got, tt.underline)
+			}
+			if got := style.GetFaint(); got != tt.faint {
+				t.Errorf("faint = %v, want %v", got, tt.faint)
+			}
+		})
+	}
+}
+
+func TestOwnStyleWinsOverVariant(t *testing.T) {
+	m := New("easyenv", WithVariant(designsystem.TextCode), WithForeground("1"))
+	if got := m.TextStyle().GetLipglossStyle().GetForeground(); got != lg.Color("1") {
+		t.Errorf("foreground = %v, want the widget's own color 1", got)
+	}
+}
+
+func TestTruncation(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		want  string
+	}{
+		{"sized to the text", "easyenv", 0, "easyenv"},
+		{"fits", "easyenv", 10, "easyenv   "},
+		{"cut with an ellipsis", "easyenv manager", 8, "easyenv…"},
+		{"wide characters", "日本語のテキスト", 8, "日本語… "},
+		{"no room for the text", "easyenv", 1, "…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(tt.text, WithWidth(tt.width))
+			got := ansi.Strip(m.View())
+			if got != tt.want {
+				t.Errorf("View() = %q, want %q", got, tt.want)
+			}
+			if tt.width > 0 && tui.Width(got) != tt.width {
+				t.Errorf("View() is %d cells wide, want %d", tui.Width(got), tt.width)
+			}
+		})
+	}
+}
+
+func TestSetTextRedraws(t *testing.T) {
+	m := New("before")
+	_ = m.View()
+	m.SetText("after")
+	if got := ansi.Strip(m.View()); got != "after" {
+		t.Errorf("View() after SetText = %q, want %q", got, "after")
+	}
+}
@@ -0,0 +1,277 @@
+package textbox
+
+import (
+	"strings"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+)
+
+// Ensure Model implements the tui.TextWidget interface.
+var _ tui.TextWidget = (*Model)(nil)
+var _ tui.Accessible = (*Model)(nil)
+
+// Option is a functional option for configuring the TextBox.
+type Option func(*Model)
+
+// Model is a block of text that fits the space it is given: long lines are
+// wrapped at word boundaries, or cut with an ellipsis when wrapping is off,
+// and text taller than the box ends with an ellipsis on its last line.
+// Widths are measured in terminal cells, so wide characters (CJK, emoji)
+// count as two.
+//
+// Without an explicit width the box is as wide as its longest line, up to
+// the width its parent offers; that keeps it usable as the AppBar title.
+type Model struct {
+	width, height               int
+	desiredWidth, desiredHeight int // 0 means not explicitly set
+	tui.ViewCache
+
+	text    string
+	variant designsystem.TextVariant
+	// wrap and ellipsis are nil until set, falling back to the theme.
+	wrap     *bool
+	ellipsis *string
+	style    tui.Style
+}
+
+// New creates a new TextBox showing text with the given options. Variant,
+// wrapping, ellipsis and padding default to the theme's TextBox tokens.
+func New(text string, opts ...Option) *Model {
+	tokens := designsystem.Current().Components.TextBox
+	m := &Model{
+		text:    text,
+		variant: tokens.Variant,
+		style:   tui.NewStyle().Padding(0, tokens.Padding),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// --- Functional Options ---
+
+// WithVariant sets the typographic variant, e.g. designsystem.TextLabel.
+func WithVariant(variant designsystem.TextVariant) Option {
+	return func(m *Model) { m.variant = variant }
+}
+
+// WithWrap turns word wrapping on or off. Without it, each line that does
+// not fit is truncated.
+func WithWrap(wrap bool) Option {
+	return func(m *Model) { m.wrap = &wrap }
+}
+
+// WithEllipsis sets the tail of truncated text; "" cuts it without a mark.
+func WithEllipsis(ellipsis string) Option {
+	return func(m *Model) { m.ellipsis = &ellipsis }
+}
+
+// WithForeground overrides the variant's color.
+func WithForeground(color string) Option {
+	return func(m *Model) { m.style = m.style.Foreground(color) }
+}
+
+func WithBackgroundColor(color string) Option {
+	return func(m *Model) { m.BackgroundColor(color) }
+}
+
+func WithBorder(border tui.Border, sides ...bool) Option {
+	return func(m *Model) { m.Border(border, sides...) }
+}
+
+func WithPadding(p ...int) Option {
+	return func(m *Model) { m.Padding(p...) }
+}
+
+func WithWidth(width int) Option {
+	return func(m *Model) { m.Width(width) }
+}
+
+func WithHeight(height int) Option {
+	return func(m *Model) { m.Height(height) }
+}
+
+func WithAlign(pos tui.Position) Option {
+	return func(m *Model) { m.Align(pos) }
+}
+
+// --- tui.Model Implementation ---
+
+func (m *Model) Init() tui.Cmd {
+	return nil
+}
+
+func (m *Model) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
+	switch msg := msg.(type) {
+	case tui.WindowSizeMsg:
+		// The offered space bounds the box; desired dimensions win.
+		m.width, m.height = msg.Width, msg.Height
+		if m.desiredWidth > 0 {
+			m.width = m.desiredWidth
+		}
+		if m.desiredHeight > 0 {
+			m.height = m.desiredHeight
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) View() string {
+	return m.CachedView(tui.ViewKey{Width: m.width, Height: m.height}, m.render)
+}
+
+// render lays out the text in the box; View caches the result.
+func (m *Model) render() string {
+	tokens := designsystem.Current().Components.TextBox
+	wrap, ellipsis := tokens.Wrap, tokens.Ellipsis
+	if m.wrap != nil {
+		wrap = *m.wrap
+	}
+	if m.ellipsis != nil {
+		ellipsis = *m.ellipsis
+	}
+
+	style := m.TextStyle()
+	hFrame, vFrame := style.GetFrameSize()
+
+	// Until the first size arrives, only a desired size bounds the box.
+	maxWidth, maxHeight := -1, -1
+	if m.width > 0 {
+		maxWidth = max(m.width-hFrame, 0)
+	}
+	if m.height > 0 {
+		maxHeight = max(m.height-vFrame, 0)
+	}
+
+	lines := Layout(m.text, maxWidth, maxHeight, wrap, ellipsis)
+
+	// Headings keep blank lines below them, room permitting.
+	spacing := designsystem.Current().Components.Text.Variant(m.variant).Lines - 1
+	for range spacing {
+		if maxHeight >= 0 && len(lines) >= maxHeight {
+			break
+		}
+		lines = append(lines, "")
+	}
+
+	// Lines are aligned within the widest of them, or within the desired
+	// width when one is set.
+	contentWidth := 0
+	for _, line := range lines {
+		contentWidth = max(contentWidth, tui.Width(line))
+	}
+	if m.desiredWidth > 0 {
+		contentWidth = maxWidth
+	}
+	style = style.Width(contentWidth)
+	if m.desiredHeight > 0 {
+		style = style.Height(maxHeight)
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// Layout breaks text into the lines a box of width by height cells shows.
+// Lines longer than width are wrapped at word boundaries when wrap is set,
+// or cut with ellipsis otherwise; when the lines exceed height, the last
+// one shown ends with ellipsis. A negative width or height is unbounded.
+func Layout(text string, width, height int, wrap bool, ellipsis string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case width < 0 || tui.Width(line) <= width:
+			lines = append(lines, line)
+		case wrap && width > 0:
+			lines = append(lines, strings.Split(tui.Wrap(line, width), "\n")...)
+		default:
+			lines = append(lines, tui.Truncate(line, width, ellipsis))
+		}
+	}
+
+	if height < 0 || len(lines) <= height {
+		return lines
+	}
+	lines = lines[:height]
+	if height > 0 {
+		last := strings.TrimRight(lines[height-1], " ")
+		// Make room for the ellipsis; a line that already fits keeps all of
+		// its text.
+		if width >= 0 && tui.Width(last)+tui.Width(ellipsis) > width {
+			last = tui.Truncate(last, max(width-tui.Width(ellipsis), 0), "")
+		}
+		lines[height-1] = last + ellipsis
+	}
+	return lines
+}
+
+// AccessibleView returns the whole text, unwrapped and untruncated.
+func (m *Model) AccessibleView() string {
+	return m.text
+}
+
+// --- tui.TextWidget Implementation ---
+
+// Text returns the text shown.
+func (m *Model) Text() string {
+	return m.text
+}
+
+// SetText replaces the text shown.
+func (m *Model) SetText(text string) {
+	m.text = text
+	m.MarkDirty()
+}
+
+// TextStyle returns the style the text is drawn with: the box's own
+// styling over the current theme's variant.
+func (m *Model) TextStyle() tui.Style {
+	variant := designsystem.Current().Components.Text.Variant(m.variant)
+	return m.style.Inherit(variant.Apply(tui.NewStyle()))
+}
+
+// --- tui.Component Implementation ---
+
+func (m *Model) BackgroundColor(color string) tui.Component {
+	m.style = m.style.Background(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Border(border tui.Border, sides ...bool) tui.Component {
+	m.style = m.style.Border(border, sides...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) BorderForeground(color string) tui.Component {
+	m.style = m.style.BorderForeground(color)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Padding(p ...int) tui.Component {
+	m.style = m.style.Padding(p...)
+	m.MarkDirty()
+	return m
+}
+
+func (m *Model) Width(width int) tui.Component {
+	m.desiredWidth = width
+	m.width = width
+	return m
+}
+
+func (m *Model) Height(height int) tui.Component {
+	m.desiredHeight = height
+	m.height = height
+	return m
+}
+
+func (m *Model) Align(pos tui.Position) tui.Component {
+	m.style = m.style.Align(pos)
+	m.MarkDirty()
+	return m
+}
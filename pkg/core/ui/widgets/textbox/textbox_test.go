@@ -0,0 +1,93 @@
+package textbox
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+	"github.com/DippingCode/easyenv/pkg/core/ui/designsystem"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/appbar"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/text"
+)
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		width, height int
+		wrap          bool
+		want          []string
+	}{
+		{"fits", "install go", 20, -1, true, []string{"install go"}},
+		{"unbounded", "install go and node", -1, -1, false, []string{"install go and node"}},
+		{"wraps at words", "install go and node", 10, -1, true, []string{"install go", "and node"}},
+		{"breaks long words", "golangci-lint", 8, -1, true, []string{"golangci", "-lint"}},
+		{"truncates without wrap", "install go and node", 10, -1, false, []string{"install g…"}},
+		{"keeps paragraphs", "one\ntwo", 10, -1, true, []string{"one", "two"}},
+		{"ellipsis on the last line", "install go and node now", 10, 2, true, []string{"install go", "and node…"}},
+		{"ellipsis replaces text", "aaaa bbbb cccc", 4, 2, true, []string{"aaaa", "bbb…"}},
+		{"wide characters", "日本語のテキスト", 7, -1, false, []string{"日本語…"}},
+		{"wide characters wrap", "日本語のテキスト", 8, -1, true, []string{"日本語の", "テキスト"}},
+		{"no room", "text", 0, 1, false, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Layout(tt.text, tt.width, tt.height, tt.wrap, "…")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Layout(%q, %d, %d) = %q, want %q", tt.text, tt.width, tt.height, got, tt.want)
+			}
+			for _, line := range got {
+				if tt.width >= 0 && tui.Width(line) > tt.width {
+					t.Errorf("line %q is wider than %d cells", line, tt.width)
+				}
+			}
+		})
+	}
+}
+
+func TestTextBoxAlignment(t *testing.T) {
+	box := New("a b c d e f", WithWidth(7), WithHeight(3), WithAlign(tui.Right))
+	d := tuitest.New(t, box, tuitest.WithSize(40, 10))
+	d.Golden("right")
+
+	box.SetText("日本 語のテキスト")
+	box.Align(tui.Center)
+	d.Resize(40, 10)
+	d.Golden("center-wide")
+}
+
+func TestHeadingSpacing(t *testing.T) {
+	box := New("Tools", WithVariant(designsystem.TextH1))
+	d := tuitest.New(t, box, tuitest.WithSize(20, 5))
+	if got := d.Frame(); got != "Tools\n     " {
+		t.Errorf("h1 frame = %q, want the heading and a blank line", got)
+	}
+}
+
+func TestTextWidgetsAsAppBarTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		title tui.TextWidget
+	}{
+		{"text", text.New("easyenv", text.WithVariant(designsystem.TextTitle))},
+		{"textbox", New("easyenv — manage your development environments", WithWrap(false))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bar := appbar.New(
+				appbar.WithBorder(tui.NormalBorder),
+				appbar.WithTitle(tt.title),
+				appbar.WithActions(text.New("v1.2.0", text.WithVariant(designsystem.TextLabel))),
+			)
+			d := tuitest.New(t, bar, tuitest.WithSize(40, 3))
+			d.Golden("initial")
+
+			tt.title.SetText("easyenv: tools")
+			d.Resize(40, 3)
+			d.Golden("set-text")
+		})
+	}
+}
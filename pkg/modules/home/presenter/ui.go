@@ -2,6 +2,7 @@ package presenter
 
 import (
 	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/navigation"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/appbar"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/bottombar"
 	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/containerbox"
@@ -12,6 +13,10 @@ import (
 
 // Ensure HomeView implements the ViewBox interface (which is tui.Model).
 var _ viewbox.ViewBox = (*HomeView)(nil)
+var _ tui.KeyScoper = (*HomeView)(nil)
+var _ tui.KeyHandler = (*HomeView)(nil)
+var _ tui.Disposable = (*HomeView)(nil)
+var _ tui.Accessible = (*HomeView)(nil)
 
 // HomeView is the ViewBox for the home screen.
 type HomeView struct {
@@ -27,6 +32,11 @@ func New() *HomeView {
 		sidemenu.WithBorder(tui.NormalBorder, true, true, true, true), // Borda normal em todos os lados
 		sidemenu.WithBorderForeground("#FF0000"), // Vermelho
 		sidemenu.WithAlign(tui.Top),
+		sidemenu.WithItems(
+			navigation.Route{ID: "home", Title: "Home"},
+			navigation.Route{ID: "tools", Title: "Tools"},
+			navigation.Route{ID: "settings", Title: "Settings"},
+		),
 	)
 	bottomBar := bottombar.New(
 		bottombar.WithBackgroundColor("#FF0000"), // Vermelho
@@ -73,6 +83,26 @@ func (hv *HomeView) Update(msg tui.Msg) (tui.Model, tui.Cmd) {
 	return hv, cmd
 }
 
+// KeyScopes returns the key binding scopes active on the home screen.
+func (hv *HomeView) KeyScopes() []string {
+	return hv.scaffold.KeyScopes()
+}
+
+// HandlesKey reports whether the focused widget of the home screen uses msg.
+func (hv *HomeView) HandlesKey(msg tui.KeyMsg) bool {
+	return hv.scaffold.HandlesKey(msg)
+}
+
+// Dispose releases the subscriptions held by the scaffold's slots.
+func (hv *HomeView) Dispose() {
+	hv.scaffold.Dispose()
+}
+
+// AccessibleView returns the home screen as plain text.
+func (hv *HomeView) AccessibleView() string {
+	return hv.scaffold.AccessibleView()
+}
+
 // View renders the HomeView.
 func (hv *HomeView) View() string {
 	return hv.scaffold.View()
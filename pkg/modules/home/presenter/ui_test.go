@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"testing"
+
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui/tuitest"
+)
+
+func TestHomeView(t *testing.T) {
+	sizes := []struct {
+		name          string
+		width, height int
+	}{
+		{"80x24", 80, 24},
+		{"120x40", 120, 40},
+	}
+
+	for _, size := range sizes {
+		t.Run(size.name, func(t *testing.T) {
+			d := tuitest.New(t, New(), tuitest.WithSize(size.width, size.height))
+			d.Golden("frame")
+		})
+	}
+}
+
+func TestHomeViewFollowsSideMenuNavigation(t *testing.T) {
+	shiftTab, err := tui.ParseKey("shift+tab")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	home := New()
+	t.Cleanup(home.Dispose)
+
+	d := tuitest.New(t, home, tuitest.WithSize(80, 24))
+	d.Send(shiftTab).Press(tui.KeyDown, tui.KeyEnter)
+	d.Golden("tools")
+}
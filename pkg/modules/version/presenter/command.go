@@ -1,8 +1,12 @@
 package presenter
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
 
+	"github.com/DippingCode/easyenv/pkg/core/adapters/tui"
+	"github.com/DippingCode/easyenv/pkg/core/ui/widgets/taskpanel"
 	"github.com/DippingCode/easyenv/pkg/modules/version/data/services"
 	"github.com/DippingCode/easyenv/pkg/modules/version/domain/usecases"
 )
@@ -18,15 +22,27 @@ var VersionCmd = &cobra.Command{
 		service := services.NewVersionService()
 		usecase := usecases.NewVersionUseCase(service)
 
+		// Progress goes to stderr, so scripts can still parse stdout. It is
+		// only shown while the changelog is read, and kept if that fails.
+		noTUI, _ := cmd.Flags().GetBool("no-tui")
+		ui := tui.NewInline(cmd.Context(), cmd.ErrOrStderr(), taskpanel.New(nil), tui.WithPlainLines(noTUI), tui.WithTransient(true))
+		task := ui.Task("Reading CHANGELOG.md")
+
 		version, err := usecase.GetVersion()
 		if err != nil {
+			task.Fail(err)
+			return errors.Join(err, ui.Close())
+		}
+		task.Done("v" + version.Number)
+		if err := ui.Close(); err != nil {
 			return err
 		}
 
+		out := cmd.OutOrStdout()
 		if detailed {
-			PrintDetailedVersion(version) // Call the function from ui.go
+			PrintDetailedVersion(out, version) // Call the function from ui.go
 		} else {
-			PrintVersion(version) // Call the function from ui.go
+			PrintVersion(out, version) // Call the function from ui.go
 		}
 
 		return nil